@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kkrt-labs/go-utils/common"
+	store "github.com/kkrt-labs/go-utils/store"
+	inputstore "github.com/kkrt-labs/zk-pig/src/store"
+	"github.com/spf13/cobra"
+)
+
+// NewConvertCommand creates and returns the convert command
+func NewConvertCommand(rootCtx *RootContext) *cobra.Command {
+	var (
+		blockNumber       uint64
+		blockCount        uint64
+		toContentType     string
+		toContentEncoding string
+		preflight         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Convert stored prover inputs to another content type or content encoding",
+		Long:  "Convert stored prover inputs (or preflight data) of a range of blocks from the configured content type (--inputs-content-type) and content encoding (--store-content-encoding) to the given ones. Every converted prover input is loaded back and checked to be equal to the original one. It runs off-line and needs --chain-id to be provided",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			chainID := rootCtx.App.ChainID()
+			if chainID == nil {
+				return fmt.Errorf("chain ID is required (use --chain-id)")
+			}
+			if blockCount == 0 {
+				return fmt.Errorf("block count must be positive")
+			}
+
+			dstContentType, err := inputstore.ParseContentType(toContentType)
+			if err != nil {
+				return err
+			}
+			dstContentEncoding, err := store.ParseContentEncoding(toContentEncoding)
+			if err != nil {
+				return err
+			}
+
+			cfg := rootCtx.Config
+			srcContentType, srcContentEncoding := common.Val(cfg.ProverInputs.ContentType), common.Val(cfg.Store.ContentEncoding)
+			srcFormat, dstFormat := srcContentType.String(), dstContentType.String()
+			if preflight {
+				// Preflight data is encoded in protobuf or JSON only
+				srcFormat, dstFormat = inputstore.PreflightContentType(srcContentType).String(), inputstore.PreflightContentType(dstContentType).String()
+			}
+			if srcFormat == dstFormat && srcContentEncoding == dstContentEncoding {
+				return fmt.Errorf("source and destination formats are identical")
+			}
+
+			srcStore := rootCtx.App.StoreWithContentEncoding(srcContentEncoding)
+			dstStore := rootCtx.App.StoreWithContentEncoding(dstContentEncoding)
+
+			from, to := blockNumber, blockNumber+blockCount-1
+			var converted []uint64
+			if preflight {
+				src, err := inputstore.NewPreflightDataStore(srcStore, inputstore.PreflightContentType(srcContentType))
+				if err != nil {
+					return err
+				}
+				dst, err := inputstore.NewPreflightDataStore(dstStore, inputstore.PreflightContentType(dstContentType))
+				if err != nil {
+					return err
+				}
+				converted, err = inputstore.ConvertPreflightData(cmd.Context(), src, dst, chainID.Uint64(), from, to)
+			} else {
+				src := inputstore.NewProverInputStore(srcStore, srcContentType)
+				dst := inputstore.NewProverInputStore(dstStore, dstContentType)
+				converted, err = inputstore.ConvertProverInputs(cmd.Context(), src, dst, chainID.Uint64(), from, to)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Converted %d of %d blocks from %s (%s) to %s (%s)\n",
+				len(converted), blockCount,
+				srcFormat, srcContentEncoding,
+				dstFormat, dstContentEncoding,
+			)
+			return err
+		},
+	}
+
+	cmd.Flags().Uint64VarP(&blockNumber, "block-number", "b", 0, "First block number")
+	cmd.Flags().Uint64Var(&blockCount, "block-count", 1, "Number of consecutive blocks to convert, starting at block number")
+	cmd.Flags().StringVar(&toContentType, "to-content-type", "application/protobuf", "Content type to convert to (e.g. \"application/json\" \"application/protobuf\" \"application/execution-witness+json\" \"application/ssz\" \"application/protobuf-stream\")")
+	cmd.Flags().StringVar(&toContentEncoding, "to-content-encoding", "plain", "Content encoding to convert to (e.g. \"plain\" \"gzip\" \"zlib\" \"flate\")")
+	cmd.Flags().BoolVar(&preflight, "preflight", false, "Convert preflight data instead of prover inputs (preflight data is encoded in protobuf for protobuf content types, and in JSON otherwise)")
+	_ = cmd.MarkFlagRequired("block-number")
+
+	return cmd
+}
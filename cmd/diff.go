@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	inputstore "github.com/kkrt-labs/zk-pig/src/store"
+	"github.com/spf13/cobra"
+)
+
+// NewDiffCommand creates and returns the diff command
+func NewDiffCommand(_ *RootContext) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "diff <file-a> <file-b>",
+		Short: "Compare two prover input files",
+		Long:  "Compare two prover input files semantically (after canonical ordering): blocks, missing/extra state nodes located in their trie, codes, ancestors, chain config and extra data. Missing data is present in the first file only, and extra data in the second file only. It detects the files format and compression, runs off-line, and fails if the prover inputs differ",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true // differing prover inputs is not a usage error
+
+			a, err := readProverInputFile(args[0])
+			if err != nil {
+				return err
+			}
+			b, err := readProverInputFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			diff, err := input.Compare(a, b)
+			if err != nil {
+				return fmt.Errorf("failed to compare prover inputs: %w", err)
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(diff); err != nil {
+					return err
+				}
+			} else {
+				printDiff(cmd.OutOrStdout(), diff)
+			}
+
+			if !diff.Equal() {
+				return fmt.Errorf("prover inputs differ")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the differences in JSON")
+
+	return cmd
+}
+
+func readProverInputFile(path string) (*input.ProverInput, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prover input file: %w", err)
+	}
+	in, _, err := inputstore.DecodeProverInput(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode prover input %s: %w", path, err)
+	}
+	return in, nil
+}
+
+func printDiff(w io.Writer, d *input.Diff) {
+	if d.Equal() {
+		fmt.Fprintln(w, "Prover inputs are equal")
+		return
+	}
+
+	if d.Version != nil {
+		printValueDiffs(w, "Version", []*input.ValueDiff{d.Version})
+	}
+	printValueDiffs(w, "Blocks", d.Blocks)
+
+	if len(d.State) > 0 {
+		fmt.Fprintf(w, "State nodes (%d):\n", len(d.State))
+		for _, node := range d.State {
+			location := node.Trie
+			switch {
+			case node.Address != nil:
+				location = fmt.Sprintf("%s %s", node.Trie, node.Address.Hex())
+			case node.Account != nil:
+				location = fmt.Sprintf("%s %s", node.Trie, node.Account.Hex())
+			}
+			if node.Trie != input.TrieUnreachable {
+				location = fmt.Sprintf("%s path=%q", location, node.Path)
+			}
+			fmt.Fprintf(w, "  %s %s %s (%d bytes)\n", diffSign(node.Kind), node.Hash.Hex(), location, node.Size)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(d.Codes) > 0 {
+		fmt.Fprintf(w, "Codes (%d):\n", len(d.Codes))
+		for _, code := range d.Codes {
+			fmt.Fprintf(w, "  %s %s (%d bytes)\n", diffSign(code.Kind), code.CodeHash.Hex(), code.Size)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(d.Ancestors) > 0 {
+		fmt.Fprintf(w, "Ancestors (%d):\n", len(d.Ancestors))
+		for _, ancestor := range d.Ancestors {
+			fmt.Fprintf(w, "  %s %d %s\n", diffSign(ancestor.Kind), ancestor.Number, ancestor.Hash.Hex())
+		}
+		fmt.Fprintln(w)
+	}
+
+	printValueDiffs(w, "Chain config", d.ChainConfig)
+	printValueDiffs(w, "Extra", d.Extra)
+}
+
+func printValueDiffs(w io.Writer, title string, diffs []*input.ValueDiff) {
+	if len(diffs) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s (%d):\n", title, len(diffs))
+	for _, diff := range diffs {
+		fmt.Fprintf(w, "  ~ %s: %s -> %s\n", diff.Path, jsonString(diff.A), jsonString(diff.B))
+	}
+	fmt.Fprintln(w)
+}
+
+func jsonString(v any) string {
+	if v == nil {
+		return "<absent>"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+func diffSign(kind input.DiffKind) string {
+	if kind == input.DiffMissing {
+		return "-"
+	}
+	return "+"
+}
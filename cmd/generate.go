@@ -18,6 +18,7 @@ func NewGenerateCommand(rootCtx *RootContext) *cobra.Command {
 	var (
 		ctx         = &ProverInputContext{RootContext: rootCtx}
 		blockNumber string
+		blockCount  uint64
 	)
 
 	cmd := &cobra.Command{
@@ -35,12 +36,13 @@ func NewGenerateCommand(rootCtx *RootContext) *cobra.Command {
 				return err
 			}
 
-			_, err = generator.Generate(cmd.Context(), ctx.blockNumber)
+			_, err = generator.GenerateRange(cmd.Context(), ctx.blockNumber, blockCount)
 			return err
 		},
 	}
 
 	cmd.Flags().StringVarP(&blockNumber, "block-number", "b", "latest", "Block number")
+	cmd.Flags().Uint64Var(&blockCount, "block-count", 1, "Number of consecutive blocks, starting at block number, to cover in a single prover input")
 
 	return cmd
 }
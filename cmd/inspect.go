@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	inputstore "github.com/kkrt-labs/zk-pig/src/store"
+	"github.com/spf13/cobra"
+)
+
+// NewInspectCommand creates and returns the inspect command
+func NewInspectCommand(_ *RootContext) *cobra.Command {
+	var (
+		jsonOutput bool
+		top        int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "inspect <file>",
+		Short: "Print a summary of a prover input file",
+		Long:  "Print a summary of a prover input file: transactions by type, witness nodes split between the account trie and each storage trie, largest contracts, ancestors and extra sections. It detects the file format and compression, and runs off-line",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if top < 0 {
+				return fmt.Errorf("top must not be negative")
+			}
+
+			b, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read prover input file: %w", err)
+			}
+
+			in, format, err := inputstore.DecodeProverInput(b)
+			if err != nil {
+				return fmt.Errorf("failed to decode prover input: %w", err)
+			}
+
+			summary, err := input.Summarize(in)
+			if err != nil {
+				return fmt.Errorf("failed to summarize prover input: %w", err)
+			}
+			if top > 0 {
+				summary.Witness.StorageTries = summary.Witness.StorageTries[:min(top, len(summary.Witness.StorageTries))]
+				summary.LargestContracts = summary.LargestContracts[:min(top, len(summary.LargestContracts))]
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(summary)
+			}
+			printSummary(cmd.OutOrStdout(), args[0], format, len(b), summary)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the summary in JSON")
+	cmd.Flags().IntVar(&top, "top", 10, "Number of largest storage tries and contracts to print (0 for all)")
+
+	return cmd
+}
+
+func printSummary(w io.Writer, path string, format *inputstore.Format, size int, s *input.Summary) {
+	fmt.Fprintf(w, "File:    %s (%d bytes)\n", path, size)
+	fmt.Fprintf(w, "Format:  %s\n", format)
+	fmt.Fprintf(w, "Version: %q\n", s.Version)
+	fmt.Fprintf(w, "Blocks:  %d-%d (%d)\n", s.FirstBlock, s.LastBlock, s.LastBlock-s.FirstBlock+1)
+
+	fmt.Fprintf(w, "\nTransactions: %d\n", s.Transactions)
+	txTypes := make([]string, 0, len(s.TransactionsByType))
+	for txType := range s.TransactionsByType {
+		txTypes = append(txTypes, txType)
+	}
+	sort.Strings(txTypes)
+	for _, txType := range txTypes {
+		fmt.Fprintf(w, "  %-12s %d\n", txType, s.TransactionsByType[txType])
+	}
+
+	ws := s.Witness
+	fmt.Fprintf(w, "\nWitness state: %d nodes, %d bytes\n", ws.Nodes, ws.Bytes)
+	fmt.Fprintf(w, "  %-68s %8d nodes %10d bytes\n", "account trie", ws.AccountTrie.Nodes, ws.AccountTrie.Bytes)
+	for _, storageTrie := range ws.StorageTries {
+		owner := storageTrie.Account.Hex()
+		if storageTrie.Address != nil {
+			owner = storageTrie.Address.Hex()
+		}
+		fmt.Fprintf(w, "  %-68s %8d nodes %10d bytes\n", "storage "+owner, storageTrie.Nodes, storageTrie.Bytes)
+	}
+	if ws.Unreachable.Nodes > 0 {
+		fmt.Fprintf(w, "  %-68s %8d nodes %10d bytes\n", "unreachable", ws.Unreachable.Nodes, ws.Unreachable.Bytes)
+	}
+
+	fmt.Fprintf(w, "\nWitness codes: %d codes, %d bytes\n", ws.Codes, ws.CodeBytes)
+	for _, contract := range s.LargestContracts {
+		fmt.Fprintf(w, "  %s %10d bytes", contract.CodeHash.Hex(), contract.Size)
+		for _, addr := range contract.Addresses {
+			fmt.Fprintf(w, " %s", addr.Hex())
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "\nAncestors: %d (depth %d)\n", s.Ancestors, s.AncestorDepth)
+	if len(s.Extra) == 0 {
+		fmt.Fprintln(w, "Extra:     none")
+	} else {
+		fmt.Fprintf(w, "Extra:     %v\n", s.Extra)
+	}
+}
@@ -58,6 +58,10 @@ func NewZkPigCommand() *cobra.Command {
 	rootCmd.AddCommand(NewPrepareCommand(ctx))
 	rootCmd.AddCommand(NewExecuteCommand(ctx))
 	rootCmd.AddCommand(NewRunCommand(ctx))
+	rootCmd.AddCommand(NewValidateCommand(ctx))
+	rootCmd.AddCommand(NewConvertCommand(ctx))
+	rootCmd.AddCommand(NewInspectCommand(ctx))
+	rootCmd.AddCommand(NewDiffCommand(ctx))
 	rootCmd.AddCommand(NewConfigCommand(ctx))
 
 	return rootCmd
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/kkrt-labs/zk-pig/src/steps"
+	inputstore "github.com/kkrt-labs/zk-pig/src/store"
+	"github.com/spf13/cobra"
+)
+
+// NewValidateCommand creates and returns the validate command
+func NewValidateCommand(rootCtx *RootContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate <file>",
+		Short: "Validate a prover input file",
+		Long:  "Validate a prover input file. It detects the file format (JSON, execution witness JSON, SSZ, protobuf or protobuf stream) and compression, checks the prover input structural invariants and executes the blocks, checking that the code of every executed account is present. It runs off-line and does not require a store to be configured",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true // a failed validation is not a usage error
+
+			b, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read prover input file: %w", err)
+			}
+
+			in, format, err := inputstore.DecodeProverInput(b)
+			if err != nil {
+				return fmt.Errorf("failed to decode prover input: %w", err)
+			}
+
+			report := rootCtx.App.Validator().Validate(cmd.Context(), in)
+			printValidationReport(cmd.OutOrStdout(), args[0], format, in, report)
+			if !report.Passed() {
+				return fmt.Errorf("prover input is invalid")
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func printValidationReport(w io.Writer, path string, format *inputstore.Format, in *input.ProverInput, report *steps.ValidationReport) {
+	fmt.Fprintf(w, "File:    %s\n", path)
+	fmt.Fprintf(w, "Format:  %s\n", format)
+	fmt.Fprintf(w, "Version: %q\n", in.Version)
+	if len(in.Blocks) > 0 && in.Blocks[0] != nil && in.Blocks[0].Header != nil {
+		first, last := in.Blocks[0].Header, in.Blocks[len(in.Blocks)-1].Header
+		fmt.Fprintf(w, "Blocks:  %v-%v (%d)\n", first.Number, last.Number, len(in.Blocks))
+	}
+	fmt.Fprintln(w)
+
+	for _, check := range report.Checks {
+		switch {
+		case check.Err == nil:
+			fmt.Fprintf(w, "PASS  %s\n", check.Name)
+		case errors.Is(check.Err, steps.ErrCheckSkipped):
+			fmt.Fprintf(w, "SKIP  %s\n", check.Name)
+		default:
+			fmt.Fprintf(w, "FAIL  %s: %v\n", check.Name, check.Err)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if report.Passed() {
+		fmt.Fprintln(w, "Result: PASS")
+	} else {
+		fmt.Fprintln(w, "Result: FAIL")
+	}
+}
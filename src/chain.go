@@ -6,11 +6,13 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/kkrt-labs/go-utils/app"
 	ethrpc "github.com/kkrt-labs/go-utils/ethereum/rpc"
 	ethjsonrpc "github.com/kkrt-labs/go-utils/ethereum/rpc/jsonrpc"
 	jsonrpc "github.com/kkrt-labs/go-utils/jsonrpc"
 	jsonrpcmrgd "github.com/kkrt-labs/go-utils/jsonrpc/merged"
+	"github.com/kkrt-labs/zk-pig/src/ethereum"
 )
 
 var (
@@ -23,9 +25,42 @@ func (a *App) ChainID() *big.Int {
 		return a.chainID()
 	}
 
+	if genesis := a.ChainGenesis(); genesis != nil {
+		return genesis.Config.ChainID
+	}
+
+	return nil
+}
+
+// ChainGenesis returns the genesis of the custom chain configured with a genesis file (if any)
+// The custom chain is registered so its chain config and genesis are used by preflight, prepare and execute
+func (a *App) ChainGenesis() *core.Genesis {
+	if a.Config().Chain.Genesis != nil {
+		return a.chainGenesis()
+	}
+
 	return nil
 }
 
+func (a *App) chainGenesis() *core.Genesis {
+	return provide(
+		a,
+		fmt.Sprintf("%s.genesis", chainComponentName),
+		func() (*core.Genesis, error) {
+			genesis, err := ethereum.LoadGenesis(*a.Config().Chain.Genesis)
+			if err != nil {
+				return nil, err
+			}
+
+			err = ethereum.RegisterChain(genesis)
+			if err != nil {
+				return nil, fmt.Errorf("failed to register custom chain: %v", err)
+			}
+
+			return genesis, nil
+		})
+}
+
 func (a *App) chainID() *big.Int {
 	return provide(
 		a,
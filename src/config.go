@@ -9,7 +9,9 @@ import (
 	"github.com/kkrt-labs/go-utils/common"
 	"github.com/kkrt-labs/go-utils/config"
 	store "github.com/kkrt-labs/go-utils/store"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/evm"
 	"github.com/kkrt-labs/zk-pig/src/steps"
+	inputstore "github.com/kkrt-labs/zk-pig/src/store"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -21,8 +23,8 @@ func init() {
 				return data, nil
 			}
 
-			if t == reflect.TypeOf(store.ContentType(0)) {
-				return store.ParseContentType(data.(string))
+			if t == reflect.TypeOf(inputstore.ContentType(0)) {
+				return inputstore.ParseContentType(data.(string))
 			}
 
 			if t == reflect.TypeOf(store.ContentEncoding(0)) {
@@ -33,6 +35,10 @@ func init() {
 				return steps.ParseIncludes(strings.Split(data.(string), ",")...)
 			}
 
+			if t == reflect.TypeOf(evm.TraceLevel(0)) {
+				return evm.ParseTraceLevel(data.(string))
+			}
+
 			return data, nil
 		},
 	)
@@ -59,12 +65,20 @@ func DefaultConfig() *Config {
 			RPC: &ChainRPCConfig{},
 		},
 		ProverInputs: &ProverInputsConfig{
-			ContentType: common.Ptr(store.ContentTypeJSON),
+			ContentType: common.Ptr(inputstore.ContentTypeJSON),
 		},
 		Generator: &GeneratorConfig{
 			StorePreflightData: common.Ptr(false),
 			FilterModulo:       common.Ptr(uint64(5)),
 			IncludeExtensions:  common.Ptr(steps.IncludeAll),
+			MinimizeWitness:    common.Ptr(false),
+			CheckReceipts:      common.Ptr(false),
+			TraceTransactions:  common.Ptr(false),
+			Tracing: &TracingConfig{
+				Preflight: DefaultTracerConfig(),
+				Prepare:   DefaultTracerConfig(),
+				Execute:   DefaultTracerConfig(),
+			},
 		},
 	}
 }
@@ -113,8 +127,9 @@ func AddFlags(v *viper.Viper, f *pflag.FlagSet) error {
 }
 
 type ChainConfig struct {
-	ID  *string         `key:"id,omitempty" desc:"Chain ID (decimal)"`
-	RPC *ChainRPCConfig `key:"rpc,omitempty"`
+	ID      *string         `key:"id,omitempty" desc:"Chain ID (decimal)"`
+	Genesis *string         `key:"genesis,omitempty" desc:"Path to a genesis.json file defining a custom chain (chain config and genesis)"`
+	RPC     *ChainRPCConfig `key:"rpc,omitempty"`
 }
 
 type ChainRPCConfig struct {
@@ -150,11 +165,68 @@ type CredentialsConfig struct {
 }
 
 type ProverInputsConfig struct {
-	ContentType *store.ContentType `key:"content-type" env:"CONTENT_TYPE" flag:"content-type" desc:"Content type (e.g. json)"`
+	ContentType *inputstore.ContentType `key:"content-type" env:"CONTENT_TYPE" flag:"content-type" desc:"Content type (e.g. \"application/json\" \"application/protobuf\" \"application/execution-witness+json\" \"application/ssz\" \"application/protobuf-stream\")"`
 }
 
 type GeneratorConfig struct {
 	StorePreflightData *bool          `key:"store-preflight-data" env:"STORE_PREFLIGHT_DATA" flag:"store-preflight-data" desc:"Store intermediate preflight data when generating prover inputs"`
-	IncludeExtensions  *steps.Include `key:"include" env:"INCLUDE_EXTENSIONS" flag:"include-extensions" desc:"Optionnal extended data to include in the generated prover input (e.g. \"accessList\" \"preState\" \"stateDiffs\" \"committed\" \"all\")"`
+	IncludeExtensions  *steps.Include `key:"include" env:"INCLUDE_EXTENSIONS" flag:"include-extensions" desc:"Optionnal extended data to include in the generated prover input (e.g. \"accessList\" \"preState\" \"stateDiffs\" \"committed\" \"provingCost\" \"senders\" \"receipts\" \"txStateDiffs\" \"preimages\" \"all\")"`
 	FilterModulo       *uint64        `key:"filter-modulo" env:"FILTER_MODULO" flag:"filter-modulo" desc:"Generate prover input for blocks which number is divisible by the given modulo"`
+	MinimizeWitness    *bool          `key:"minimize-witness" env:"MINIMIZE_WITNESS" flag:"minimize-witness" desc:"Minimize the prover input witness by dropping data that is never resolved during block execution"`
+	CheckReceipts      *bool          `key:"check-receipts" env:"CHECK_RECEIPTS" flag:"check-receipts" desc:"Cross-check execution receipts against the chain RPC node receipts (eth_getBlockReceipts)"`
+	TraceTransactions  *bool          `key:"trace-transactions" env:"TRACE_TRANSACTIONS" flag:"trace-transactions" desc:"Export an EIP-3155 struct log trace of every transaction next to the generated prover input"`
+	Tracing            *TracingConfig `key:"tracing" env:"TRACING" flag:"tracing"`
+}
+
+// TracingConfig configures EVM execution logging for each step
+type TracingConfig struct {
+	Preflight *TracerConfig `key:"preflight" env:"PREFLIGHT" flag:"preflight"`
+	Prepare   *TracerConfig `key:"prepare" env:"PREPARE" flag:"prepare"`
+	Execute   *TracerConfig `key:"execute" env:"EXECUTE" flag:"execute"`
+}
+
+// TracerConfig configures the level at which each category of EVM events is logged
+type TracerConfig struct {
+	Tx         *evm.TraceLevel `key:"tx" env:"TX" flag:"tx" desc:"Log level of transactions and system calls (e.g. \"off\" \"debug\" \"info\" \"warn\")"`
+	CallFrames *evm.TraceLevel `key:"call-frames" env:"CALL_FRAMES" flag:"call-frames" desc:"Log level of EVM call frames (e.g. \"off\" \"debug\" \"info\" \"warn\")"`
+	Opcodes    *evm.TraceLevel `key:"opcodes" env:"OPCODES" flag:"opcodes" desc:"Log level of executed opcodes (e.g. \"off\" \"debug\" \"info\" \"warn\")"`
+	Faults     *evm.TraceLevel `key:"faults" env:"FAULTS" flag:"faults" desc:"Log level of opcode faults (e.g. \"off\" \"debug\" \"info\" \"warn\")"`
+	Storage    *evm.TraceLevel `key:"storage" env:"STORAGE" flag:"storage" desc:"Log level of storage reads and writes (e.g. \"off\" \"debug\" \"info\" \"warn\")"`
+}
+
+// DefaultTracerConfig returns the default tracer configuration
+func DefaultTracerConfig() *TracerConfig {
+	def := evm.DefaultLoggerTracerConfig()
+	return &TracerConfig{
+		Tx:         common.Ptr(def.Tx),
+		CallFrames: common.Ptr(def.CallFrames),
+		Opcodes:    common.Ptr(def.Opcodes),
+		Faults:     common.Ptr(def.Faults),
+		Storage:    common.Ptr(def.Storage),
+	}
+}
+
+// LoggerTracerConfig returns the logger tracer configuration (unset levels take their default value)
+func (cfg *TracerConfig) LoggerTracerConfig() *evm.LoggerTracerConfig {
+	loggerCfg := evm.DefaultLoggerTracerConfig()
+	if cfg == nil {
+		return loggerCfg
+	}
+
+	for _, level := range []struct {
+		src *evm.TraceLevel
+		dst *evm.TraceLevel
+	}{
+		{cfg.Tx, &loggerCfg.Tx},
+		{cfg.CallFrames, &loggerCfg.CallFrames},
+		{cfg.Opcodes, &loggerCfg.Opcodes},
+		{cfg.Faults, &loggerCfg.Faults},
+		{cfg.Storage, &loggerCfg.Storage},
+	} {
+		if level.src != nil {
+			*level.dst = *level.src
+		}
+	}
+
+	return loggerCfg
 }
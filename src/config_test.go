@@ -11,7 +11,9 @@ import (
 	"github.com/kkrt-labs/go-utils/log"
 	kkrthttp "github.com/kkrt-labs/go-utils/net/http"
 	store "github.com/kkrt-labs/go-utils/store"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/evm"
 	"github.com/kkrt-labs/zk-pig/src/steps"
+	inputstore "github.com/kkrt-labs/zk-pig/src/store"
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,6 +47,7 @@ func TestViperConfig(t *testing.T) {
 	v.Set("app.start-timeout", "10s")
 	v.Set("app.stop-timeout", "20s")
 	v.Set("chain.id", "1")
+	v.Set("chain.genesis", "genesis.json")
 	v.Set("chain.rpc.url", "https://test.com")
 	v.Set("store.file.dir", "testdata")
 	v.Set("store.s3.provider.region", "us-east-1")
@@ -57,6 +60,12 @@ func TestViperConfig(t *testing.T) {
 	v.Set("generator.store-preflight-data", "true")
 	v.Set("generator.filter-modulo", "15")
 	v.Set("generator.include", "preState,accessList")
+	v.Set("generator.minimize-witness", "true")
+	v.Set("generator.check-receipts", "true")
+	v.Set("generator.trace-transactions", "true")
+	v.Set("generator.tracing.preflight.opcodes", "debug")
+	v.Set("generator.tracing.prepare.storage", "info")
+	v.Set("generator.tracing.execute.tx", "off")
 
 	cfg := new(Config)
 	err := cfg.Unmarshal(v)
@@ -109,7 +118,8 @@ func TestViperConfig(t *testing.T) {
 			StopTimeout:  common.Ptr("20s"),
 		},
 		Chain: &ChainConfig{
-			ID: common.Ptr("1"),
+			ID:      common.Ptr("1"),
+			Genesis: common.Ptr("genesis.json"),
 			RPC: &ChainRPCConfig{
 				URL: common.Ptr("https://test.com"),
 			},
@@ -132,12 +142,20 @@ func TestViperConfig(t *testing.T) {
 			ContentEncoding: common.Ptr(store.ContentEncodingGzip),
 		},
 		ProverInputs: &ProverInputsConfig{
-			ContentType: common.Ptr(store.ContentTypeProtobuf),
+			ContentType: common.Ptr(inputstore.ContentTypeProtobuf),
 		},
 		Generator: &GeneratorConfig{
 			StorePreflightData: common.Ptr(true),
 			FilterModulo:       common.Ptr(uint64(15)),
 			IncludeExtensions:  common.Ptr(steps.IncludePreState | steps.IncludeAccessList),
+			MinimizeWitness:    common.Ptr(true),
+			CheckReceipts:      common.Ptr(true),
+			TraceTransactions:  common.Ptr(true),
+			Tracing: &TracingConfig{
+				Preflight: &TracerConfig{Opcodes: common.Ptr(evm.TraceLevelDebug)},
+				Prepare:   &TracerConfig{Storage: common.Ptr(evm.TraceLevelInfo)},
+				Execute:   &TracerConfig{Tx: common.Ptr(evm.TraceLevelOff)},
+			},
 		},
 	}
 	assert.Equal(t, expectedCfg, cfg)
@@ -191,7 +209,8 @@ func TestEnv(t *testing.T) {
 			StopTimeout:  common.Ptr("20s"),
 		},
 		Chain: &ChainConfig{
-			ID: common.Ptr("1"),
+			ID:      common.Ptr("1"),
+			Genesis: common.Ptr("genesis.json"),
 			RPC: &ChainRPCConfig{
 				URL: common.Ptr("https://test.com"),
 			},
@@ -214,12 +233,20 @@ func TestEnv(t *testing.T) {
 			ContentEncoding: common.Ptr(store.ContentEncodingGzip),
 		},
 		ProverInputs: &ProverInputsConfig{
-			ContentType: common.Ptr(store.ContentTypeProtobuf),
+			ContentType: common.Ptr(inputstore.ContentTypeProtobuf),
 		},
 		Generator: &GeneratorConfig{
 			StorePreflightData: common.Ptr(true),
 			FilterModulo:       common.Ptr(uint64(15)),
 			IncludeExtensions:  common.Ptr(steps.IncludePreState | steps.IncludeAccessList),
+			MinimizeWitness:    common.Ptr(true),
+			CheckReceipts:      common.Ptr(true),
+			TraceTransactions:  common.Ptr(true),
+			Tracing: &TracingConfig{
+				Preflight: &TracerConfig{Opcodes: common.Ptr(evm.TraceLevelDebug)},
+				Prepare:   &TracerConfig{Storage: common.Ptr(evm.TraceLevelInfo)},
+				Execute:   &TracerConfig{Tx: common.Ptr(evm.TraceLevelOff)},
+			},
 		},
 	}).Env()
 	require.NoError(t, err)
@@ -250,6 +277,7 @@ func TestEnv(t *testing.T) {
 		"START_TIMEOUT":                            "10s",
 		"STOP_TIMEOUT":                             "20s",
 		"CHAIN_ID":                                 "1",
+		"CHAIN_GENESIS":                            "genesis.json",
 		"CHAIN_RPC_URL":                            "https://test.com",
 		"STORE_FILE_DIR":                           "testdata",
 		"STORE_AWS_S3_PROVIDER_REGION":             "us-east-1",
@@ -262,6 +290,12 @@ func TestEnv(t *testing.T) {
 		"STORE_PREFLIGHT_DATA":                     "true",
 		"FILTER_MODULO":                            "15",
 		"INCLUDE_EXTENSIONS":                       "accessList,preState",
+		"MINIMIZE_WITNESS":                         "true",
+		"CHECK_RECEIPTS":                           "true",
+		"TRACE_TRANSACTIONS":                       "true",
+		"TRACING_PREFLIGHT_OPCODES":                "debug",
+		"TRACING_PREPARE_STORAGE":                  "info",
+		"TRACING_EXECUTE_TX":                       "off",
 	}, env)
 }
 
@@ -272,8 +306,10 @@ func TestFlagsUsage(t *testing.T) {
 	err := AddFlags(v, set)
 	require.NoError(t, err)
 
-	expectedUsage := `      --chain-id string                                   Chain ID (decimal) [env: CHAIN_ID]
+	expectedUsage := `      --chain-genesis string                              Path to a genesis.json file defining a custom chain (chain config and genesis) [env: CHAIN_GENESIS]
+      --chain-id string                                   Chain ID (decimal) [env: CHAIN_ID]
       --chain-rpc-url string                              Chain JSON-RPC URL [env: CHAIN_RPC_URL]
+      --check-receipts                                    Cross-check execution receipts against the chain RPC node receipts (eth_getBlockReceipts) [env: CHECK_RECEIPTS]
   -c, --config strings                                     [env: CONFIG] (default [config.yaml,config.yml])
       --filter-modulo uint                                Generate prover input for blocks which number is divisible by the given modulo [env: FILTER_MODULO] (default 5)
       --healthz-ep-addr string                            healthz entrypoint: TCP Address to listen on [env: HEALTHZ_EP_ADDR] (default ":8081")
@@ -287,8 +323,8 @@ func TestFlagsUsage(t *testing.T) {
       --healthz-ep-net-keep-alive-probe-enable            healthz entrypoint: Enable keep alive probes [env: HEALTHZ_EP_NET_KEEP_ALIVE_PROBE_ENABLE]
       --healthz-ep-net-keep-alive-probe-idle string       healthz entrypoint: Time that the connection must be idle before the first keep-alive probe is sent [env: HEALTHZ_EP_NET_KEEP_ALIVE_PROBE_IDLE] (default "15s")
       --healthz-ep-net-keep-alive-probe-interval string   healthz entrypoint: Time between keep-alive probes [env: HEALTHZ_EP_NET_KEEP_ALIVE_PROBE_INTERVAL] (default "15s")
-      --include-extensions string                         Optionnal extended data to include in the generated prover input (e.g. "accessList" "preState" "stateDiffs" "committed" "all") [env: INCLUDE_EXTENSIONS] (default "all")
-      --inputs-content-type string                        Content type (e.g. json) [env: INPUTS_CONTENT_TYPE] (default "application/json")
+      --include-extensions string                         Optionnal extended data to include in the generated prover input (e.g. "accessList" "preState" "stateDiffs" "committed" "provingCost" "senders" "receipts" "txStateDiffs" "preimages" "all") [env: INCLUDE_EXTENSIONS] (default "all")
+      --inputs-content-type string                        Content type (e.g. "application/json" "application/protobuf" "application/execution-witness+json" "application/ssz" "application/protobuf-stream") [env: INPUTS_CONTENT_TYPE] (default "application/json")
       --log-enable-caller                                 Enable caller [env: LOG_ENABLE_CALLER]
       --log-enable-stacktrace                             Enable automatic stacktrace capturing [env: LOG_ENABLE_STACKTRACE]
       --log-encoding-caller-encoder string                Encoding: Primitive representation for the log caller (e.g. 'full' [env: LOG_ENCODING_CALLER_ENCODER] (default "short")
@@ -323,6 +359,7 @@ func TestFlagsUsage(t *testing.T) {
       --main-ep-net-keep-alive-probe-enable               main entrypoint: Enable keep alive probes [env: MAIN_EP_NET_KEEP_ALIVE_PROBE_ENABLE]
       --main-ep-net-keep-alive-probe-idle string          main entrypoint: Time that the connection must be idle before the first keep-alive probe is sent [env: MAIN_EP_NET_KEEP_ALIVE_PROBE_IDLE] (default "15s")
       --main-ep-net-keep-alive-probe-interval string      main entrypoint: Time between keep-alive probes [env: MAIN_EP_NET_KEEP_ALIVE_PROBE_INTERVAL] (default "15s")
+      --minimize-witness                                  Minimize the prover input witness by dropping data that is never resolved during block execution [env: MINIMIZE_WITNESS]
       --start-timeout string                              Start timeout [env: START_TIMEOUT] (default "10s")
       --stop-timeout string                               Stop timeout [env: STOP_TIMEOUT] (default "10s")
       --store-aws-s3-bucket string                        AWS S3 bucket [env: STORE_AWS_S3_BUCKET]
@@ -335,6 +372,22 @@ func TestFlagsUsage(t *testing.T) {
       --store-file-dir string                             Path to local data directory [env: STORE_FILE_DIR] (default "data")
       --store-file-enabled                                Enable file store [env: STORE_FILE_ENABLED] (default true)
       --store-preflight-data                              Store intermediate preflight data when generating prover inputs [env: STORE_PREFLIGHT_DATA]
+      --trace-transactions                                Export an EIP-3155 struct log trace of every transaction next to the generated prover input [env: TRACE_TRANSACTIONS]
+      --tracing-execute-call-frames string                Log level of EVM call frames (e.g. "off" "debug" "info" "warn") [env: TRACING_EXECUTE_CALL_FRAMES] (default "debug")
+      --tracing-execute-faults string                     Log level of opcode faults (e.g. "off" "debug" "info" "warn") [env: TRACING_EXECUTE_FAULTS] (default "debug")
+      --tracing-execute-opcodes string                    Log level of executed opcodes (e.g. "off" "debug" "info" "warn") [env: TRACING_EXECUTE_OPCODES] (default "off")
+      --tracing-execute-storage string                    Log level of storage reads and writes (e.g. "off" "debug" "info" "warn") [env: TRACING_EXECUTE_STORAGE] (default "off")
+      --tracing-execute-tx string                         Log level of transactions and system calls (e.g. "off" "debug" "info" "warn") [env: TRACING_EXECUTE_TX] (default "debug")
+      --tracing-preflight-call-frames string              Log level of EVM call frames (e.g. "off" "debug" "info" "warn") [env: TRACING_PREFLIGHT_CALL_FRAMES] (default "debug")
+      --tracing-preflight-faults string                   Log level of opcode faults (e.g. "off" "debug" "info" "warn") [env: TRACING_PREFLIGHT_FAULTS] (default "debug")
+      --tracing-preflight-opcodes string                  Log level of executed opcodes (e.g. "off" "debug" "info" "warn") [env: TRACING_PREFLIGHT_OPCODES] (default "off")
+      --tracing-preflight-storage string                  Log level of storage reads and writes (e.g. "off" "debug" "info" "warn") [env: TRACING_PREFLIGHT_STORAGE] (default "off")
+      --tracing-preflight-tx string                       Log level of transactions and system calls (e.g. "off" "debug" "info" "warn") [env: TRACING_PREFLIGHT_TX] (default "debug")
+      --tracing-prepare-call-frames string                Log level of EVM call frames (e.g. "off" "debug" "info" "warn") [env: TRACING_PREPARE_CALL_FRAMES] (default "debug")
+      --tracing-prepare-faults string                     Log level of opcode faults (e.g. "off" "debug" "info" "warn") [env: TRACING_PREPARE_FAULTS] (default "debug")
+      --tracing-prepare-opcodes string                    Log level of executed opcodes (e.g. "off" "debug" "info" "warn") [env: TRACING_PREPARE_OPCODES] (default "off")
+      --tracing-prepare-storage string                    Log level of storage reads and writes (e.g. "off" "debug" "info" "warn") [env: TRACING_PREPARE_STORAGE] (default "off")
+      --tracing-prepare-tx string                         Log level of transactions and system calls (e.g. "off" "debug" "info" "warn") [env: TRACING_PREPARE_TX] (default "debug")
 `
 
 	expectedRaws := strings.Split(expectedUsage, "\n")
@@ -417,12 +470,26 @@ func TestAddFlagsAndLoadEnv(t *testing.T) {
 			ContentEncoding: common.Ptr(store.ContentEncodingGzip),
 		},
 		ProverInputs: &ProverInputsConfig{
-			ContentType: common.Ptr(store.ContentTypeJSON),
+			ContentType: common.Ptr(inputstore.ContentTypeJSON),
 		},
 		Generator: &GeneratorConfig{
 			StorePreflightData: common.Ptr(true),
 			FilterModulo:       common.Ptr(uint64(15)),
 			IncludeExtensions:  common.Ptr(steps.IncludePreState | steps.IncludeAccessList),
+			MinimizeWitness:    common.Ptr(true),
+			CheckReceipts:      common.Ptr(true),
+			TraceTransactions:  common.Ptr(true),
+			Tracing: &TracingConfig{
+				Preflight: &TracerConfig{
+					Tx:         common.Ptr(evm.TraceLevelInfo),
+					CallFrames: common.Ptr(evm.TraceLevelOff),
+					Opcodes:    common.Ptr(evm.TraceLevelDebug),
+					Faults:     common.Ptr(evm.TraceLevelWarn),
+					Storage:    common.Ptr(evm.TraceLevelDebug),
+				},
+				Prepare: DefaultTracerConfig(),
+				Execute: DefaultTracerConfig(),
+			},
 		},
 	}
 
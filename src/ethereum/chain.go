@@ -3,35 +3,75 @@ package ethereum
 import (
 	"fmt"
 
-	"github.com/ethereum/go-ethereum/core"
-	gethstate "github.com/ethereum/go-ethereum/core/state"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// NewChain creates a new core.HeaderChain instance
-func NewChain(cfg *params.ChainConfig, stateDB gethstate.Database) (*core.HeaderChain, error) {
-	genesis, err := GetDefaultGenesis(cfg.ChainID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get default genesis for chain %q: %v", cfg.ChainID.String(), err)
-	}
-
-	// Setup the genesis block, to avoid error on core.NewHeaderChain
-	_, err = genesis.Commit(stateDB.TrieDB().Disk(), stateDB.TrieDB())
-	if err != nil {
-		return nil, fmt.Errorf("failed to apply genesis block: %v", err)
-	}
+// Chain is a lightweight chain context (core.ChainContext and consensus.ChainHeaderReader) backed by a database
+//
+// Unlike core.HeaderChain, it does not require any genesis: it only resolves the headers written to the database,
+// typically the witness ancestors (see WriteHeaders), which are the headers accessed during execution
+// (parent and ancestors accessed by BLOCKHASH). Headers that are not in the database are not resolved.
+type Chain struct {
+	config *params.ChainConfig
+	engine consensus.Engine
+	db     ethdb.Database
+}
 
+// NewChain creates a new Chain instance backed by db
+func NewChain(cfg *params.ChainConfig, db ethdb.Database) (*Chain, error) {
 	// Create consensus engine
-	engine, err := ethconfig.CreateConsensusEngine(cfg, stateDB.TrieDB().Disk())
+	engine, err := ethconfig.CreateConsensusEngine(cfg, db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create consensus engine: %v", err)
 	}
 
-	hc, err := core.NewHeaderChain(stateDB.TrieDB().Disk(), cfg, engine, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create header chain: %v", err)
+	return &Chain{
+		config: cfg,
+		engine: engine,
+		db:     db,
+	}, nil
+}
+
+// Config returns the chain configuration
+func (c *Chain) Config() *params.ChainConfig {
+	return c.config
+}
+
+// Engine returns the chain consensus engine
+func (c *Chain) Engine() consensus.Engine {
+	return c.engine
+}
+
+// CurrentHeader returns the highest header written to the database (nil if there is none)
+func (c *Chain) CurrentHeader() *gethtypes.Header {
+	return rawdb.ReadHeadHeader(c.db)
+}
+
+// GetHeader returns the header with the given hash and number (nil if it is not in the database)
+func (c *Chain) GetHeader(hash gethcommon.Hash, number uint64) *gethtypes.Header {
+	return rawdb.ReadHeader(c.db, hash, number)
+}
+
+// GetHeaderByHash returns the header with the given hash (nil if it is not in the database)
+func (c *Chain) GetHeaderByHash(hash gethcommon.Hash) *gethtypes.Header {
+	number := rawdb.ReadHeaderNumber(c.db, hash)
+	if number == nil {
+		return nil
 	}
+	return rawdb.ReadHeader(c.db, hash, *number)
+}
 
-	return hc, nil
+// GetHeaderByNumber returns the header with the given number (nil if it is not in the database)
+func (c *Chain) GetHeaderByNumber(number uint64) *gethtypes.Header {
+	hash := rawdb.ReadCanonicalHash(c.db, number)
+	if hash == (gethcommon.Hash{}) {
+		return nil
+	}
+	return rawdb.ReadHeader(c.db, hash, number)
 }
@@ -1,13 +1,19 @@
 package ethereum
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"os"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/params"
 )
 
+// registryMu protects the chain configuration registry
+var registryMu sync.RWMutex
+
 // ChainConfigs are supported chain configurations.
 var chainConfigs = map[string]*params.ChainConfig{
 	params.MainnetChainConfig.ChainID.String(): params.MainnetChainConfig,
@@ -16,6 +22,9 @@ var chainConfigs = map[string]*params.ChainConfig{
 }
 
 func GetChainConfig(chainID *big.Int) (*params.ChainConfig, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
 	cfg, ok := chainConfigs[chainID.String()]
 	if !ok {
 		return nil, fmt.Errorf("unsupported chain: %q", chainID.String())
@@ -23,16 +32,38 @@ func GetChainConfig(chainID *big.Int) (*params.ChainConfig, error) {
 	return cfg, nil
 }
 
-var defaultGenesis = map[string]*core.Genesis{
-	params.MainnetChainConfig.ChainID.String(): core.DefaultGenesisBlock(),
-	params.SepoliaChainConfig.ChainID.String(): core.DefaultSepoliaGenesisBlock(),
-	params.HoleskyChainConfig.ChainID.String(): core.DefaultHoleskyGenesisBlock(),
+// RegisterChain registers a custom chain from its genesis.
+// Once registered, the chain configuration is available by chain ID (the genesis allocation is never used).
+// If a chain with the same chain ID is already registered, it is overridden.
+func RegisterChain(genesis *core.Genesis) error {
+	if genesis == nil || genesis.Config == nil || genesis.Config.ChainID == nil {
+		return fmt.Errorf("invalid genesis: missing chain config or chain ID")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	chainConfigs[genesis.Config.ChainID.String()] = genesis.Config
+
+	return nil
 }
 
-func GetDefaultGenesis(chainID *big.Int) (*core.Genesis, error) {
-	genesis, ok := defaultGenesis[chainID.String()]
-	if !ok {
-		return nil, fmt.Errorf("unsupported chain: %q", chainID.String())
+// LoadGenesis loads a genesis from a genesis.json file (as used by geth init).
+func LoadGenesis(path string) (*core.Genesis, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open genesis file: %v", err)
+	}
+	defer f.Close()
+
+	genesis := new(core.Genesis)
+	if err := json.NewDecoder(f).Decode(genesis); err != nil {
+		return nil, fmt.Errorf("failed to decode genesis file: %v", err)
 	}
+
+	if genesis.Config == nil || genesis.Config.ChainID == nil {
+		return nil, fmt.Errorf("invalid genesis file: missing chain config or chain ID")
+	}
+
 	return genesis, nil
 }
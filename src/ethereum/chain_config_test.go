@@ -4,10 +4,13 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestChainConfigAndGenesis(t *testing.T) {
+func TestChainConfig(t *testing.T) {
 	var testCases = []struct {
 		desc    string
 		chainID *big.Int
@@ -31,10 +34,30 @@ func TestChainConfigAndGenesis(t *testing.T) {
 			cfg, err := GetChainConfig(tc.chainID)
 			assert.NoError(t, err)
 			assert.NotNil(t, cfg)
-
-			genesis, err := GetDefaultGenesis(tc.chainID)
-			assert.NoError(t, err)
-			assert.NotNil(t, genesis)
 		})
 	}
 }
+
+func TestRegisterChain(t *testing.T) {
+	genesis, err := LoadGenesis("testdata/genesis.json")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(3151908), genesis.Config.ChainID)
+
+	_, err = GetChainConfig(genesis.Config.ChainID)
+	assert.Error(t, err)
+
+	err = RegisterChain(genesis)
+	require.NoError(t, err)
+
+	cfg, err := GetChainConfig(genesis.Config.ChainID)
+	require.NoError(t, err)
+	assert.Equal(t, genesis.Config, cfg)
+
+	_, err = NewChain(cfg, rawdb.NewMemoryDatabase())
+	assert.NoError(t, err)
+}
+
+func TestRegisterChainInvalid(t *testing.T) {
+	assert.Error(t, RegisterChain(nil))
+	assert.Error(t, RegisterChain(&core.Genesis{}))
+}
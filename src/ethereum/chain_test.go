@@ -4,11 +4,12 @@ import (
 	"math/big"
 	"testing"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
-	gethstate "github.com/ethereum/go-ethereum/core/state"
-	"github.com/ethereum/go-ethereum/triedb"
-	"github.com/ethereum/go-ethereum/triedb/hashdb"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewChain(t *testing.T) {
@@ -26,9 +27,34 @@ func TestNewChain(t *testing.T) {
 			cfg, err := GetChainConfig(tc.chainID)
 			assert.NoError(t, err)
 			assert.NotNil(t, cfg)
-			trieDB := triedb.NewDatabase(rawdb.NewMemoryDatabase(), &triedb.Config{HashDB: &hashdb.Config{}})
-			_, err = NewChain(cfg, gethstate.NewDatabase(trieDB, nil))
+			_, err = NewChain(cfg, rawdb.NewMemoryDatabase())
 			assert.NoError(t, err)
 		})
 	}
 }
+
+func TestNewChainFromAncestors(t *testing.T) {
+	// Chain with no known genesis
+	cfg := *params.MergedTestChainConfig
+	cfg.ChainID = big.NewInt(987654321)
+
+	db := rawdb.NewMemoryDatabase()
+	grandParent := &gethtypes.Header{Number: big.NewInt(9), Difficulty: big.NewInt(0)}
+	parent := &gethtypes.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0), ParentHash: grandParent.Hash()}
+	WriteHeaders(db, parent, grandParent)
+
+	chain, err := NewChain(&cfg, db)
+	require.NoError(t, err)
+
+	// Ancestors are resolved by hash and number
+	assert.Equal(t, parent.Hash(), chain.GetHeader(parent.Hash(), 10).Hash())
+	assert.Equal(t, grandParent.Hash(), chain.GetHeader(parent.ParentHash, 9).Hash())
+	assert.Equal(t, grandParent.Hash(), chain.GetHeaderByHash(parent.ParentHash).Hash())
+	assert.Equal(t, grandParent.Hash(), chain.GetHeaderByNumber(9).Hash())
+	assert.Equal(t, parent.Hash(), chain.CurrentHeader().Hash())
+
+	// Headers that are not ancestors (e.g. genesis) are not resolved
+	assert.Nil(t, chain.GetHeaderByNumber(0))
+	assert.Nil(t, chain.GetHeader(gethcommon.Hash{0x1}, 8))
+	assert.Nil(t, chain.GetHeaderByHash(gethcommon.Hash{0x1}))
+}
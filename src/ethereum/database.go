@@ -26,9 +26,18 @@ func WriteCodes(db ethdb.Database, codes ...[]byte) {
 }
 
 // WriteHeaders fills an ethdb.Database with the provided headers
+//
+// Headers are expected to be ancestors of the executed blocks, so they are indexed as canonical by number,
+// and the highest one is marked as the head header.
 func WriteHeaders(db ethdb.Database, headers ...*gethtypes.Header) {
+	head := rawdb.ReadHeadHeader(db)
 	for _, header := range headers {
 		rawdb.WriteHeader(db, header)
+		rawdb.WriteCanonicalHash(db, header.Hash(), header.Number.Uint64())
+		if head == nil || header.Number.Cmp(head.Number) > 0 {
+			head = header
+			rawdb.WriteHeadHeaderHash(db, header.Hash())
+		}
 	}
 }
 
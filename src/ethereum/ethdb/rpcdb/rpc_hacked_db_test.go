@@ -84,6 +84,19 @@ func TestDatabase(t *testing.T) {
 		assert.Equal(t, hexutil.Encode(expectedB), hexutil.Encode(b))
 	})
 
+	t.Run("Get Local Header", func(t *testing.T) {
+		header := &gethtypes.Header{
+			Number:     big.NewInt(1235),
+			ParentHash: gethcommon.HexToHash("0xb44fb4e949d0f78f87f79ee46428f23a2a5713ce6fc6e0beb3dda78c2ac1ea55"),
+		}
+
+		rawdb.WriteHeader(db, header)
+		b, err := db.Get(headerKey(1235, header.Hash()))
+		require.NoError(t, err)
+		expectedB, _ := rlp.EncodeToBytes(header)
+		assert.Equal(t, hexutil.Encode(expectedB), hexutil.Encode(b))
+	})
+
 	t.Run("Get Non-Header", func(t *testing.T) {
 		b, err := db.Get([]byte("key"))
 		require.Error(t, err)
@@ -20,8 +20,9 @@ type ExecParams struct {
 	Block    *types.Block
 	Validate bool // Whether to the validate the block at the end of execution
 	Commit   bool // Whether to commit the state changes
+	Flush    bool // Whether to flush the committed state changes to the state database (only applies if Commit is true)
 	State    *gethstate.StateDB
-	Chain    *core.HeaderChain
+	Chain    ChainContext
 	Reporter func(error)
 }
 
@@ -95,16 +96,18 @@ func (e *executor) Execute(ctx context.Context, params *ExecParams) (res *core.P
 	}
 
 	if params.Commit {
-		_, execErr = params.State.CommitWithoutFlush(true, false)
+		if params.Flush {
+			_, execErr = params.State.Commit(params.Block.NumberU64(), true, false)
+		} else {
+			_, execErr = params.State.CommitWithoutFlush(true, false)
+		}
 	}
 
 	return
 }
 
 func (e *executor) processBlock(_ context.Context, params *ExecParams) (*core.ProcessResult, error) {
-	processor := core.NewStateProcessor(params.Chain.Config(), params.Chain)
-
-	res, err := processor.Process(params.Block, params.State, *params.VMConfig)
+	res, err := processBlock(params.Chain, params.Block, params.State, *params.VMConfig)
 	if err != nil {
 		if params.Reporter != nil {
 			params.Reporter(summarizeBadBlockError(params.Chain.Config(), params.Block, res, err))
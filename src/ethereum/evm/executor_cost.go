@@ -0,0 +1,328 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/kkrt-labs/go-utils/log"
+	"github.com/kkrt-labs/go-utils/tag"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// ExecutionStats are statistics collected during the execution of a block
+// that drive the cost of proving the block.
+type ExecutionStats struct {
+	Opcodes      map[gethvm.OpCode]uint64                // Number of executions of each opcode
+	Precompiles  map[gethcommon.Address]*PrecompileStats // Calls to each precompile
+	KeccakBytes  uint64                                  // Number of bytes hashed by KECCAK256
+	WitnessNodes uint64                                  // Number of state nodes in the execution witness
+}
+
+// PrecompileStats are statistics of the calls to a precompile
+type PrecompileStats struct {
+	Calls      uint64 // Number of calls
+	InputBytes uint64 // Total size of the call inputs
+}
+
+// NewExecutionStats creates empty execution statistics
+func NewExecutionStats() *ExecutionStats {
+	return &ExecutionStats{
+		Opcodes:     make(map[gethvm.OpCode]uint64),
+		Precompiles: make(map[gethcommon.Address]*PrecompileStats),
+	}
+}
+
+// Merge adds the statistics of other to the statistics
+func (s *ExecutionStats) Merge(other *ExecutionStats) {
+	for op, count := range other.Opcodes {
+		s.Opcodes[op] += count
+	}
+	for addr, pStats := range other.Precompiles {
+		s.precompile(addr).Calls += pStats.Calls
+		s.precompile(addr).InputBytes += pStats.InputBytes
+	}
+	s.KeccakBytes += other.KeccakBytes
+	s.WitnessNodes += other.WitnessNodes
+}
+
+func (s *ExecutionStats) precompile(addr gethcommon.Address) *PrecompileStats {
+	pStats, ok := s.Precompiles[addr]
+	if !ok {
+		pStats = new(PrecompileStats)
+		s.Precompiles[addr] = pStats
+	}
+	return pStats
+}
+
+// StatsTracer is an EVM tracer that collects execution statistics
+type StatsTracer struct {
+	stats       *ExecutionStats
+	precompiles map[gethcommon.Address]struct{}
+}
+
+// NewStatsTracer creates a new stats tracer for the given active precompiles
+func NewStatsTracer(stats *ExecutionStats, precompiles []gethcommon.Address) *StatsTracer {
+	t := &StatsTracer{
+		stats:       stats,
+		precompiles: make(map[gethcommon.Address]struct{}, len(precompiles)),
+	}
+	for _, addr := range precompiles {
+		t.precompiles[addr] = struct{}{}
+	}
+	return t
+}
+
+// OnEnter records calls to precompiles
+func (t *StatsTracer) OnEnter(_ int, _ byte, _, to gethcommon.Address, input []byte, _ uint64, _ *big.Int) {
+	if _, ok := t.precompiles[to]; ok {
+		pStats := t.stats.precompile(to)
+		pStats.Calls++
+		pStats.InputBytes += uint64(len(input))
+	}
+}
+
+// OnOpcode records opcode executions and the size of the data hashed by KECCAK256
+func (t *StatsTracer) OnOpcode(_ uint64, op byte, _, _ uint64, scope tracing.OpContext, _ []byte, _ int, err error) {
+	if err != nil {
+		return
+	}
+
+	t.stats.Opcodes[gethvm.OpCode(op)]++
+	if gethvm.OpCode(op) == gethvm.KECCAK256 {
+		// Stack is [..., size, offset]
+		if stack := scope.StackData(); len(stack) >= 2 {
+			t.stats.KeccakBytes += stack[len(stack)-2].Uint64()
+		}
+	}
+}
+
+// Hooks returns the stats tracer hooks
+func (t *StatsTracer) Hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnEnter:  t.OnEnter,
+		OnOpcode: t.OnOpcode,
+	}
+}
+
+// CostModel estimates the number of cycles necessary to prove a block from its execution statistics
+type CostModel interface {
+	Cycles(stats *ExecutionStats) uint64
+}
+
+// CostModelFunc is a function that estimates proving cycles
+type CostModelFunc func(stats *ExecutionStats) uint64
+
+func (f CostModelFunc) Cycles(stats *ExecutionStats) uint64 {
+	return f(stats)
+}
+
+// LinearCostModel is a cost model that prices each statistic linearly
+type LinearCostModel struct {
+	OpcodeCycles        map[gethvm.OpCode]uint64 // Cycles per opcode execution
+	DefaultOpcodeCycles uint64                   // Cycles per execution of an opcode missing from OpcodeCycles
+
+	PrecompileCallCycles      map[gethcommon.Address]uint64 // Cycles per precompile call
+	PrecompileInputByteCycles map[gethcommon.Address]uint64 // Cycles per byte of precompile input
+
+	KeccakByteCycles  uint64 // Cycles per byte hashed by KECCAK256
+	WitnessNodeCycles uint64 // Cycles per witness state node (decoding and hashing)
+}
+
+// Cycles estimates the number of cycles necessary to prove a block
+func (m *LinearCostModel) Cycles(stats *ExecutionStats) uint64 {
+	var cycles uint64
+	for op, count := range stats.Opcodes {
+		opCycles, ok := m.OpcodeCycles[op]
+		if !ok {
+			opCycles = m.DefaultOpcodeCycles
+		}
+		cycles += count * opCycles
+	}
+	for addr, pStats := range stats.Precompiles {
+		cycles += pStats.Calls*m.PrecompileCallCycles[addr] + pStats.InputBytes*m.PrecompileInputByteCycles[addr]
+	}
+	cycles += stats.KeccakBytes * m.KeccakByteCycles
+	cycles += stats.WitnessNodes * m.WitnessNodeCycles
+	return cycles
+}
+
+// DefaultCostModel returns a linear cost model with rough cycle counts for a RISC-V zkVM
+// It is meant to rank blocks by proving cost and should be calibrated against the target prover
+func DefaultCostModel() *LinearCostModel {
+	return &LinearCostModel{
+		OpcodeCycles: map[gethvm.OpCode]uint64{
+			gethvm.KECCAK256:    1_000,
+			gethvm.BALANCE:      5_000,
+			gethvm.EXTCODESIZE:  5_000,
+			gethvm.EXTCODECOPY:  5_000,
+			gethvm.EXTCODEHASH:  5_000,
+			gethvm.SLOAD:        5_000,
+			gethvm.SSTORE:       10_000,
+			gethvm.CALL:         5_000,
+			gethvm.CALLCODE:     5_000,
+			gethvm.DELEGATECALL: 5_000,
+			gethvm.STATICCALL:   5_000,
+			gethvm.CREATE:       20_000,
+			gethvm.CREATE2:      20_000,
+			gethvm.SELFDESTRUCT: 10_000,
+			gethvm.LOG0:         500,
+			gethvm.LOG1:         1_000,
+			gethvm.LOG2:         1_500,
+			gethvm.LOG3:         2_000,
+			gethvm.LOG4:         2_500,
+			gethvm.EXP:          2_000,
+			gethvm.MULMOD:       500,
+			gethvm.ADDMOD:       300,
+			gethvm.SDIV:         300,
+			gethvm.DIV:          300,
+			gethvm.SMOD:         300,
+			gethvm.MOD:          300,
+		},
+		DefaultOpcodeCycles: 100,
+		PrecompileCallCycles: map[gethcommon.Address]uint64{
+			gethcommon.BytesToAddress([]byte{0x01}): 200_000,    // ecrecover
+			gethcommon.BytesToAddress([]byte{0x02}): 1_000,      // sha256
+			gethcommon.BytesToAddress([]byte{0x03}): 1_000,      // ripemd160
+			gethcommon.BytesToAddress([]byte{0x04}): 100,        // identity
+			gethcommon.BytesToAddress([]byte{0x05}): 100_000,    // modexp
+			gethcommon.BytesToAddress([]byte{0x06}): 50_000,     // bn256Add
+			gethcommon.BytesToAddress([]byte{0x07}): 500_000,    // bn256ScalarMul
+			gethcommon.BytesToAddress([]byte{0x08}): 5_000_000,  // bn256Pairing
+			gethcommon.BytesToAddress([]byte{0x09}): 10_000,     // blake2f
+			gethcommon.BytesToAddress([]byte{0x0a}): 10_000_000, // kzgPointEvaluation
+		},
+		PrecompileInputByteCycles: map[gethcommon.Address]uint64{
+			gethcommon.BytesToAddress([]byte{0x02}): 40,     // sha256
+			gethcommon.BytesToAddress([]byte{0x03}): 50,     // ripemd160
+			gethcommon.BytesToAddress([]byte{0x04}): 1,      // identity
+			gethcommon.BytesToAddress([]byte{0x05}): 1_000,  // modexp
+			gethcommon.BytesToAddress([]byte{0x08}): 30_000, // bn256Pairing
+			gethcommon.BytesToAddress([]byte{0x09}): 100,    // blake2f
+		},
+		KeccakByteCycles:  80,
+		WitnessNodeCycles: 20_000,
+	}
+}
+
+// CostEstimate is the estimated proving cost of a block
+type CostEstimate struct {
+	Cycles uint64          // Estimated number of proving cycles
+	Stats  *ExecutionStats // Statistics the estimate is computed from
+}
+
+// CostEstimator estimates the proving cost of blocks and exposes it as a metric
+type CostEstimator struct {
+	model CostModel
+
+	estimatedCycles     prometheus.Histogram
+	lastEstimatedCycles prometheus.Gauge
+}
+
+// NewCostEstimator creates a new cost estimator using the given cost model
+func NewCostEstimator(model CostModel) *CostEstimator {
+	return &CostEstimator{model: model}
+}
+
+var (
+	estimatedCyclesBuckets = prometheus.ExponentialBuckets(1e6, 2, 16)
+)
+
+func (e *CostEstimator) SetMetrics(system, subsystem string, _ ...*tag.Tag) {
+	e.estimatedCycles = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:      "estimated_cycles",
+		Namespace: system,
+		Subsystem: subsystem,
+		Help:      "Estimated number of cycles to prove a block",
+		Buckets:   estimatedCyclesBuckets,
+	})
+
+	e.lastEstimatedCycles = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "last_estimated_cycles",
+		Namespace: system,
+		Subsystem: subsystem,
+		Help:      "Estimated number of cycles to prove the last executed block",
+	})
+}
+
+func (e *CostEstimator) Describe(ch chan<- *prometheus.Desc) {
+	if e.estimatedCycles != nil {
+		e.estimatedCycles.Describe(ch)
+		e.lastEstimatedCycles.Describe(ch)
+	}
+}
+
+func (e *CostEstimator) Collect(ch chan<- prometheus.Metric) {
+	if e.estimatedCycles != nil {
+		e.estimatedCycles.Collect(ch)
+		e.lastEstimatedCycles.Collect(ch)
+	}
+}
+
+// Estimate estimates the proving cost of a block from its execution statistics
+func (e *CostEstimator) Estimate(stats *ExecutionStats) *CostEstimate {
+	estimate := &CostEstimate{
+		Cycles: e.model.Cycles(stats),
+		Stats:  stats,
+	}
+
+	if e.estimatedCycles != nil {
+		e.estimatedCycles.Observe(float64(estimate.Cycles))
+		e.lastEstimatedCycles.Set(float64(estimate.Cycles))
+	}
+
+	return estimate
+}
+
+// WithCostEstimate is an executor decorator that collects execution statistics
+// (opcode histogram, precompile calls, keccak bytes and witness nodes) and estimates the proving cost of the block.
+// On successful execution, the estimate is recorded in the estimator metrics and passed to the recorder
+// attached to the context with ContextWithCostEstimateRecorder (if any).
+//
+// Witness nodes are only counted if the execution records a witness (params.VMConfig.StatelessSelfValidation)
+func WithCostEstimate(estimator *CostEstimator) ExecutorDecorator {
+	return func(executor Executor) Executor {
+		return ExecutorFunc(func(ctx context.Context, params *ExecParams) (*core.ProcessResult, error) {
+			stats := NewExecutionStats()
+			AddTracer(params.VMConfig, NewStatsTracer(stats, activePrecompiles(params)).Hooks())
+
+			res, err := executor.Execute(ctx, params)
+			if err != nil {
+				return res, err
+			}
+
+			if witness := params.State.Witness(); witness != nil {
+				stats.WitnessNodes = uint64(len(witness.State))
+			}
+
+			estimate := estimator.Estimate(stats)
+			log.LoggerFromContext(ctx).Debug("Estimated block proving cost", zap.Uint64("cycles", estimate.Cycles))
+			if record, ok := ctx.Value(costEstimateRecorderKey{}).(CostEstimateRecorder); ok {
+				record(estimate)
+			}
+
+			return res, nil
+		})
+	}
+}
+
+// CostEstimateRecorder is a function receiving the proving cost estimate of every block executed with WithCostEstimate
+type CostEstimateRecorder func(estimate *CostEstimate)
+
+type costEstimateRecorderKey struct{}
+
+// ContextWithCostEstimateRecorder returns a context passing the proving cost estimates of the blocks executed with it to record
+func ContextWithCostEstimateRecorder(ctx context.Context, record CostEstimateRecorder) context.Context {
+	return context.WithValue(ctx, costEstimateRecorderKey{}, record)
+}
+
+func activePrecompiles(params *ExecParams) []gethcommon.Address {
+	header := params.Block.Header()
+	isMerge := header.Difficulty != nil && header.Difficulty.Sign() == 0
+	rules := params.Chain.Config().Rules(header.Number, isMerge, header.Time)
+	return gethvm.ActivePrecompiles(rules)
+}
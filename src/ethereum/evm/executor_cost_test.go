@@ -0,0 +1,149 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	gethstate "github.com/ethereum/go-ethereum/core/state"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOpContext struct {
+	stack []uint256.Int
+}
+
+func (c *testOpContext) MemoryData() []byte          { return nil }
+func (c *testOpContext) StackData() []uint256.Int    { return c.stack }
+func (c *testOpContext) Caller() gethcommon.Address  { return gethcommon.Address{} }
+func (c *testOpContext) Address() gethcommon.Address { return gethcommon.Address{} }
+func (c *testOpContext) CallValue() *uint256.Int     { return new(uint256.Int) }
+func (c *testOpContext) CallInput() []byte           { return nil }
+func (c *testOpContext) ContractCode() []byte        { return nil }
+
+func TestStatsTracer(t *testing.T) {
+	ecrecover := gethcommon.BytesToAddress([]byte{0x01})
+	stats := NewExecutionStats()
+	hooks := NewStatsTracer(stats, []gethcommon.Address{ecrecover}).Hooks()
+
+	hooks.OnEnter(0, byte(gethvm.CALL), gethcommon.Address{}, gethcommon.HexToAddress("0xabcd"), make([]byte, 4), 100000, big.NewInt(0))
+	hooks.OnOpcode(0, byte(gethvm.PUSH1), 100000, 3, &testOpContext{}, nil, 1, nil)
+	hooks.OnOpcode(2, byte(gethvm.PUSH1), 99997, 3, &testOpContext{}, nil, 1, nil)
+	// Stack is [..., size=64, offset=0]
+	hooks.OnOpcode(4, byte(gethvm.KECCAK256), 99994, 42, &testOpContext{stack: []uint256.Int{*uint256.NewInt(64), *uint256.NewInt(0)}}, nil, 1, nil)
+	hooks.OnEnter(1, byte(gethvm.STATICCALL), gethcommon.HexToAddress("0xabcd"), ecrecover, make([]byte, 128), 50000, nil)
+	// Failed opcodes are not counted
+	hooks.OnOpcode(6, byte(gethvm.SLOAD), 10, 2100, &testOpContext{}, nil, 1, gethvm.ErrOutOfGas)
+
+	assert.Equal(t, map[gethvm.OpCode]uint64{gethvm.PUSH1: 2, gethvm.KECCAK256: 1}, stats.Opcodes)
+	assert.Equal(t, map[gethcommon.Address]*PrecompileStats{ecrecover: {Calls: 1, InputBytes: 128}}, stats.Precompiles)
+	assert.Equal(t, uint64(64), stats.KeccakBytes)
+}
+
+func TestExecutionStatsMerge(t *testing.T) {
+	addr := gethcommon.BytesToAddress([]byte{0x02})
+	stats := &ExecutionStats{
+		Opcodes:      map[gethvm.OpCode]uint64{gethvm.ADD: 1},
+		Precompiles:  map[gethcommon.Address]*PrecompileStats{addr: {Calls: 1, InputBytes: 32}},
+		KeccakBytes:  32,
+		WitnessNodes: 2,
+	}
+	stats.Merge(&ExecutionStats{
+		Opcodes:      map[gethvm.OpCode]uint64{gethvm.ADD: 2, gethvm.MUL: 1},
+		Precompiles:  map[gethcommon.Address]*PrecompileStats{addr: {Calls: 2, InputBytes: 64}},
+		KeccakBytes:  10,
+		WitnessNodes: 3,
+	})
+
+	assert.Equal(t, &ExecutionStats{
+		Opcodes:      map[gethvm.OpCode]uint64{gethvm.ADD: 3, gethvm.MUL: 1},
+		Precompiles:  map[gethcommon.Address]*PrecompileStats{addr: {Calls: 3, InputBytes: 96}},
+		KeccakBytes:  42,
+		WitnessNodes: 5,
+	}, stats)
+}
+
+func TestLinearCostModel(t *testing.T) {
+	addr := gethcommon.BytesToAddress([]byte{0x02})
+	model := &LinearCostModel{
+		OpcodeCycles:              map[gethvm.OpCode]uint64{gethvm.SLOAD: 1000},
+		DefaultOpcodeCycles:       10,
+		PrecompileCallCycles:      map[gethcommon.Address]uint64{addr: 500},
+		PrecompileInputByteCycles: map[gethcommon.Address]uint64{addr: 2},
+		KeccakByteCycles:          3,
+		WitnessNodeCycles:         100,
+	}
+
+	stats := &ExecutionStats{
+		Opcodes:      map[gethvm.OpCode]uint64{gethvm.SLOAD: 2, gethvm.ADD: 5},
+		Precompiles:  map[gethcommon.Address]*PrecompileStats{addr: {Calls: 2, InputBytes: 64}},
+		KeccakBytes:  32,
+		WitnessNodes: 4,
+	}
+
+	// 2*1000 + 5*10 + 2*500 + 64*2 + 32*3 + 4*100
+	assert.Equal(t, uint64(3674), model.Cycles(stats))
+}
+
+func TestCostEstimator(t *testing.T) {
+	estimator := NewCostEstimator(CostModelFunc(func(stats *ExecutionStats) uint64 { return stats.KeccakBytes * 2 }))
+	estimator.SetMetrics("zkpig", "cost")
+
+	stats := NewExecutionStats()
+	stats.KeccakBytes = 21
+	estimate := estimator.Estimate(stats)
+	require.NotNil(t, estimate)
+	assert.Equal(t, uint64(42), estimate.Cycles)
+	assert.Equal(t, stats, estimate.Stats)
+}
+
+type testChain struct {
+	ChainContext
+	config *params.ChainConfig
+}
+
+func (c *testChain) Config() *params.ChainConfig { return c.config }
+
+func TestWithCostEstimate(t *testing.T) {
+	estimator := NewCostEstimator(CostModelFunc(func(stats *ExecutionStats) uint64 { return stats.Opcodes[gethvm.ADD] * 10 }))
+	executor := WithCostEstimate(estimator)(ExecutorFunc(func(_ context.Context, params *ExecParams) (*core.ProcessResult, error) {
+		params.VMConfig.Tracer.OnOpcode(0, byte(gethvm.ADD), 100, 3, &testOpContext{}, nil, 1, nil)
+		return &core.ProcessResult{}, nil
+	}))
+
+	state, err := gethstate.New(gethtypes.EmptyRootHash, gethstate.NewDatabaseForTesting())
+	require.NoError(t, err)
+	newParams := func() *ExecParams {
+		return &ExecParams{
+			VMConfig: &gethvm.Config{},
+			Block:    gethtypes.NewBlockWithHeader(&gethtypes.Header{Number: big.NewInt(1), Difficulty: big.NewInt(0)}),
+			Chain:    &testChain{config: params.MergedTestChainConfig},
+			State:    state,
+		}
+	}
+
+	var estimates []*CostEstimate
+	ctx := ContextWithCostEstimateRecorder(context.TODO(), func(estimate *CostEstimate) {
+		estimates = append(estimates, estimate)
+	})
+	_, err = executor.Execute(ctx, newParams())
+	require.NoError(t, err)
+	_, err = executor.Execute(ctx, newParams())
+	require.NoError(t, err)
+
+	require.Len(t, estimates, 2)
+	assert.Equal(t, uint64(10), estimates[0].Cycles)
+	assert.Equal(t, uint64(1), estimates[1].Stats.Opcodes[gethvm.ADD])
+
+	// Estimates are not recorded without a recorder in the context
+	_, err = executor.Execute(context.TODO(), newParams())
+	require.NoError(t, err)
+	assert.Len(t, estimates, 2)
+}
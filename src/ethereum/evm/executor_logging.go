@@ -2,7 +2,9 @@ package evm
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"strings"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -14,6 +16,7 @@ import (
 	"github.com/kkrt-labs/go-utils/log"
 	"github.com/kkrt-labs/go-utils/tag"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 type tagged struct {
@@ -39,15 +42,20 @@ func (t *tagged) Execute(ctx context.Context, params *ExecParams) (*core.Process
 }
 
 // WithLog is an executor decorator that logs block execution
+// It adds a logger tracer configured with cfg (if nil, it uses the default logger tracer configuration)
 // If namespaces are provided, it loads tags from the provided namespaces
 // By default (recommended) it logs tags from the default namespace
-func WithLog() ExecutorDecorator {
+func WithLog(cfg *LoggerTracerConfig) ExecutorDecorator {
+	if cfg == nil {
+		cfg = DefaultLoggerTracerConfig()
+	}
+
 	return func(executor Executor) Executor {
 		return ExecutorFunc(func(ctx context.Context, params *ExecParams) (*core.ProcessResult, error) {
 			logger := log.LoggerFromContext(ctx)
 
-			// Set tracing logger
-			params.VMConfig.Tracer = NewLoggerTracer(logger).Hooks()
+			// Add tracing logger
+			AddTracer(params.VMConfig, NewLoggerTracer(logger, cfg).Hooks())
 
 			logger.Debug("Execute block...")
 			res, err := executor.Execute(ctx, params)
@@ -62,19 +70,107 @@ func WithLog() ExecutorDecorator {
 	}
 }
 
+// TraceLevel is the level at which the logger tracer logs a category of EVM events
+type TraceLevel int
+
+const (
+	TraceLevelOff TraceLevel = iota
+	TraceLevelDebug
+	TraceLevelInfo
+	TraceLevelWarn
+)
+
+var traceLevelsStr = []string{
+	"off",
+	"debug",
+	"info",
+	"warn",
+}
+
+var traceLevels = map[string]TraceLevel{
+	traceLevelsStr[TraceLevelOff]:   TraceLevelOff,
+	traceLevelsStr[TraceLevelDebug]: TraceLevelDebug,
+	traceLevelsStr[TraceLevelInfo]:  TraceLevelInfo,
+	traceLevelsStr[TraceLevelWarn]:  TraceLevelWarn,
+}
+
+var zapTraceLevels = map[TraceLevel]zapcore.Level{
+	TraceLevelDebug: zap.DebugLevel,
+	TraceLevelInfo:  zap.InfoLevel,
+	TraceLevelWarn:  zap.WarnLevel,
+}
+
+func (l TraceLevel) String() string {
+	if l >= 0 && l < TraceLevel(len(traceLevelsStr)) {
+		return traceLevelsStr[l]
+	}
+	return "unknown"
+}
+
+// ParseTraceLevel parses a string and returns the corresponding trace level
+func ParseTraceLevel(level string) (TraceLevel, error) {
+	if l, ok := traceLevels[strings.ToLower(level)]; ok {
+		return l, nil
+	}
+	return TraceLevelOff, fmt.Errorf("invalid trace level %q (must be one of %q)", level, traceLevelsStr)
+}
+
+// LoggerTracerConfig configures the level at which each category of EVM events is logged
+type LoggerTracerConfig struct {
+	Tx         TraceLevel // Transactions and system calls start and end
+	CallFrames TraceLevel // EVM messages (call frames) start and end
+	Opcodes    TraceLevel // Every executed opcode
+	Faults     TraceLevel // Opcodes failing to execute and faults
+	Storage    TraceLevel // Storage reads (SLOAD) and writes
+}
+
+// DefaultLoggerTracerConfig returns the default logger tracer configuration
+// It logs transactions, call frames and faults at debug level
+func DefaultLoggerTracerConfig() *LoggerTracerConfig {
+	return &LoggerTracerConfig{
+		Tx:         TraceLevelDebug,
+		CallFrames: TraceLevelDebug,
+		Opcodes:    TraceLevelOff,
+		Faults:     TraceLevelDebug,
+		Storage:    TraceLevelOff,
+	}
+}
+
 // LoggerTracer is an EVM tracer that logs EVM execution
-// TODO: it would be nice to have a way to configure when to log and when not to log for each method
 type LoggerTracer struct {
+	cfg *LoggerTracerConfig
+
 	logger      *zap.Logger
 	blockLogger *zap.Logger
 	txLogger    *zap.Logger
 }
 
 // NewLoggerTracer creates a new logger tracer
-// We use a sugared logger because the DevX is better with it
-// If the performance is an issue, we can switch to a standard logger
-func NewLoggerTracer(logger *zap.Logger) *LoggerTracer {
-	return &LoggerTracer{logger: logger}
+func NewLoggerTracer(logger *zap.Logger, cfg *LoggerTracerConfig) *LoggerTracer {
+	return &LoggerTracer{logger: logger, cfg: cfg}
+}
+
+// logAt logs a message at the given trace level, if the level is enabled
+// fields are only computed if the message is effectively logged
+func logAt(logger *zap.Logger, level TraceLevel, msg string, fields func() []zap.Field) {
+	if level == TraceLevelOff {
+		return
+	}
+	if ce := logger.Check(zapTraceLevels[level], msg); ce != nil {
+		ce.Write(fields()...)
+	}
+}
+
+// currentLogger returns the logger of the transaction or system call being executed
+// It falls back to the block logger for events happening outside of a transaction (e.g. withdrawals)
+func (t *LoggerTracer) currentLogger() *zap.Logger {
+	if t.txLogger != nil {
+		return t.txLogger
+	}
+	if t.blockLogger != nil {
+		return t.blockLogger
+	}
+	return t.logger
 }
 
 // OnBlockStart logs block execution start
@@ -92,114 +188,174 @@ func (t *LoggerTracer) OnBlockEnd(_ error) {
 
 // OnTxStart logs transaction execution start
 func (t *LoggerTracer) OnTxStart(vm *tracing.VMContext, tx *gethtypes.Transaction, from gethcommon.Address) {
-	t.txLogger = t.blockLogger.With(
+	t.txLogger = t.currentLogger().With(
 		zap.String("tx.type", "transaction"),
 		zap.String("tx.hash", tx.Hash().Hex()),
 		zap.String("tx.from", from.Hex()),
 	)
 
-	t.txLogger.Debug("Execute transaction...",
-		zap.String("vm.blocknumber", vm.BlockNumber.String()),
-	)
+	logAt(t.txLogger, t.cfg.Tx, "Execute transaction...", func() []zap.Field {
+		return []zap.Field{zap.String("vm.blocknumber", vm.BlockNumber.String())}
+	})
 }
 
 // OnTxEnd logs transaction execution end
+// Failed transactions are logged at error level whenever transactions are logged
 func (t *LoggerTracer) OnTxEnd(receipt *gethtypes.Receipt, err error) {
 	if err != nil {
-		t.txLogger.Error("Transaction execution failed",
-			zap.Error(err),
-		)
+		if t.cfg.Tx != TraceLevelOff {
+			t.txLogger.Error("Transaction execution failed",
+				zap.Error(err),
+			)
+		}
 	} else {
-		t.txLogger.Debug("Transaction executed",
-			zap.String("receipt.txHash", receipt.TxHash.Hex()),
-			zap.Uint64("receipt.status", receipt.Status),
-			zap.Uint64("receipt.gasUsed", receipt.GasUsed),
-			zap.String("receipt.postState", hexutil.Encode(receipt.PostState)),
-			zap.String("receipt.contractAddress", receipt.ContractAddress.Hex()),
-		)
+		logAt(t.txLogger, t.cfg.Tx, "Transaction executed", func() []zap.Field {
+			return []zap.Field{
+				zap.String("receipt.txHash", receipt.TxHash.Hex()),
+				zap.Uint64("receipt.status", receipt.Status),
+				zap.Uint64("receipt.gasUsed", receipt.GasUsed),
+				zap.String("receipt.postState", hexutil.Encode(receipt.PostState)),
+				zap.String("receipt.contractAddress", receipt.ContractAddress.Hex()),
+			}
+		})
 	}
 	t.txLogger = nil
 }
 
 // OnSystemCallStart logs system call execution start
 func (t *LoggerTracer) OnSystemCallStart() {
-	t.txLogger = t.blockLogger.With(
+	t.txLogger = t.currentLogger().With(
 		zap.String("tx.type", "system"),
 	)
-	t.txLogger.Debug("Execute system call")
+	logAt(t.txLogger, t.cfg.Tx, "Execute system call", noFields)
 }
 
 // OnSystemCallEnd logs system call execution end
 func (t *LoggerTracer) OnSystemCallEnd() {
-	t.txLogger.Debug("System call executed")
+	logAt(t.txLogger, t.cfg.Tx, "System call executed", noFields)
 	t.txLogger = nil
 }
 
 // OnEnter logs EVM message execution start
 func (t *LoggerTracer) OnEnter(depth int, typ byte, from, to gethcommon.Address, input []byte, gas uint64, value *big.Int) {
-	if value == nil {
-		value = new(big.Int)
-	}
-	t.txLogger.Debug("Start EVM message execution...",
-		zap.String("msg.type", gethvm.OpCode(typ).String()),
-		zap.Int("msg.depth", depth),
-		zap.String("msg.from", from.Hex()),
-		zap.String("msg.to", to.Hex()),
-		zap.String("msg.input", hexutil.Encode(input)),
-		zap.Uint64("msg.gas", gas),
-		zap.String("msg.value", hexutil.EncodeBig(value)),
-	)
+	logAt(t.currentLogger(), t.cfg.CallFrames, "Start EVM message execution...", func() []zap.Field {
+		if value == nil {
+			value = new(big.Int)
+		}
+		return []zap.Field{
+			zap.String("msg.type", gethvm.OpCode(typ).String()),
+			zap.Int("msg.depth", depth),
+			zap.String("msg.from", from.Hex()),
+			zap.String("msg.to", to.Hex()),
+			zap.String("msg.input", hexutil.Encode(input)),
+			zap.Uint64("msg.gas", gas),
+			zap.String("msg.value", hexutil.EncodeBig(value)),
+		}
+	})
 }
 
 // OnExit logs EVM message execution end
 func (t *LoggerTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
-	t.txLogger.Debug("End EVM message execution",
-		zap.Int("msg.depth", depth),
-		zap.String("msg.output", hexutil.Encode(output)),
-		zap.Uint64("msg.gasUsed", gasUsed),
-		zap.Bool("msg.reverted", reverted),
-		zap.Error(err),
-	)
+	logAt(t.currentLogger(), t.cfg.CallFrames, "End EVM message execution", func() []zap.Field {
+		return []zap.Field{
+			zap.Int("msg.depth", depth),
+			zap.String("msg.output", hexutil.Encode(output)),
+			zap.Uint64("msg.gasUsed", gasUsed),
+			zap.Bool("msg.reverted", reverted),
+			zap.Error(err),
+		}
+	})
 }
 
-// OnOpcode logs opcode execution
-func (t *LoggerTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, _ tracing.OpContext, _ []byte, depth int, err error) {
-	if err != nil {
-		t.txLogger.Debug("Cannot execute opcode",
+// OnOpcode logs opcode execution, failing opcodes and storage reads
+func (t *LoggerTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, _ []byte, depth int, err error) {
+	opFields := func() []zap.Field {
+		return []zap.Field{
 			zap.Uint64("pc", pc),
 			zap.String("op", gethvm.OpCode(op).String()),
 			zap.Uint64("gas", gas),
 			zap.Uint64("cost", cost),
 			zap.Int("depth", depth),
 			zap.Error(err),
-		)
+		}
+	}
+
+	if err != nil {
+		logAt(t.currentLogger(), t.cfg.Faults, "Cannot execute opcode", opFields)
+		return
+	}
+
+	logAt(t.currentLogger(), t.cfg.Opcodes, "Execute opcode", opFields)
+
+	if gethvm.OpCode(op) == gethvm.SLOAD {
+		logAt(t.currentLogger(), t.cfg.Storage, "Read storage", func() []zap.Field {
+			fields := []zap.Field{zap.String("storage.address", scope.Address().Hex())}
+			if stack := scope.StackData(); len(stack) > 0 {
+				fields = append(fields, zap.String("storage.slot", gethcommon.Hash(stack[len(stack)-1].Bytes32()).Hex()))
+			}
+			return fields
+		})
 	}
 }
 
 // OnFault logs opcode execution fault
 func (t *LoggerTracer) OnFault(pc uint64, op byte, gas, cost uint64, _ tracing.OpContext, depth int, err error) {
-	t.txLogger.Debug("Failed to execute opcode",
-		zap.Uint64("pc", pc),
-		zap.String("op", gethvm.OpCode(op).String()),
-		zap.Uint64("gas", gas),
-		zap.Uint64("cost", cost),
-		zap.Int("depth", depth),
-		zap.Error(err),
-	)
+	logAt(t.currentLogger(), t.cfg.Faults, "Failed to execute opcode", func() []zap.Field {
+		return []zap.Field{
+			zap.Uint64("pc", pc),
+			zap.String("op", gethvm.OpCode(op).String()),
+			zap.Uint64("gas", gas),
+			zap.Uint64("cost", cost),
+			zap.Int("depth", depth),
+			zap.Error(err),
+		}
+	})
+}
+
+// OnStorageChange logs storage writes
+func (t *LoggerTracer) OnStorageChange(addr gethcommon.Address, slot, prev, new gethcommon.Hash) {
+	logAt(t.currentLogger(), t.cfg.Storage, "Write storage", func() []zap.Field {
+		return []zap.Field{
+			zap.String("storage.address", addr.Hex()),
+			zap.String("storage.slot", slot.Hex()),
+			zap.String("storage.prev", prev.Hex()),
+			zap.String("storage.new", new.Hex()),
+		}
+	})
 }
 
 // Hooks returns the logger tracer hooks
+// Hooks of disabled event categories are not set, so they have no overhead on execution
 func (t *LoggerTracer) Hooks() *tracing.Hooks {
-	return &tracing.Hooks{
+	hooks := &tracing.Hooks{
 		OnBlockStart:      t.OnBlockStart,
 		OnBlockEnd:        t.OnBlockEnd,
 		OnTxStart:         t.OnTxStart,
 		OnTxEnd:           t.OnTxEnd,
-		OnEnter:           t.OnEnter,
-		OnExit:            t.OnExit,
-		OnOpcode:          t.OnOpcode,
-		OnFault:           t.OnFault,
 		OnSystemCallStart: t.OnSystemCallStart,
 		OnSystemCallEnd:   t.OnSystemCallEnd,
 	}
+
+	if t.cfg.CallFrames != TraceLevelOff {
+		hooks.OnEnter = t.OnEnter
+		hooks.OnExit = t.OnExit
+	}
+
+	if t.cfg.Opcodes != TraceLevelOff || t.cfg.Faults != TraceLevelOff || t.cfg.Storage != TraceLevelOff {
+		hooks.OnOpcode = t.OnOpcode
+	}
+
+	if t.cfg.Faults != TraceLevelOff {
+		hooks.OnFault = t.OnFault
+	}
+
+	if t.cfg.Storage != TraceLevelOff {
+		hooks.OnStorageChange = t.OnStorageChange
+	}
+
+	return hooks
+}
+
+func noFields() []zap.Field {
+	return nil
 }
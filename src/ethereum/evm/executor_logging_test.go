@@ -1,12 +1,76 @@
 package evm
 
 import (
+	"math/big"
 	"testing"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/holiman/uint256"
 	"github.com/kkrt-labs/go-utils/app/svc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestImplementInterface(t *testing.T) {
 	assert.Implements(t, (*svc.Taggable)(nil), WithTags(nil))
 }
+
+func TestParseTraceLevel(t *testing.T) {
+	for _, level := range []TraceLevel{TraceLevelOff, TraceLevelDebug, TraceLevelInfo, TraceLevelWarn} {
+		parsed, err := ParseTraceLevel(level.String())
+		require.NoError(t, err)
+		assert.Equal(t, level, parsed)
+	}
+
+	_, err := ParseTraceLevel("trace")
+	require.Error(t, err)
+}
+
+func TestLoggerTracerHooks(t *testing.T) {
+	hooks := NewLoggerTracer(zap.NewNop(), DefaultLoggerTracerConfig()).Hooks()
+	assert.NotNil(t, hooks.OnTxStart)
+	assert.NotNil(t, hooks.OnEnter)
+	assert.NotNil(t, hooks.OnOpcode)
+	assert.NotNil(t, hooks.OnFault)
+	assert.Nil(t, hooks.OnStorageChange)
+
+	hooks = NewLoggerTracer(zap.NewNop(), &LoggerTracerConfig{Tx: TraceLevelDebug}).Hooks()
+	assert.NotNil(t, hooks.OnTxStart)
+	assert.Nil(t, hooks.OnEnter)
+	assert.Nil(t, hooks.OnOpcode)
+	assert.Nil(t, hooks.OnFault)
+	assert.Nil(t, hooks.OnStorageChange)
+}
+
+func TestLoggerTracerLevels(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	tracer := NewLoggerTracer(zap.New(core), &LoggerTracerConfig{
+		Tx:      TraceLevelInfo,
+		Storage: TraceLevelDebug,
+	})
+	hooks := tracer.Hooks()
+
+	hooks.OnBlockStart(tracing.BlockEvent{Block: gethtypes.NewBlockWithHeader(&gethtypes.Header{Number: big.NewInt(1)})})
+	hooks.OnTxStart(&tracing.VMContext{BlockNumber: big.NewInt(1)}, gethtypes.NewTx(&gethtypes.LegacyTx{}), gethcommon.Address{})
+	hooks.OnOpcode(0, byte(gethvm.PUSH1), 100, 3, &testOpContext{}, nil, 1, nil)
+	hooks.OnOpcode(2, byte(gethvm.SLOAD), 97, 2100, &testOpContext{stack: []uint256.Int{*uint256.NewInt(1)}}, nil, 1, nil)
+	hooks.OnStorageChange(gethcommon.HexToAddress("0x1"), gethcommon.HexToHash("0x1"), gethcommon.Hash{}, gethcommon.HexToHash("0x2"))
+	hooks.OnTxEnd(&gethtypes.Receipt{}, nil)
+	hooks.OnBlockEnd(nil)
+
+	var entries []string
+	for _, entry := range logs.All() {
+		entries = append(entries, entry.Level.String()+" "+entry.Message)
+	}
+	assert.Equal(t, []string{
+		"info Execute transaction...",
+		"debug Read storage",
+		"debug Write storage",
+		"info Transaction executed",
+	}, entries)
+}
@@ -0,0 +1,150 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/kkrt-labs/go-utils/jsonrpc"
+	"github.com/kkrt-labs/go-utils/log"
+	"go.uber.org/zap"
+)
+
+// ReceiptsFetcher fetches the receipts of a block from a reference node
+type ReceiptsFetcher interface {
+	BlockReceipts(ctx context.Context, blockHash gethcommon.Hash) (gethtypes.Receipts, error)
+}
+
+// ReceiptsFetcherFunc is a function that fetches the receipts of a block
+type ReceiptsFetcherFunc func(ctx context.Context, blockHash gethcommon.Hash) (gethtypes.Receipts, error)
+
+func (f ReceiptsFetcherFunc) BlockReceipts(ctx context.Context, blockHash gethcommon.Hash) (gethtypes.Receipts, error) {
+	return f(ctx, blockHash)
+}
+
+// NewRPCReceiptsFetcher creates a ReceiptsFetcher fetching receipts with eth_getBlockReceipts
+func NewRPCReceiptsFetcher(remote jsonrpc.Client) ReceiptsFetcher {
+	return ReceiptsFetcherFunc(func(ctx context.Context, blockHash gethcommon.Hash) (gethtypes.Receipts, error) {
+		var receipts gethtypes.Receipts
+		err := remote.Call(
+			ctx,
+			&jsonrpc.Request{
+				Method: "eth_getBlockReceipts",
+				Params: []any{blockHash},
+			},
+			&receipts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return receipts, nil
+	})
+}
+
+// WithReceiptsCheck is an executor decorator that cross-checks the receipts of the execution
+// against the receipts of the block fetched from a reference node.
+//
+// It compares the status, gas used, cumulative gas used and logs of every transaction
+// and reports the first divergent transaction.
+// Failing to fetch the reference receipts does not fail the execution.
+func WithReceiptsCheck(fetcher ReceiptsFetcher) ExecutorDecorator {
+	return func(executor Executor) Executor {
+		return ExecutorFunc(func(ctx context.Context, params *ExecParams) (*core.ProcessResult, error) {
+			res, err := executor.Execute(ctx, params)
+			if res == nil {
+				// Block processing failed, there are no receipts to check
+				return res, err
+			}
+
+			expected, fetchErr := fetcher.BlockReceipts(ctx, params.Block.Hash())
+			if fetchErr != nil {
+				log.LoggerFromContext(ctx).Warn("Failed to fetch receipts, skip receipts check", zap.Error(fetchErr))
+				return res, err
+			}
+
+			if mismatchErr := CompareReceipts(expected, res.Receipts); mismatchErr != nil {
+				if err != nil {
+					return res, fmt.Errorf("%w (%v)", err, mismatchErr)
+				}
+				return res, mismatchErr
+			}
+
+			return res, err
+		})
+	}
+}
+
+// ReceiptMismatchError is returned when execution receipts diverge from the reference receipts
+type ReceiptMismatchError struct {
+	TxIndex int             // Index of the first divergent transaction
+	TxHash  gethcommon.Hash // Hash of the first divergent transaction
+	Diffs   []string        // Human-readable differences
+}
+
+func (e *ReceiptMismatchError) Error() string {
+	return fmt.Sprintf("receipts mismatch at tx %d (%v):\n  %v", e.TxIndex, e.TxHash.Hex(), strings.Join(e.Diffs, "\n  "))
+}
+
+// CompareReceipts compares the receipts of an execution with reference receipts
+// It returns a *ReceiptMismatchError for the first divergent transaction, or nil if receipts match
+func CompareReceipts(expected, actual gethtypes.Receipts) error {
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		switch {
+		case i >= len(actual):
+			return &ReceiptMismatchError{TxIndex: i, TxHash: expected[i].TxHash, Diffs: []string{"receipt missing from execution"}}
+		case i >= len(expected):
+			return &ReceiptMismatchError{TxIndex: i, TxHash: actual[i].TxHash, Diffs: []string{"receipt missing from reference"}}
+		}
+
+		if diffs := diffReceipt(expected[i], actual[i]); len(diffs) > 0 {
+			return &ReceiptMismatchError{TxIndex: i, TxHash: expected[i].TxHash, Diffs: diffs}
+		}
+	}
+	return nil
+}
+
+func diffReceipt(expected, actual *gethtypes.Receipt) []string {
+	var diffs []string
+	if expected.Status != actual.Status {
+		diffs = append(diffs, fmt.Sprintf("status: expected %d, got %d", expected.Status, actual.Status))
+	}
+	if expected.GasUsed != actual.GasUsed {
+		diffs = append(diffs, fmt.Sprintf("gasUsed: expected %d, got %d", expected.GasUsed, actual.GasUsed))
+	}
+	if expected.CumulativeGasUsed != actual.CumulativeGasUsed {
+		diffs = append(diffs, fmt.Sprintf("cumulativeGasUsed: expected %d, got %d", expected.CumulativeGasUsed, actual.CumulativeGasUsed))
+	}
+	if len(expected.Logs) != len(actual.Logs) {
+		diffs = append(diffs, fmt.Sprintf("logs: expected %d logs, got %d", len(expected.Logs), len(actual.Logs)))
+		return diffs
+	}
+	for j := range expected.Logs {
+		diffs = append(diffs, diffLog(j, expected.Logs[j], actual.Logs[j])...)
+	}
+	return diffs
+}
+
+func diffLog(index int, expected, actual *gethtypes.Log) []string {
+	var diffs []string
+	if expected.Address != actual.Address {
+		diffs = append(diffs, fmt.Sprintf("logs[%d].address: expected %v, got %v", index, expected.Address.Hex(), actual.Address.Hex()))
+	}
+	if len(expected.Topics) != len(actual.Topics) {
+		diffs = append(diffs, fmt.Sprintf("logs[%d].topics: expected %d topics, got %d", index, len(expected.Topics), len(actual.Topics)))
+	} else {
+		for k := range expected.Topics {
+			if expected.Topics[k] != actual.Topics[k] {
+				diffs = append(diffs, fmt.Sprintf("logs[%d].topics[%d]: expected %v, got %v", index, k, expected.Topics[k].Hex(), actual.Topics[k].Hex()))
+			}
+		}
+	}
+	if !bytes.Equal(expected.Data, actual.Data) {
+		diffs = append(diffs, fmt.Sprintf("logs[%d].data: expected %v, got %v", index, hexutil.Encode(expected.Data), hexutil.Encode(actual.Data)))
+	}
+	return diffs
+}
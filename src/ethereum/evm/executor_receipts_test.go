@@ -0,0 +1,147 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReceipts() gethtypes.Receipts {
+	return gethtypes.Receipts{
+		{
+			Status:            gethtypes.ReceiptStatusSuccessful,
+			GasUsed:           21000,
+			CumulativeGasUsed: 21000,
+			TxHash:            gethcommon.HexToHash("0x1"),
+		},
+		{
+			Status:            gethtypes.ReceiptStatusSuccessful,
+			GasUsed:           30000,
+			CumulativeGasUsed: 51000,
+			TxHash:            gethcommon.HexToHash("0x2"),
+			Logs: []*gethtypes.Log{
+				{
+					Address: gethcommon.HexToAddress("0xa"),
+					Topics:  []gethcommon.Hash{gethcommon.HexToHash("0xb")},
+					Data:    []byte{0x1},
+				},
+			},
+		},
+	}
+}
+
+func TestCompareReceipts(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		modify        func(gethtypes.Receipts) gethtypes.Receipts
+		expectedIndex int
+		expectedDiffs []string
+	}{
+		{
+			desc:          "identical receipts",
+			modify:        func(r gethtypes.Receipts) gethtypes.Receipts { return r },
+			expectedIndex: -1,
+		},
+		{
+			desc: "status",
+			modify: func(r gethtypes.Receipts) gethtypes.Receipts {
+				r[0].Status = gethtypes.ReceiptStatusFailed
+				return r
+			},
+			expectedIndex: 0,
+			expectedDiffs: []string{"status: expected 1, got 0"},
+		},
+		{
+			desc: "gas",
+			modify: func(r gethtypes.Receipts) gethtypes.Receipts {
+				r[1].GasUsed = 30001
+				r[1].CumulativeGasUsed = 51001
+				return r
+			},
+			expectedIndex: 1,
+			expectedDiffs: []string{"gasUsed: expected 30000, got 30001", "cumulativeGasUsed: expected 51000, got 51001"},
+		},
+		{
+			desc: "log",
+			modify: func(r gethtypes.Receipts) gethtypes.Receipts {
+				r[1].Logs[0].Topics[0] = gethcommon.HexToHash("0xc")
+				r[1].Logs[0].Data = []byte{0x2}
+				return r
+			},
+			expectedIndex: 1,
+			expectedDiffs: []string{
+				"logs[0].topics[0]: expected 0x000000000000000000000000000000000000000000000000000000000000000b, got 0x000000000000000000000000000000000000000000000000000000000000000c",
+				"logs[0].data: expected 0x01, got 0x02",
+			},
+		},
+		{
+			desc: "missing receipt",
+			modify: func(r gethtypes.Receipts) gethtypes.Receipts {
+				return r[:1]
+			},
+			expectedIndex: 1,
+			expectedDiffs: []string{"receipt missing from execution"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := CompareReceipts(testReceipts(), tc.modify(testReceipts()))
+			if tc.expectedIndex < 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var mismatchErr *ReceiptMismatchError
+			require.ErrorAs(t, err, &mismatchErr)
+			assert.Equal(t, tc.expectedIndex, mismatchErr.TxIndex)
+			assert.Equal(t, tc.expectedDiffs, mismatchErr.Diffs)
+		})
+	}
+}
+
+func TestWithReceiptsCheck(t *testing.T) {
+	block := gethtypes.NewBlockWithHeader(&gethtypes.Header{Number: big.NewInt(1)})
+	fetcher := ReceiptsFetcherFunc(func(_ context.Context, blockHash gethcommon.Hash) (gethtypes.Receipts, error) {
+		assert.Equal(t, block.Hash(), blockHash)
+		return testReceipts(), nil
+	})
+
+	t.Run("matching receipts", func(t *testing.T) {
+		executor := WithReceiptsCheck(fetcher)(ExecutorFunc(func(_ context.Context, _ *ExecParams) (*core.ProcessResult, error) {
+			return &core.ProcessResult{Receipts: testReceipts()}, nil
+		}))
+		_, err := executor.Execute(context.TODO(), &ExecParams{Block: block})
+		require.NoError(t, err)
+	})
+
+	t.Run("divergent receipts", func(t *testing.T) {
+		executor := WithReceiptsCheck(fetcher)(ExecutorFunc(func(_ context.Context, _ *ExecParams) (*core.ProcessResult, error) {
+			receipts := testReceipts()
+			receipts[1].Status = gethtypes.ReceiptStatusFailed
+			return &core.ProcessResult{Receipts: receipts}, fmt.Errorf("block validation failed")
+		}))
+		_, err := executor.Execute(context.TODO(), &ExecParams{Block: block})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "block validation failed")
+		assert.Contains(t, err.Error(), "receipts mismatch at tx 1")
+	})
+
+	t.Run("fetch failure", func(t *testing.T) {
+		failingFetcher := ReceiptsFetcherFunc(func(_ context.Context, _ gethcommon.Hash) (gethtypes.Receipts, error) {
+			return nil, fmt.Errorf("method not found")
+		})
+		executor := WithReceiptsCheck(failingFetcher)(ExecutorFunc(func(_ context.Context, _ *ExecParams) (*core.ProcessResult, error) {
+			return &core.ProcessResult{}, nil
+		}))
+		_, err := executor.Execute(context.TODO(), &ExecParams{Block: block})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,142 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/kkrt-labs/go-utils/log"
+	"go.uber.org/zap"
+)
+
+// TraceWriter stores transaction traces
+type TraceWriter interface {
+	// StoreTxTrace stores the EIP-3155 JSON-lines trace of a transaction of a block,
+	// executed within the range of blocks [fromBlock, toBlock] (see ContextWithBlockRange)
+	StoreTxTrace(ctx context.Context, chainID, fromBlock, toBlock, blockNumber uint64, txIndex int, txHash gethcommon.Hash, trace io.Reader) error
+}
+
+// WithStructLogTrace is an executor decorator that records an EIP-3155 struct log trace (JSON-lines)
+// for every transaction of the block and stores it with the given writer
+//
+// Failing to store a trace does not fail the execution.
+func WithStructLogTrace(w TraceWriter) ExecutorDecorator {
+	return func(executor Executor) Executor {
+		return ExecutorFunc(func(ctx context.Context, params *ExecParams) (*core.ProcessResult, error) {
+			tracer := NewStructLogTracer(ctx, w, params.Chain.Config().ChainID, params.Block.NumberU64())
+			AddTracer(params.VMConfig, tracer.Hooks())
+			return executor.Execute(ctx, params)
+		})
+	}
+}
+
+type blockRangeKey struct{}
+
+type blockRange struct {
+	from, to uint64
+}
+
+// ContextWithBlockRange returns a context indicating that the executed blocks are part of the range of blocks [from, to]
+// executed together (e.g. the blocks of a prover input)
+func ContextWithBlockRange(ctx context.Context, from, to uint64) context.Context {
+	return context.WithValue(ctx, blockRangeKey{}, &blockRange{from: from, to: to})
+}
+
+// blockRangeFromContext returns the range of blocks attached to the context, defaulting to the single given block
+func blockRangeFromContext(ctx context.Context, blockNumber uint64) (from, to uint64) {
+	if r, ok := ctx.Value(blockRangeKey{}).(*blockRange); ok {
+		return r.from, r.to
+	}
+	return blockNumber, blockNumber
+}
+
+// StructLogTracer is an EVM tracer that records an EIP-3155 struct log trace per transaction
+// System calls are not traced
+type StructLogTracer struct {
+	ctx         context.Context
+	writer      TraceWriter
+	chainID     uint64
+	fromBlock   uint64
+	toBlock     uint64
+	blockNumber uint64
+
+	txIndex int
+	tx      *gethtypes.Transaction
+	buf     *bytes.Buffer
+	txHooks *tracing.Hooks // struct logger of the transaction being executed
+}
+
+// NewStructLogTracer creates a new struct log tracer of a block
+// (the range of blocks the block is executed within is taken from the context, see ContextWithBlockRange)
+func NewStructLogTracer(ctx context.Context, w TraceWriter, chainID *big.Int, blockNumber uint64) *StructLogTracer {
+	from, to := blockRangeFromContext(ctx, blockNumber)
+	return &StructLogTracer{
+		ctx:         ctx,
+		writer:      w,
+		chainID:     chainID.Uint64(),
+		fromBlock:   from,
+		toBlock:     to,
+		blockNumber: blockNumber,
+	}
+}
+
+// OnTxStart starts recording the trace of a transaction
+func (t *StructLogTracer) OnTxStart(vm *tracing.VMContext, tx *gethtypes.Transaction, from gethcommon.Address) {
+	t.tx = tx
+	t.buf = new(bytes.Buffer)
+	t.txHooks = logger.NewJSONLogger(&logger.Config{EnableReturnData: true}, t.buf)
+	t.txHooks.OnTxStart(vm, tx, from)
+}
+
+// OnTxEnd writes the trace of the transaction
+func (t *StructLogTracer) OnTxEnd(_ *gethtypes.Receipt, _ error) {
+	err := t.writer.StoreTxTrace(t.ctx, t.chainID, t.fromBlock, t.toBlock, t.blockNumber, t.txIndex, t.tx.Hash(), bytes.NewReader(t.buf.Bytes()))
+	if err != nil {
+		log.LoggerFromContext(t.ctx).Warn("Failed to write transaction trace",
+			zap.Int("tx.index", t.txIndex),
+			zap.String("tx.hash", t.tx.Hash().Hex()),
+			zap.Error(err),
+		)
+	}
+
+	t.txIndex++
+	t.tx, t.buf, t.txHooks = nil, nil, nil
+}
+
+// OnExit records the end of an EVM message execution
+func (t *StructLogTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.txHooks != nil {
+		t.txHooks.OnExit(depth, output, gasUsed, err, reverted)
+	}
+}
+
+// OnOpcode records an opcode execution
+func (t *StructLogTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if t.txHooks != nil {
+		t.txHooks.OnOpcode(pc, op, gas, cost, scope, rData, depth, err)
+	}
+}
+
+// OnFault records an opcode execution fault
+func (t *StructLogTracer) OnFault(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+	if t.txHooks != nil {
+		t.txHooks.OnFault(pc, op, gas, cost, scope, depth, err)
+	}
+}
+
+// Hooks returns the struct log tracer hooks
+func (t *StructLogTracer) Hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnTxStart: t.OnTxStart,
+		OnTxEnd:   t.OnTxEnd,
+		OnExit:    t.OnExit,
+		OnOpcode:  t.OnOpcode,
+		OnFault:   t.OnFault,
+	}
+}
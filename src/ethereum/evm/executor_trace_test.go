@@ -0,0 +1,63 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTraceWriter struct {
+	traces map[string]string
+}
+
+func (w *testTraceWriter) StoreTxTrace(_ context.Context, chainID, fromBlock, toBlock, blockNumber uint64, txIndex int, txHash gethcommon.Hash, trace io.Reader) error {
+	b, err := io.ReadAll(trace)
+	if err != nil {
+		return err
+	}
+	w.traces[fmt.Sprintf("%d/%d-%d/%d/%d-%s", chainID, fromBlock, toBlock, blockNumber, txIndex, txHash.Hex())] = string(b)
+	return nil
+}
+
+func TestStructLogTracer(t *testing.T) {
+	w := &testTraceWriter{traces: make(map[string]string)}
+	hooks := NewStructLogTracer(context.TODO(), w, big.NewInt(1), 10).Hooks()
+
+	tx0 := gethtypes.NewTx(&gethtypes.LegacyTx{Nonce: 0})
+	tx1 := gethtypes.NewTx(&gethtypes.LegacyTx{Nonce: 1})
+
+	// Events outside of a transaction (e.g. system calls) are not traced
+	hooks.OnExit(0, nil, 100, nil, false)
+
+	hooks.OnTxStart(&tracing.VMContext{}, tx0, gethcommon.Address{})
+	hooks.OnExit(0, []byte{0x1}, 21000, nil, false)
+	hooks.OnTxEnd(&gethtypes.Receipt{}, nil)
+
+	hooks.OnTxStart(&tracing.VMContext{}, tx1, gethcommon.Address{})
+	hooks.OnExit(0, nil, 30000, fmt.Errorf("execution reverted"), true)
+	hooks.OnTxEnd(&gethtypes.Receipt{}, nil)
+
+	require.Len(t, w.traces, 2)
+	assert.Equal(t, "{\"output\":\"01\",\"gasUsed\":\"0x5208\"}\n", w.traces[fmt.Sprintf("1/10-10/10/0-%s", tx0.Hash().Hex())])
+	assert.Equal(t, "{\"output\":\"\",\"gasUsed\":\"0x7530\",\"error\":\"execution reverted\"}\n", w.traces[fmt.Sprintf("1/10-10/10/1-%s", tx1.Hash().Hex())])
+}
+
+func TestStructLogTracerBlockRange(t *testing.T) {
+	w := &testTraceWriter{traces: make(map[string]string)}
+	ctx := ContextWithBlockRange(context.TODO(), 9, 11)
+	hooks := NewStructLogTracer(ctx, w, big.NewInt(1), 10).Hooks()
+
+	tx := gethtypes.NewTx(&gethtypes.LegacyTx{})
+	hooks.OnTxStart(&tracing.VMContext{}, tx, gethcommon.Address{})
+	hooks.OnTxEnd(&gethtypes.Receipt{}, nil)
+
+	assert.Contains(t, w.traces, fmt.Sprintf("1/9-11/10/0-%s", tx.Hash().Hex()))
+}
@@ -0,0 +1,100 @@
+package evm
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core"
+	gethstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// ChainContext is the chain a block is executed on.
+// It resolves the ancestor headers (e.g. for BLOCKHASH) and provides the chain configuration and consensus engine.
+type ChainContext interface {
+	core.ChainContext
+	consensus.ChainHeaderReader
+}
+
+// processBlock applies the block on the state and returns the execution result
+//
+// It mirrors core.StateProcessor.Process, which can only run on a core.HeaderChain (that requires a genesis),
+// on any ChainContext.
+func processBlock(chain ChainContext, block *types.Block, statedb *gethstate.StateDB, cfg vm.Config) (*core.ProcessResult, error) {
+	var (
+		config      = chain.Config()
+		receipts    types.Receipts
+		usedGas     = new(uint64)
+		header      = block.Header()
+		blockHash   = block.Hash()
+		blockNumber = block.Number()
+		allLogs     []*types.Log
+		gp          = new(core.GasPool).AddGas(block.GasLimit())
+		signer      = types.MakeSigner(config, header.Number, header.Time)
+	)
+
+	// Mutate the block and state according to any hard-fork specs
+	if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(block.Number()) == 0 {
+		misc.ApplyDAOHardFork(statedb)
+	}
+
+	// Apply pre-execution system calls
+	var tracingStateDB = vm.StateDB(statedb)
+	if hooks := cfg.Tracer; hooks != nil {
+		tracingStateDB = gethstate.NewHookedState(statedb, hooks)
+	}
+	evm := vm.NewEVM(core.NewEVMBlockContext(header, chain, nil), tracingStateDB, config, cfg)
+
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*beaconRoot, evm)
+	}
+	if config.IsPrague(block.Number(), block.Time()) || config.IsVerkle(block.Number(), block.Time()) {
+		core.ProcessParentBlockHash(block.ParentHash(), evm)
+	}
+
+	// Iterate over and process the individual transactions
+	for i, tx := range block.Transactions() {
+		msg, err := core.TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+		}
+		statedb.SetTxContext(tx.Hash(), i)
+
+		receipt, err := core.ApplyTransactionWithEVM(msg, gp, statedb, blockNumber, blockHash, tx, usedGas, evm)
+		if err != nil {
+			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+		}
+		receipts = append(receipts, receipt)
+		allLogs = append(allLogs, receipt.Logs...)
+	}
+
+	// Read requests if Prague is enabled
+	var requests [][]byte
+	if config.IsPrague(block.Number(), block.Time()) {
+		requests = [][]byte{}
+		// EIP-6110
+		if err := core.ParseDepositLogs(&requests, allLogs, config); err != nil {
+			return nil, err
+		}
+		// EIP-7002
+		if err := core.ProcessWithdrawalQueue(&requests, evm); err != nil {
+			return nil, err
+		}
+		// EIP-7251
+		if err := core.ProcessConsolidationQueue(&requests, evm); err != nil {
+			return nil, err
+		}
+	}
+
+	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
+	chain.Engine().Finalize(chain, header, tracingStateDB, block.Body())
+
+	return &core.ProcessResult{
+		Receipts: receipts,
+		Requests: requests,
+		Logs:     allLogs,
+		GasUsed:  *usedGas,
+	}, nil
+}
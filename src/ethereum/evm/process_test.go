@@ -0,0 +1,85 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	gethtrie "github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+	"github.com/kkrt-labs/zk-pig/src/ethereum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteOnAncestors(t *testing.T) {
+	cfg := *params.MergedTestChainConfig
+	cfg.PragueTime, cfg.OsakaTime, cfg.VerkleTime = nil, nil, nil
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	contract := gethcommon.HexToAddress("0xc0")
+	code := []byte{
+		0x60, 0x09, // PUSH1 9
+		0x40,       // BLOCKHASH
+		0x60, 0x00, // PUSH1 0
+		0x55, // SSTORE
+		0x00, // STOP
+	}
+
+	// Chain only made of the ancestors of the executed block (no genesis)
+	db := rawdb.NewMemoryDatabase()
+	grandParent := &gethtypes.Header{Number: big.NewInt(8), Difficulty: big.NewInt(0)}
+	parent := &gethtypes.Header{Number: big.NewInt(9), Difficulty: big.NewInt(0), ParentHash: grandParent.Hash()}
+	ethereum.WriteHeaders(db, grandParent, parent)
+	chain, err := ethereum.NewChain(&cfg, db)
+	require.NoError(t, err)
+
+	statedb, err := gethstate.New(gethtypes.EmptyRootHash, gethstate.NewDatabase(triedb.NewDatabase(db, nil), nil))
+	require.NoError(t, err)
+	statedb.SetBalance(sender, uint256.NewInt(1e18), tracing.BalanceChangeUnspecified)
+	statedb.SetCode(contract, code)
+
+	tx, err := gethtypes.SignNewTx(key, gethtypes.LatestSigner(&cfg), &gethtypes.DynamicFeeTx{
+		ChainID:   cfg.ChainID,
+		Gas:       100_000,
+		GasFeeCap: big.NewInt(params.GWei),
+		GasTipCap: big.NewInt(1),
+		To:        &contract,
+	})
+	require.NoError(t, err)
+
+	header := &gethtypes.Header{
+		Number:     big.NewInt(10),
+		ParentHash: parent.Hash(),
+		Difficulty: big.NewInt(0),
+		GasLimit:   30_000_000,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Time:       1,
+	}
+	block := gethtypes.NewBlock(header, &gethtypes.Body{Transactions: gethtypes.Transactions{tx}}, nil, gethtrie.NewStackTrie(nil))
+
+	res, err := NewExecutor().Execute(context.TODO(), &ExecParams{
+		VMConfig: &vm.Config{},
+		Block:    block,
+		State:    statedb,
+		Chain:    chain,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Receipts, 1)
+	assert.Equal(t, gethtypes.ReceiptStatusSuccessful, res.Receipts[0].Status)
+	assert.Equal(t, res.Receipts[0].GasUsed, res.GasUsed)
+
+	// BLOCKHASH is resolved from the ancestors
+	assert.Equal(t, parent.Hash(), statedb.GetState(contract, gethcommon.Hash{}))
+}
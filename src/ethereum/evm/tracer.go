@@ -0,0 +1,271 @@
+package evm
+
+import (
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// AddTracer adds a tracer to the VM configuration
+// If a tracer is already set, both tracers are run through a multiplexer
+func AddTracer(vmCfg *gethvm.Config, hooks *tracing.Hooks) {
+	if vmCfg.Tracer == nil {
+		vmCfg.Tracer = hooks
+		return
+	}
+	vmCfg.Tracer = NewMuxTracer(vmCfg.Tracer, hooks)
+}
+
+// NewMuxTracer creates a tracer that forwards every event to each of the given tracers, in order
+//
+// A hook is set on the multiplexer if at least one of the tracers sets it at creation time.
+// System call hooks are always set, as some tracers swap their hooks during system calls.
+func NewMuxTracer(tracers ...*tracing.Hooks) *tracing.Hooks {
+	m := &muxTracer{tracers: tracers}
+
+	hooks := &tracing.Hooks{
+		OnSystemCallStartV2: m.OnSystemCallStartV2,
+		OnSystemCallEnd:     m.OnSystemCallEnd,
+	}
+	for _, t := range tracers {
+		if t.OnTxStart != nil {
+			hooks.OnTxStart = m.OnTxStart
+		}
+		if t.OnTxEnd != nil {
+			hooks.OnTxEnd = m.OnTxEnd
+		}
+		if t.OnEnter != nil {
+			hooks.OnEnter = m.OnEnter
+		}
+		if t.OnExit != nil {
+			hooks.OnExit = m.OnExit
+		}
+		if t.OnOpcode != nil {
+			hooks.OnOpcode = m.OnOpcode
+		}
+		if t.OnFault != nil {
+			hooks.OnFault = m.OnFault
+		}
+		if t.OnGasChange != nil {
+			hooks.OnGasChange = m.OnGasChange
+		}
+		if t.OnBlockchainInit != nil {
+			hooks.OnBlockchainInit = m.OnBlockchainInit
+		}
+		if t.OnClose != nil {
+			hooks.OnClose = m.OnClose
+		}
+		if t.OnBlockStart != nil {
+			hooks.OnBlockStart = m.OnBlockStart
+		}
+		if t.OnBlockEnd != nil {
+			hooks.OnBlockEnd = m.OnBlockEnd
+		}
+		if t.OnSkippedBlock != nil {
+			hooks.OnSkippedBlock = m.OnSkippedBlock
+		}
+		if t.OnGenesisBlock != nil {
+			hooks.OnGenesisBlock = m.OnGenesisBlock
+		}
+		if t.OnBalanceChange != nil {
+			hooks.OnBalanceChange = m.OnBalanceChange
+		}
+		if t.OnNonceChange != nil || t.OnNonceChangeV2 != nil {
+			hooks.OnNonceChangeV2 = m.OnNonceChangeV2
+		}
+		if t.OnCodeChange != nil {
+			hooks.OnCodeChange = m.OnCodeChange
+		}
+		if t.OnStorageChange != nil {
+			hooks.OnStorageChange = m.OnStorageChange
+		}
+		if t.OnLog != nil {
+			hooks.OnLog = m.OnLog
+		}
+		if t.OnBlockHashRead != nil {
+			hooks.OnBlockHashRead = m.OnBlockHashRead
+		}
+	}
+
+	return hooks
+}
+
+type muxTracer struct {
+	tracers []*tracing.Hooks
+}
+
+func (m *muxTracer) OnTxStart(vm *tracing.VMContext, tx *gethtypes.Transaction, from gethcommon.Address) {
+	for _, t := range m.tracers {
+		if t.OnTxStart != nil {
+			t.OnTxStart(vm, tx, from)
+		}
+	}
+}
+
+func (m *muxTracer) OnTxEnd(receipt *gethtypes.Receipt, err error) {
+	for _, t := range m.tracers {
+		if t.OnTxEnd != nil {
+			t.OnTxEnd(receipt, err)
+		}
+	}
+}
+
+func (m *muxTracer) OnEnter(depth int, typ byte, from, to gethcommon.Address, input []byte, gas uint64, value *big.Int) {
+	for _, t := range m.tracers {
+		if t.OnEnter != nil {
+			t.OnEnter(depth, typ, from, to, input, gas, value)
+		}
+	}
+}
+
+func (m *muxTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	for _, t := range m.tracers {
+		if t.OnExit != nil {
+			t.OnExit(depth, output, gasUsed, err, reverted)
+		}
+	}
+}
+
+func (m *muxTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	for _, t := range m.tracers {
+		if t.OnOpcode != nil {
+			t.OnOpcode(pc, op, gas, cost, scope, rData, depth, err)
+		}
+	}
+}
+
+func (m *muxTracer) OnFault(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+	for _, t := range m.tracers {
+		if t.OnFault != nil {
+			t.OnFault(pc, op, gas, cost, scope, depth, err)
+		}
+	}
+}
+
+func (m *muxTracer) OnGasChange(old, new uint64, reason tracing.GasChangeReason) {
+	for _, t := range m.tracers {
+		if t.OnGasChange != nil {
+			t.OnGasChange(old, new, reason)
+		}
+	}
+}
+
+func (m *muxTracer) OnBlockchainInit(chainConfig *params.ChainConfig) {
+	for _, t := range m.tracers {
+		if t.OnBlockchainInit != nil {
+			t.OnBlockchainInit(chainConfig)
+		}
+	}
+}
+
+func (m *muxTracer) OnClose() {
+	for _, t := range m.tracers {
+		if t.OnClose != nil {
+			t.OnClose()
+		}
+	}
+}
+
+func (m *muxTracer) OnBlockStart(event tracing.BlockEvent) {
+	for _, t := range m.tracers {
+		if t.OnBlockStart != nil {
+			t.OnBlockStart(event)
+		}
+	}
+}
+
+func (m *muxTracer) OnBlockEnd(err error) {
+	for _, t := range m.tracers {
+		if t.OnBlockEnd != nil {
+			t.OnBlockEnd(err)
+		}
+	}
+}
+
+func (m *muxTracer) OnSkippedBlock(event tracing.BlockEvent) {
+	for _, t := range m.tracers {
+		if t.OnSkippedBlock != nil {
+			t.OnSkippedBlock(event)
+		}
+	}
+}
+
+func (m *muxTracer) OnGenesisBlock(genesis *gethtypes.Block, alloc gethtypes.GenesisAlloc) {
+	for _, t := range m.tracers {
+		if t.OnGenesisBlock != nil {
+			t.OnGenesisBlock(genesis, alloc)
+		}
+	}
+}
+
+func (m *muxTracer) OnSystemCallStartV2(vm *tracing.VMContext) {
+	for _, t := range m.tracers {
+		if t.OnSystemCallStartV2 != nil {
+			t.OnSystemCallStartV2(vm)
+		} else if t.OnSystemCallStart != nil {
+			t.OnSystemCallStart()
+		}
+	}
+}
+
+func (m *muxTracer) OnSystemCallEnd() {
+	for _, t := range m.tracers {
+		if t.OnSystemCallEnd != nil {
+			t.OnSystemCallEnd()
+		}
+	}
+}
+
+func (m *muxTracer) OnBalanceChange(addr gethcommon.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+	for _, t := range m.tracers {
+		if t.OnBalanceChange != nil {
+			t.OnBalanceChange(addr, prev, new, reason)
+		}
+	}
+}
+
+func (m *muxTracer) OnNonceChangeV2(addr gethcommon.Address, prev, new uint64, reason tracing.NonceChangeReason) {
+	for _, t := range m.tracers {
+		if t.OnNonceChangeV2 != nil {
+			t.OnNonceChangeV2(addr, prev, new, reason)
+		} else if t.OnNonceChange != nil {
+			t.OnNonceChange(addr, prev, new)
+		}
+	}
+}
+
+func (m *muxTracer) OnCodeChange(addr gethcommon.Address, prevCodeHash gethcommon.Hash, prevCode []byte, codeHash gethcommon.Hash, code []byte) {
+	for _, t := range m.tracers {
+		if t.OnCodeChange != nil {
+			t.OnCodeChange(addr, prevCodeHash, prevCode, codeHash, code)
+		}
+	}
+}
+
+func (m *muxTracer) OnStorageChange(addr gethcommon.Address, slot, prev, new gethcommon.Hash) {
+	for _, t := range m.tracers {
+		if t.OnStorageChange != nil {
+			t.OnStorageChange(addr, slot, prev, new)
+		}
+	}
+}
+
+func (m *muxTracer) OnLog(log *gethtypes.Log) {
+	for _, t := range m.tracers {
+		if t.OnLog != nil {
+			t.OnLog(log)
+		}
+	}
+}
+
+func (m *muxTracer) OnBlockHashRead(blockNumber uint64, hash gethcommon.Hash) {
+	for _, t := range m.tracers {
+		if t.OnBlockHashRead != nil {
+			t.OnBlockHashRead(blockNumber, hash)
+		}
+	}
+}
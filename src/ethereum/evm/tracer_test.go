@@ -0,0 +1,64 @@
+package evm
+
+import (
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTracer(t *testing.T) {
+	var calls []string
+	tracer1 := &tracing.Hooks{
+		OnTxStart: func(_ *tracing.VMContext, _ *gethtypes.Transaction, _ gethcommon.Address) {
+			calls = append(calls, "tracer1.OnTxStart")
+		},
+		OnExit: func(_ int, _ []byte, _ uint64, _ error, _ bool) { calls = append(calls, "tracer1.OnExit") },
+	}
+	tracer2 := &tracing.Hooks{
+		OnTxStart: func(_ *tracing.VMContext, _ *gethtypes.Transaction, _ gethcommon.Address) {
+			calls = append(calls, "tracer2.OnTxStart")
+		},
+		OnSystemCallStart: func() { calls = append(calls, "tracer2.OnSystemCallStart") },
+	}
+
+	vmCfg := &gethvm.Config{}
+	AddTracer(vmCfg, tracer1)
+	assert.Equal(t, tracer1, vmCfg.Tracer)
+
+	AddTracer(vmCfg, tracer2)
+	hooks := vmCfg.Tracer
+	require.NotNil(t, hooks.OnTxStart)
+	require.NotNil(t, hooks.OnExit)
+	assert.Nil(t, hooks.OnOpcode, "hooks unset on every tracer should not be set on the multiplexer")
+
+	hooks.OnTxStart(nil, gethtypes.NewTx(&gethtypes.LegacyTx{}), gethcommon.Address{})
+	hooks.OnSystemCallStartV2(nil)
+	hooks.OnExit(0, nil, 0, nil, false)
+
+	assert.Equal(t, []string{"tracer1.OnTxStart", "tracer2.OnTxStart", "tracer2.OnSystemCallStart", "tracer1.OnExit"}, calls)
+}
+
+func TestMuxTracerHooksSwap(t *testing.T) {
+	// Some tracers (e.g. geth JSON logger) swap their hooks in place during system calls
+	var opcodes int
+	onOpcode := func(_ uint64, _ byte, _, _ uint64, _ tracing.OpContext, _ []byte, _ int, _ error) { opcodes++ }
+	tracer := &tracing.Hooks{OnOpcode: onOpcode}
+	tracer.OnSystemCallStart = func() {
+		saved := *tracer
+		*tracer = tracing.Hooks{OnSystemCallEnd: func() { *tracer = saved }}
+	}
+
+	hooks := NewMuxTracer(tracer)
+	hooks.OnOpcode(0, 0, 0, 0, nil, nil, 0, nil)
+	hooks.OnSystemCallStartV2(nil)
+	hooks.OnOpcode(0, 0, 0, 0, nil, nil, 0, nil)
+	hooks.OnSystemCallEnd()
+	hooks.OnOpcode(0, 0, 0, 0, nil, nil, 0, nil)
+
+	assert.Equal(t, 2, opcodes)
+}
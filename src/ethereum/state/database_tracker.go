@@ -83,6 +83,51 @@ func newStateAccessTracker() *AccessTracker {
 	}
 }
 
+// NewAccessTracker creates a new empty access tracker.
+func NewAccessTracker() *AccessTracker {
+	return newStateAccessTracker()
+}
+
+// Merge merges the accesses tracked on a subsequent state into the tracker.
+//
+// Accounts and storage slots already tracked are kept untouched, so the tracker keeps
+// the values at the earliest state they have been accessed on. Storage slots of an account
+// that did not exist or had an empty storage on the earliest state are tracked as empty.
+func (t *AccessTracker) Merge(other *AccessTracker) {
+	if other == nil {
+		return
+	}
+
+	for addr, otherAccount := range other.Accounts {
+		account, ok := t.Accounts[addr]
+		if !ok {
+			account = &AccountAccessTracker{
+				Storage: make(map[gethcommon.Hash]gethcommon.Hash),
+			}
+			if otherAccount.Account != nil {
+				account.Account = otherAccount.Account.Copy()
+			}
+			t.Accounts[addr] = account
+
+			for slot, value := range otherAccount.Storage {
+				account.Storage[slot] = value
+			}
+			continue
+		}
+
+		emptyStorage := account.Account == nil || account.Account.Root == gethtypes.EmptyRootHash
+		for slot, value := range otherAccount.Storage {
+			if _, ok := account.Storage[slot]; ok {
+				continue
+			}
+			if emptyStorage {
+				value = gethcommon.Hash{}
+			}
+			account.Storage[slot] = value
+		}
+	}
+}
+
 // stateAccessTrackerReader is a state reader that tracks the state access (account and storage) during the read operation.
 type stateAccessTrackerReader struct {
 	reader gethstate.Reader
@@ -1,3 +1,63 @@
 package state
 
+import (
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+)
+
 // TODO: write test for AccountState and StorageState
+
+func TestAccessTrackerMerge(t *testing.T) {
+	var (
+		addr1 = gethcommon.HexToAddress("0x1")
+		addr2 = gethcommon.HexToAddress("0x2")
+		addr3 = gethcommon.HexToAddress("0x3")
+		slot1 = gethcommon.HexToHash("0x1")
+		slot2 = gethcommon.HexToHash("0x2")
+	)
+
+	tracker := NewAccessTracker()
+	tracker.Accounts[addr1] = &AccountAccessTracker{
+		Account: &gethtypes.StateAccount{Nonce: 1, Balance: uint256.NewInt(1), Root: gethcommon.HexToHash("0xabcd")},
+		Storage: map[gethcommon.Hash]gethcommon.Hash{slot1: gethcommon.HexToHash("0xa")},
+	}
+	tracker.Accounts[addr2] = &AccountAccessTracker{
+		Storage: make(map[gethcommon.Hash]gethcommon.Hash),
+	}
+
+	next := NewAccessTracker()
+	next.Accounts[addr1] = &AccountAccessTracker{
+		Account: &gethtypes.StateAccount{Nonce: 2, Balance: uint256.NewInt(2), Root: gethcommon.HexToHash("0xef01")},
+		Storage: map[gethcommon.Hash]gethcommon.Hash{
+			slot1: gethcommon.HexToHash("0xb"),
+			slot2: gethcommon.HexToHash("0xc"),
+		},
+	}
+	next.Accounts[addr2] = &AccountAccessTracker{
+		Account: &gethtypes.StateAccount{Nonce: 1, Balance: uint256.NewInt(1), Root: gethcommon.HexToHash("0xef01")},
+		Storage: map[gethcommon.Hash]gethcommon.Hash{slot1: gethcommon.HexToHash("0xd")},
+	}
+	next.Accounts[addr3] = &AccountAccessTracker{
+		Account: &gethtypes.StateAccount{Nonce: 3, Balance: uint256.NewInt(3), Root: gethtypes.EmptyRootHash},
+		Storage: map[gethcommon.Hash]gethcommon.Hash{slot1: gethcommon.HexToHash("0xe")},
+	}
+
+	tracker.Merge(next)
+
+	// Account already tracked keeps its earliest values, new slots are added
+	assert.Equal(t, uint64(1), tracker.Accounts[addr1].Account.Nonce)
+	assert.Equal(t, gethcommon.HexToHash("0xa"), tracker.Accounts[addr1].Storage[slot1])
+	assert.Equal(t, gethcommon.HexToHash("0xc"), tracker.Accounts[addr1].Storage[slot2])
+
+	// Account that did not exist keeps being tracked as non-existing with empty storage
+	assert.Nil(t, tracker.Accounts[addr2].Account)
+	assert.Equal(t, gethcommon.Hash{}, tracker.Accounts[addr2].Storage[slot1])
+
+	// Account not tracked yet is added
+	assert.Equal(t, uint64(3), tracker.Accounts[addr3].Account.Nonce)
+	assert.Equal(t, gethcommon.HexToHash("0xe"), tracker.Accounts[addr3].Storage[slot1])
+}
@@ -1,10 +1,12 @@
 package trie
 
 import (
+	"bytes"
 	"fmt"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/ethereum/go-ethereum/trie/trienode"
 )
@@ -129,8 +131,10 @@ func AddNodes(set *trienode.NodeSet, root gethcommon.Hash, proofDB ethdb.KeyValu
 // AddOrphanNodes adds orphan nodes to the node set for keys deleted during a state transition, ensuring
 // the node set contains all necessary nodes for proper key deletion.
 //
-// This is required because deleting a key may reduce the trie, and orphan nodes are needed to handle
-// the reduction when removing a branch node.
+// This is required because deleting a key may reduce the trie: when a branch node is left with a single child
+// after deletion, the branch is replaced by a short node and, if the remaining child is itself a short node,
+// the child is resolved so it can be merged into its parent. In the pre-state trie, this remaining child (the orphan)
+// is a sibling of the deleted key and thus is not part of the pre-state proof of the deleted key.
 //
 // Assumptions:
 // - NodeSet holds nodes of the pre-state trie.
@@ -138,16 +142,13 @@ func AddNodes(set *trienode.NodeSet, root gethcommon.Hash, proofDB ethdb.KeyValu
 // - postProofDB is a database containing trie nodes for the post-state.
 // - keys are keys deleted from the pre-state during the state transition (in case they are actually not deleted, they are ignored).
 //
-// The function uses post-state proofs to identify deleted keys and compute orphan nodes to add to
+// The function uses post-state proofs to identify deleted keys and compute the orphan nodes to add to
 // the pre-state trie, ensuring it contains the required nodes for proper key deletion during the transition.
+//
+// Only the orphan nodes that are short nodes are added. In case the remaining child is a full node, it does not need to
+// be resolved during the reduction, as the trie reduction tolerates a missing node in this case.
 func AddOrphanNodes(set *trienode.NodeSet, postRoot gethcommon.Hash, postProofDB ethdb.KeyValueReader, keys ...[]byte) error {
 	for _, key := range keys {
-		// Verify the post-state proof
-		// v, prefix, node, err := trie.VerifyProofWithLastNode(postRoot, key, postProofDB)
-		// if err != nil {
-		// 	return fmt.Errorf("invalid post-state proofs: %v", err)
-		// }
-
 		proof, err := trie.VerifyProofWithProof(postRoot, key, postProofDB)
 		if err != nil {
 			return fmt.Errorf("failed to verify proof for key %x: %v", key, err)
@@ -158,29 +159,87 @@ func AddOrphanNodes(set *trienode.NodeSet, postRoot gethcommon.Hash, postProofDB
 			continue
 		}
 
-		// The last proof node in the post-state is a short node, this means that the deletion resulted in a trie reduction
-		// so we need to add orphan nodes to the pre-state trie
+		if len(proof.Nodes()) == 0 {
+			// The post-state trie is empty, so there is no trie reduction
+			continue
+		}
 
-		// We add every possible shorter extension of the last node to the pre-state trie
-		// This is overkill, but it's the easiest way to make sure that the necessary orphan is added to the pre-state trie
-		// TODO: Optimize this by only adding the necessary orphan node
-		lastNode := proof.Nodes()[len(proof.Nodes())-1]
-		shortNodes, err := trie.ShortenShortNode(lastNode.Node.Blob)
+		orphan, err := orphanNode(set, key, proof.Nodes()[len(proof.Nodes())-1])
 		if err != nil {
-			// The last proof node in the post-state is not a short node, this means that the deletion did not
-			// result in any trie reduction, so there is no need to add orphan nodes to the pre-state trie
-			continue
+			return fmt.Errorf("failed to compute orphan node for key %x: %v", key, err)
 		}
 
-		for _, sn := range shortNodes {
-			fullPath := lastNode.Path
-			fullPath = append(fullPath, sn.Path...)
-			// If there is no node with the same key in the pre-state trie, we add the possible orphan node
-			if set.Nodes[string(fullPath)] == nil {
-				set.AddNode(fullPath, sn.Node)
-			}
+		// If there is no node with the same path in the pre-state trie, we add the orphan node
+		if orphan != nil && set.Nodes[string(orphan.Path)] == nil {
+			set.AddNode(orphan.Path, orphan.Node)
 		}
 	}
 
 	return nil
 }
+
+// orphanNode computes the pre-state orphan node resulting from the deletion of key given the last node
+// of the post-state exclusion proof of the key.
+//
+// If the deletion resulted in a trie reduction, the last post-state proof node is a short node (at path Q with key K)
+// that is the result of the reduction of a pre-state branch node at path Q+K[:m], where m is the length of
+// the common prefix between K and the deleted key (after Q). The orphan is the remaining child of the branch node
+// at path Q+K[:m+1], which is a short node with key K[m+1:] and the same value as the post-state short node.
+//
+// It returns nil if the deletion did not result in any reduction or if the orphan does not need to be resolved.
+func orphanNode(set *trienode.NodeSet, key []byte, last *trie.ProofNode) (*trie.ProofNode, error) {
+	shortNodes, err := trie.ShortenShortNode(last.Node.Blob)
+	if err != nil {
+		// The last proof node in the post-state is not a short node, this means that the deletion did not
+		// result in any trie reduction, so there is no need to add orphan nodes to the pre-state trie
+		return nil, nil
+	}
+
+	hexKey := keybytesToHex(key)
+	if len(hexKey) < len(last.Path) {
+		return nil, fmt.Errorf("invalid proof node path %x", last.Path)
+	}
+	hexKey = hexKey[len(last.Path):]
+
+	// ShortenShortNode returns candidate nodes at every relative path K[:i] (with 1 <= i < len(K))
+	// The orphan is the candidate whose parent path is the common prefix with the deleted key
+	for _, sn := range shortNodes {
+		parentPath := sn.Path[:len(sn.Path)-1]
+		if len(hexKey) < len(sn.Path) ||
+			!bytes.Equal(parentPath, hexKey[:len(parentPath)]) ||
+			sn.Path[len(parentPath)] == hexKey[len(parentPath)] {
+			continue
+		}
+
+		// The reduced branch node must be part of the pre-state trie
+		parent := set.Nodes[string(concat(last.Path, parentPath))]
+		if parent == nil || !isFullNode(parent.Blob) {
+			return nil, nil
+		}
+
+		return &trie.ProofNode{
+			Path: concat(last.Path, sn.Path),
+			Node: sn.Node,
+		}, nil
+	}
+
+	// Either the remaining child is a full node or it is embedded in its parent node,
+	// in both cases, there is no orphan node to add
+	return nil, nil
+}
+
+// isFullNode returns whether the RLP encoded node is a full node (a list of 17 items)
+func isFullNode(blob []byte) bool {
+	content, _, err := rlp.SplitList(blob)
+	if err != nil {
+		return false
+	}
+	n, err := rlp.CountValues(content)
+	return err == nil && n == 17
+}
+
+func concat(a, b []byte) []byte {
+	c := make([]byte, 0, len(a)+len(b))
+	c = append(c, a...)
+	return append(c, b...)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"testing"
@@ -12,7 +13,10 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	gethstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/ethereum/go-ethereum/triedb/hashdb"
 	"github.com/stretchr/testify/assert"
@@ -198,3 +202,130 @@ func testDeleteAccount(t *testing.T, trieDB *triedb.Database, stateTrie gethstat
 		assert.Len(t, v, 0, "Unexpected storage value for key %v (should have been deleted)", preStorageProof.Key)
 	}
 }
+
+// TestAddOrphanNodes is a property-based test that generates random tries, applies random updates
+// and deletions and verifies that the pre-state node set computed from proofs (including orphan nodes)
+// allows to replay the state transition and to compute the same post-state root as the full trie.
+// It also verifies that only necessary orphan nodes are added to the node set.
+func TestAddOrphanNodes(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		t.Run(fmt.Sprintf("case_%d", i), func(t *testing.T) {
+			testAddOrphanNodes(t, rng)
+		})
+	}
+}
+
+// randomTrieKey generates keys with a small nibble alphabet on the first bytes,
+// so the generated tries contain many extension nodes and deep branches
+func randomTrieKey(rng *rand.Rand) []byte {
+	key := make([]byte, 32)
+	rng.Read(key)
+	prefixes := []byte{0x00, 0x01, 0x10, 0x11}
+	for i := 0; i < 3; i++ {
+		key[i] = prefixes[rng.Intn(len(prefixes))]
+	}
+	return key
+}
+
+// randomTrieValue generates values of random length, so the generated tries contain both embedded and hashed nodes
+func randomTrieValue(rng *rand.Rand) []byte {
+	value := make([]byte, 1+rng.Intn(40))
+	rng.Read(value)
+	return value
+}
+
+func commitTestTrie(t *testing.T, trieDB *triedb.Database, tr *trie.Trie, parent gethcommon.Hash) gethcommon.Hash {
+	root, nodes := tr.Commit(false)
+	if nodes != nil {
+		err := trieDB.Update(root, parent, 0, trienode.NewWithNodeSet(nodes), triedb.NewStateSet())
+		require.NoError(t, err)
+	}
+	return root
+}
+
+func testAddOrphanNodes(t *testing.T, rng *rand.Rand) {
+	trieDB := newTestTrieDB()
+
+	// Create random pre-state trie
+	preTrie := trie.NewEmpty(trieDB)
+	keys := make([][]byte, 2+rng.Intn(60))
+	for i := range keys {
+		keys[i] = randomTrieKey(rng)
+		require.NoError(t, preTrie.Update(keys[i], randomTrieValue(rng)))
+	}
+	preRoot := commitTestTrie(t, trieDB, preTrie, types.EmptyRootHash)
+
+	// Select random keys to delete and to update
+	var deleted, updated [][]byte
+	updates := make(map[string][]byte)
+	for _, key := range keys {
+		switch rng.Intn(3) {
+		case 0:
+			deleted = append(deleted, key)
+		case 1:
+			updated = append(updated, key)
+			updates[string(key)] = randomTrieValue(rng)
+		}
+	}
+
+	// Collect pre-state proofs for every accessed key
+	preTrie, err := trie.New(trie.StateTrieID(preRoot), trieDB)
+	require.NoError(t, err)
+	preProofDB := memorydb.New()
+	for _, key := range append(append([][]byte{}, deleted...), updated...) {
+		require.NoError(t, preTrie.Prove(key, preProofDB))
+	}
+
+	// Apply the state transition on the full trie
+	postTrie, err := trie.New(trie.StateTrieID(preRoot), trieDB)
+	require.NoError(t, err)
+	applyTestTransition(t, postTrie, deleted, updated, updates)
+	postRoot := commitTestTrie(t, trieDB, postTrie, preRoot)
+
+	// Collect post-state proofs for deleted keys
+	postTrie, err = trie.New(trie.StateTrieID(postRoot), trieDB)
+	require.NoError(t, err)
+	postProofDB := memorydb.New()
+	for _, key := range deleted {
+		require.NoError(t, postTrie.Prove(key, postProofDB))
+	}
+
+	// Compute node set
+	set := trienode.NewNodeSet(gethcommon.Hash{})
+	require.NoError(t, AddNodes(set, preRoot, preProofDB, append(append([][]byte{}, deleted...), updated...)...))
+	proofNodes := len(set.Nodes)
+	require.NoError(t, AddOrphanNodes(set, postRoot, postProofDB, deleted...))
+
+	// Orphan nodes are nodes of the pre-state trie, and there is at most one orphan node per deleted key
+	assert.LessOrEqual(t, len(set.Nodes)-proofNodes, len(deleted))
+	preNodes := make(map[string][]byte)
+	it, err := preTrie.NodeIterator(nil)
+	require.NoError(t, err)
+	for it.Next(true) {
+		if it.Hash() != (gethcommon.Hash{}) {
+			preNodes[string(it.Path())] = it.NodeBlob()
+		}
+	}
+	require.NoError(t, it.Error())
+	for path, n := range set.Nodes {
+		assert.Equal(t, preNodes[path], n.Blob, "node at path %x is not a pre-state node", path)
+	}
+
+	// Replay the state transition on the partial trie
+	partialDB := newTestTrieDB()
+	require.NoError(t, partialDB.Update(preRoot, types.EmptyRootHash, 0, trienode.NewWithNodeSet(set), triedb.NewStateSet()))
+	partialTrie, err := trie.New(trie.StateTrieID(preRoot), partialDB)
+	require.NoError(t, err)
+	applyTestTransition(t, partialTrie, deleted, updated, updates)
+	assert.Equal(t, postRoot, partialTrie.Hash(), "post-state root mismatch")
+}
+
+func applyTestTransition(t *testing.T, tr *trie.Trie, deleted, updated [][]byte, updates map[string][]byte) {
+	for _, key := range updated {
+		require.NoError(t, tr.Update(key, updates[string(key)]))
+	}
+	for _, key := range deleted {
+		require.NoError(t, tr.Delete(key))
+	}
+}
@@ -0,0 +1,194 @@
+package trie
+
+import (
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ReachableNodes walks the account trie rooted at stateRoot and the storage tries of the accounts it contains,
+// and returns the hashes of the nodes that are reached.
+//
+// nodes is the set of known nodes indexed by hash. As tries can be partial, nodes that are referenced but unknown are not walked.
+func ReachableNodes(stateRoot gethcommon.Hash, nodes map[gethcommon.Hash][]byte) (map[gethcommon.Hash]struct{}, error) {
+	locations, err := NodeLocations(stateRoot, nodes)
+	if err != nil {
+		return nil, err
+	}
+	reachable := make(map[gethcommon.Hash]struct{}, len(locations))
+	for hash := range locations {
+		reachable[hash] = struct{}{}
+	}
+	return reachable, nil
+}
+
+// NodeLocation is the location of a node in the state
+type NodeLocation struct {
+	Owner gethcommon.Hash // Zero hash for the account trie, key of the account in the account trie (i.e. keccak(address)) for a storage trie
+	Path  []byte          // Path of the node in its trie, in nibbles
+}
+
+// IsAccountTrie returns whether the node belongs to the account trie
+func (l *NodeLocation) IsAccountTrie() bool {
+	return l.Owner == (gethcommon.Hash{})
+}
+
+// NodeLocations walks the tries as ReachableNodes, and returns the location of every reached node, indexed by node hash.
+//
+// A node present at several locations (e.g. in identical storage tries) is located where it is first walked.
+func NodeLocations(stateRoot gethcommon.Hash, nodes map[gethcommon.Hash][]byte) (map[gethcommon.Hash]*NodeLocation, error) {
+	w := &reachableWalker{
+		nodes:     nodes,
+		locations: make(map[gethcommon.Hash]*NodeLocation),
+	}
+	if err := w.walk(stateRoot, gethcommon.Hash{}, nil); err != nil {
+		return nil, err
+	}
+	return w.locations, nil
+}
+
+type reachableWalker struct {
+	nodes     map[gethcommon.Hash][]byte
+	locations map[gethcommon.Hash]*NodeLocation
+}
+
+// walk walks the node with the given hash, owned by owner and located at path (in nibbles) in its trie
+func (w *reachableWalker) walk(hash, owner gethcommon.Hash, path []byte) error {
+	if _, ok := w.locations[hash]; ok {
+		return nil
+	}
+	blob, ok := w.nodes[hash]
+	if !ok {
+		return nil
+	}
+	w.locations[hash] = &NodeLocation{Owner: owner, Path: path}
+
+	if err := w.walkNode(blob, owner, path); err != nil {
+		return fmt.Errorf("node %v: %v", hash, err)
+	}
+	return nil
+}
+
+func (w *reachableWalker) walkNode(blob []byte, owner gethcommon.Hash, path []byte) error {
+	elems, _, err := rlp.SplitList(blob)
+	if err != nil {
+		return err
+	}
+	count, err := rlp.CountValues(elems)
+	if err != nil {
+		return err
+	}
+
+	switch count {
+	case 17: // full node
+		for i := 0; i < 16; i++ {
+			var child []byte
+			child, elems, err = splitRaw(elems)
+			if err != nil {
+				return err
+			}
+			if err := w.walkChild(child, owner, appendPath(path, byte(i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	case 2: // short node
+		key, rest, err := rlp.SplitString(elems)
+		if err != nil {
+			return err
+		}
+		child, _, err := splitRaw(rest)
+		if err != nil {
+			return err
+		}
+		path = appendPath(path, compactToNibbles(key)...)
+		if !hasTerm(key) {
+			return w.walkChild(child, owner, path)
+		}
+		if owner == (gethcommon.Hash{}) {
+			return w.walkAccount(child, path)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid number of list elements: %d", count)
+	}
+}
+
+// walkChild walks a child reference, which is either the hash of a node or an embedded node
+func (w *reachableWalker) walkChild(child []byte, owner gethcommon.Hash, path []byte) error {
+	kind, content, _, err := rlp.Split(child)
+	if err != nil {
+		return err
+	}
+	switch {
+	case kind == rlp.List:
+		return w.walkNode(child, owner, path)
+	case len(content) == gethcommon.HashLength:
+		return w.walk(gethcommon.BytesToHash(content), owner, path)
+	default:
+		return nil
+	}
+}
+
+// walkAccount walks the storage trie of an account leaf located at path
+func (w *reachableWalker) walkAccount(value, path []byte) error {
+	content, _, err := rlp.SplitString(value)
+	if err != nil {
+		return err
+	}
+	var acc gethtypes.StateAccount
+	if err := rlp.DecodeBytes(content, &acc); err != nil {
+		return fmt.Errorf("invalid account: %v", err)
+	}
+	if acc.Root == gethtypes.EmptyRootHash {
+		return nil
+	}
+	if len(path) != 2*gethcommon.HashLength {
+		return fmt.Errorf("invalid account key length: %d nibbles", len(path))
+	}
+	return w.walk(acc.Root, gethcommon.BytesToHash(nibblesToBytes(path)), nil)
+}
+
+// splitRaw splits the first RLP value (including its prefix) from the rest of b
+func splitRaw(b []byte) (raw, rest []byte, err error) {
+	_, _, rest, err = rlp.Split(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b[:len(b)-len(rest)], rest, nil
+}
+
+// hasTerm returns whether a compact encoded key is the key of a leaf node
+func hasTerm(compact []byte) bool {
+	return len(compact) > 0 && compact[0]>>4 >= 2
+}
+
+// compactToNibbles returns the nibbles of a compact encoded key (without terminator)
+func compactToNibbles(compact []byte) []byte {
+	if len(compact) == 0 {
+		return nil
+	}
+	nibbles := make([]byte, 0, 2*len(compact))
+	if compact[0]&0x10 != 0 { // odd length, the first nibble is in the flag byte
+		nibbles = append(nibbles, compact[0]&0x0f)
+	}
+	for _, b := range compact[1:] {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles
+}
+
+func nibblesToBytes(nibbles []byte) []byte {
+	b := make([]byte, len(nibbles)/2)
+	for i := range b {
+		b[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+	return b
+}
+
+// appendPath returns a new path made of path followed by nibbles (path is not modified)
+func appendPath(path []byte, nibbles ...byte) []byte {
+	return append(path[:len(path):len(path)], nibbles...)
+}
@@ -0,0 +1,91 @@
+package trie
+
+import (
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/hashdb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStateNodes creates a state of 50 accounts (every 10th having 20 storage slots), and returns its root and every node
+func newTestStateNodes(t *testing.T) (gethcommon.Hash, map[gethcommon.Hash][]byte) {
+	db := rawdb.NewMemoryDatabase()
+	tdb := triedb.NewDatabase(db, &triedb.Config{HashDB: &hashdb.Config{}})
+	statedb, err := gethstate.New(gethcommon.Hash{}, gethstate.NewDatabase(tdb, nil))
+	require.NoError(t, err)
+
+	for i := 1; i <= 50; i++ {
+		addr := gethcommon.BigToAddress(uint256.NewInt(uint64(i)).ToBig())
+		statedb.SetBalance(addr, uint256.NewInt(uint64(i)), tracing.BalanceChangeUnspecified)
+		if i%10 == 0 {
+			for j := 1; j <= 20; j++ {
+				statedb.SetState(addr, gethcommon.BigToHash(uint256.NewInt(uint64(j)).ToBig()), gethcommon.BigToHash(uint256.NewInt(uint64(i)).ToBig()))
+			}
+		}
+	}
+	root, err := statedb.Commit(0, false, false)
+	require.NoError(t, err)
+	require.NoError(t, tdb.Commit(root, false))
+
+	// Collect every node of the account and storage tries
+	nodes := make(map[gethcommon.Hash][]byte)
+	it := db.NewIterator(nil, nil)
+	for it.Next() {
+		if len(it.Key()) == gethcommon.HashLength {
+			nodes[gethcommon.BytesToHash(it.Key())] = gethcommon.CopyBytes(it.Value())
+		}
+	}
+	it.Release()
+	require.Greater(t, len(nodes), 50)
+
+	return root, nodes
+}
+
+func TestReachableNodes(t *testing.T) {
+	root, nodes := newTestStateNodes(t)
+
+	// Add a node that is not part of the tries
+	orphan := []byte{0xc2, 0x80, 0x80}
+	nodes[crypto.Keccak256Hash(orphan)] = orphan
+
+	reachable, err := ReachableNodes(root, nodes)
+	require.NoError(t, err)
+	assert.Len(t, reachable, len(nodes)-1)
+	assert.NotContains(t, reachable, crypto.Keccak256Hash(orphan))
+
+	// Nodes below a missing node are not reachable
+	delete(nodes, root)
+	reachable, err = ReachableNodes(root, nodes)
+	require.NoError(t, err)
+	assert.Empty(t, reachable)
+}
+
+func TestNodeLocations(t *testing.T) {
+	root, nodes := newTestStateNodes(t)
+
+	locations, err := NodeLocations(root, nodes)
+	require.NoError(t, err)
+	assert.Len(t, locations, len(nodes))
+	assert.Equal(t, &NodeLocation{}, locations[root])
+
+	storageTries := make(map[gethcommon.Hash]int)
+	for _, location := range locations {
+		if !location.IsAccountTrie() {
+			storageTries[location.Owner]++
+		}
+		assert.Less(t, len(location.Path), 2*gethcommon.HashLength)
+	}
+	assert.Len(t, storageTries, 5)
+	for i := 10; i <= 50; i += 10 {
+		addr := gethcommon.BigToAddress(uint256.NewInt(uint64(i)).ToBig())
+		assert.Contains(t, storageTries, crypto.Keccak256Hash(addr.Bytes()), "storage trie of %v", addr)
+	}
+}
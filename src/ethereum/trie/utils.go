@@ -24,3 +24,14 @@ func StorageTrieOwner(addr gethcommon.Address) gethcommon.Hash {
 func AccountTrieOwner() gethcommon.Hash {
 	return gethcommon.Hash{}
 }
+
+// keybytesToHex converts a trie key to its nibbles representation (without terminator)
+// which is the representation of node paths in the trie.
+func keybytesToHex(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2)
+	for i, b := range key {
+		nibbles[i*2] = b / 16
+		nibbles[i*2+1] = b % 16
+	}
+	return nibbles
+}
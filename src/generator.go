@@ -20,7 +20,7 @@ func (a *App) PreflightEVM() evm.Executor {
 		fmt.Sprintf("%s.preflight.evm", zkpigComponentName),
 		func() (evm.Executor, error) {
 			vm := evm.NewExecutor()
-			vm = evm.WithLog()(vm)
+			vm = evm.WithLog(a.loggerTracerConfig(func(cfg *TracingConfig) *TracerConfig { return cfg.Preflight }))(vm)
 			vm = evm.WithTags(vm)
 			return vm, nil
 		},
@@ -53,24 +53,47 @@ func (a *App) PreparerEVM() evm.Executor {
 		fmt.Sprintf("%s.preparer.evm", zkpigComponentName),
 		func() (evm.Executor, error) {
 			vm := evm.NewExecutor()
-			vm = evm.WithLog()(vm)
+			vm = a.withCostEstimate(vm)
+			vm = evm.WithLog(a.loggerTracerConfig(func(cfg *TracingConfig) *TracerConfig { return cfg.Prepare }))(vm)
 			vm = evm.WithTags(vm)
 			return vm, nil
 		},
 	)
 }
 
+// CostEstimator estimates the proving cost of the prepared blocks
+func (a *App) CostEstimator() *evm.CostEstimator {
+	return provide(
+		a,
+		fmt.Sprintf("%s.cost-estimator", zkpigComponentName),
+		func() (*evm.CostEstimator, error) {
+			return evm.NewCostEstimator(evm.DefaultCostModel()), nil
+		},
+	)
+}
+
+// withCostEstimate decorates the EVM executor with a proving cost estimate (if proving cost is included in the prover input)
+func (a *App) withCostEstimate(vm evm.Executor) evm.Executor {
+	if !a.includeExtensions().Include(steps.IncludeProvingCost) {
+		return vm
+	}
+	return evm.WithCostEstimate(a.CostEstimator())(vm)
+}
+
+func (a *App) includeExtensions() steps.Include {
+	gCfg := a.Config()
+	if gCfg.Generator != nil && gCfg.Generator.IncludeExtensions != nil {
+		return *gCfg.Generator.IncludeExtensions
+	}
+	return steps.IncludeNone
+}
+
 func (a *App) PreparerBase() steps.Preparer {
 	return provide(
 		a,
 		fmt.Sprintf("%s.preparer.base", zkpigComponentName),
 		func() (steps.Preparer, error) {
-			gCfg := a.Config()
-			var include steps.Include
-			if gCfg.Generator != nil && gCfg.Generator.IncludeExtensions != nil {
-				include = *gCfg.Generator.IncludeExtensions
-			}
-			return steps.NewPreparerFromEvm(a.PreparerEVM(), steps.WithDataInclude(include))
+			return steps.NewPreparerFromEvm(a.PreparerEVM(), steps.WithDataInclude(a.includeExtensions()))
 		},
 	)
 }
@@ -85,19 +108,84 @@ func (a *App) Preparer() steps.Preparer {
 	)
 }
 
+func (a *App) MinimizerBase() steps.Minimizer {
+	return provide(
+		a,
+		fmt.Sprintf("%s.minimizer.base", zkpigComponentName),
+		func() (steps.Minimizer, error) {
+			return steps.NewMinimizerFromEvm(a.MinimizerEVM()), nil
+		},
+	)
+}
+
+// MinimizerEVM is the EVM executor replaying blocks against the witness to minimize
+// (traces are only exported and receipts only checked by the execute step)
+func (a *App) MinimizerEVM() evm.Executor {
+	return provide(
+		a,
+		fmt.Sprintf("%s.minimizer.evm", zkpigComponentName),
+		func() (evm.Executor, error) {
+			vm := evm.NewExecutor()
+			vm = evm.WithLog(a.loggerTracerConfig(func(cfg *TracingConfig) *TracerConfig { return cfg.Execute }))(vm)
+			vm = evm.WithTags(vm)
+			return vm, nil
+		},
+	)
+}
+
+func (a *App) Minimizer() steps.Minimizer {
+	return provide(
+		a,
+		fmt.Sprintf("%s.minimizer", zkpigComponentName),
+		func() (steps.Minimizer, error) {
+			return steps.MinimizerWithTags(a.MinimizerBase()), nil
+		},
+	)
+}
+
 func (a *App) ExecutorEVM() evm.Executor {
 	return provide(
 		a,
 		fmt.Sprintf("%s.executor.evm", zkpigComponentName),
 		func() (evm.Executor, error) {
 			vm := evm.NewExecutor()
-			vm = evm.WithLog()(vm)
+			vm = a.withTrace(vm)
+			vm = a.withReceiptsCheck(vm)
+			vm = evm.WithLog(a.loggerTracerConfig(func(cfg *TracingConfig) *TracerConfig { return cfg.Execute }))(vm)
 			vm = evm.WithTags(vm)
 			return vm, nil
 		},
 	)
 }
 
+// loggerTracerConfig returns the logger tracer configuration of a step
+func (a *App) loggerTracerConfig(step func(*TracingConfig) *TracerConfig) *evm.LoggerTracerConfig {
+	gCfg := a.Config()
+	if gCfg.Generator == nil || gCfg.Generator.Tracing == nil {
+		return evm.DefaultLoggerTracerConfig()
+	}
+	return step(gCfg.Generator.Tracing).LoggerTracerConfig()
+}
+
+// withTrace decorates the EVM executor with a per-transaction struct log trace export (if enabled)
+// It must only decorate the execute step EVM, so each trace is written once per block.
+func (a *App) withTrace(vm evm.Executor) evm.Executor {
+	gCfg := a.Config()
+	if gCfg.Generator == nil || !common.Val(gCfg.Generator.TraceTransactions) {
+		return vm
+	}
+	return evm.WithStructLogTrace(a.TraceStore())(vm)
+}
+
+// withReceiptsCheck decorates the EVM executor with a cross-check of the receipts against the chain RPC node (if enabled)
+func (a *App) withReceiptsCheck(vm evm.Executor) evm.Executor {
+	gCfg := a.Config()
+	if gCfg.Generator == nil || !common.Val(gCfg.Generator.CheckReceipts) || a.Chain() == nil {
+		return vm
+	}
+	return evm.WithReceiptsCheck(evm.NewRPCReceiptsFetcher(a.chainRPC()))(vm)
+}
+
 func (a *App) ExecutorBase() steps.Executor {
 	return provide(
 		a,
@@ -118,17 +206,39 @@ func (a *App) Executor() steps.Executor {
 	)
 }
 
+func (a *App) Validator() steps.Validator {
+	return provide(
+		a,
+		fmt.Sprintf("%s.validator", zkpigComponentName),
+		func() (steps.Validator, error) {
+			// The validator runs off-line, so it does not export traces nor check receipts against the node
+			vm := evm.NewExecutor()
+			vm = evm.WithLog(a.loggerTracerConfig(func(cfg *TracingConfig) *TracerConfig { return cfg.Execute }))(vm)
+			return steps.NewValidatorFromEvm(vm), nil
+		},
+	)
+}
+
 func (a *App) Generator() *generator.Generator {
 	return provide(
 		a,
 		fmt.Sprintf("%s.base", zkpigComponentName),
 		func() (*generator.Generator, error) {
+			// Register custom chain (if any) before constructing the steps
+			a.ChainGenesis()
+
+			var minimizer steps.Minimizer
+			if gCfg := a.Config().Generator; gCfg != nil && common.Val(gCfg.MinimizeWitness) {
+				minimizer = a.Minimizer()
+			}
+
 			return generator.NewGenerator(
 				&generator.Config{
 					ChainID:            a.ChainID(),
 					RPC:                a.Chain(),
 					Preflighter:        a.Preflight(),
 					Preparer:           a.Preparer(),
+					Minimizer:          minimizer,
 					Executor:           a.Executor(),
 					PreflightDataStore: a.PreflightDataStore(),
 					ProverInputStore:   a.ProverInputStore(),
@@ -23,6 +23,7 @@ const (
 	StorePreflightDataStep
 	LoadPreflightDataStep
 	PrepareStep
+	MinimizeStep
 	StoreProverInputStep
 	LoadProverInputStep
 	ExecuteStep
@@ -35,6 +36,7 @@ var stepNames = []string{
 	"storePreflightData",
 	"loadPreflightData",
 	"prepare",
+	"minimize",
 	"storeProverInput",
 	"loadProverInput",
 	"execute",
@@ -52,6 +54,7 @@ type Config struct {
 
 	Preflighter steps.Preflight
 	Preparer    steps.Preparer
+	Minimizer   steps.Minimizer // Optional, if set the witness of the prepared prover input is minimized
 	Executor    steps.Executor
 
 	PreflightDataStore inputstore.PreflightDataStore
@@ -67,6 +70,7 @@ type Generator struct {
 
 	Preflighter steps.Preflight
 	Preparer    steps.Preparer
+	Minimizer   steps.Minimizer
 	Executor    steps.Executor
 
 	PreflightDataStore inputstore.PreflightDataStore
@@ -89,6 +93,7 @@ func NewGenerator(cfg *Config) (*Generator, error) {
 		RPC:                       cfg.RPC,
 		Preflighter:               cfg.Preflighter,
 		Preparer:                  cfg.Preparer,
+		Minimizer:                 cfg.Minimizer,
 		Executor:                  cfg.Executor,
 		PreflightDataStore:        cfg.PreflightDataStore,
 		ProverInputStore:          cfg.ProverInputStore,
@@ -174,10 +179,19 @@ func (s *Generator) Collect(ch chan<- prometheus.Metric) {
 }
 
 func (s *Generator) Generate(ctx context.Context, blockNumber *big.Int) (*input.ProverInput, error) {
+	return s.GenerateRange(ctx, blockNumber, 1)
+}
+
+// GenerateRange generates a single prover input covering count consecutive blocks starting at the given block number.
+func (s *Generator) GenerateRange(ctx context.Context, blockNumber *big.Int, count uint64) (*input.ProverInput, error) {
 	if s.RPC == nil {
 		return nil, ErrChainRPCNotConfigured
 	}
 
+	if count == 0 {
+		return nil, fmt.Errorf("invalid block count: must be at least 1")
+	}
+
 	ctx = s.Context(ctx)
 	ctx = tag.WithTags(
 		ctx,
@@ -190,38 +204,58 @@ func (s *Generator) Generate(ctx context.Context, blockNumber *big.Int) (*input.
 		return nil, fmt.Errorf("failed to fetch block: %v", err)
 	}
 
+	blocks := []*gethtypes.Block{block}
+	for i := uint64(1); i < count; i++ {
+		number := new(big.Int).Add(block.Number(), new(big.Int).SetUint64(i))
+		next, err := s.RPC.BlockByNumber(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block %v: %v", number, err)
+		}
+		blocks = append(blocks, next)
+	}
+
 	ctx = tag.WithTags(
 		ctx,
 		tag.Key("block.number").Int64(block.Number().Int64()),
 		tag.Key("block.hash").String(block.Hash().Hex()),
 	)
+	if count > 1 {
+		ctx = tag.WithTags(ctx, tag.Key("block.count").Int64(int64(count)))
+	}
 
-	return s.generate(ctx, block)
+	return s.generate(ctx, blocks...)
 }
 
-func (s *Generator) generate(ctx context.Context, block *gethtypes.Block) (*input.ProverInput, error) {
-	s.blocks.WithLabelValues(block.Number().String()).Inc()
-	defer s.blocks.DeleteLabelValues(block.Number().String())
+func (s *Generator) generate(ctx context.Context, blocks ...*gethtypes.Block) (*input.ProverInput, error) {
+	for _, block := range blocks {
+		s.blocks.WithLabelValues(block.Number().String()).Inc()
+		defer s.blocks.DeleteLabelValues(block.Number().String())
+	}
 
 	start := time.Now()
 
-	data, err := s.preflight(ctx, block)
-	if err != nil {
-		s.generationTime.WithLabelValues(PreflightStep.String()).Observe(time.Since(start).Seconds())
-		return nil, err
-	}
-
-	if s.storePreflightDataEnabled {
-		err = s.storePreflightData(ctx, data)
+	data := make([]*steps.PreflightData, 0, len(blocks))
+	for _, block := range blocks {
+		d, err := s.preflight(ctx, block)
 		if err != nil {
-			s.generationTime.
-				WithLabelValues(StorePreflightDataStep.String()).
-				Observe(time.Since(start).Seconds())
+			s.generationTime.WithLabelValues(PreflightStep.String()).Observe(time.Since(start).Seconds())
 			return nil, err
 		}
+
+		if s.storePreflightDataEnabled {
+			err = s.storePreflightData(ctx, d)
+			if err != nil {
+				s.generationTime.
+					WithLabelValues(StorePreflightDataStep.String()).
+					Observe(time.Since(start).Seconds())
+				return nil, err
+			}
+		}
+
+		data = append(data, d)
 	}
 
-	in, err := s.prepare(ctx, data)
+	in, err := s.prepare(ctx, data...)
 	if err != nil {
 		s.generationTime.
 			WithLabelValues(PrepareStep.String()).
@@ -229,6 +263,16 @@ func (s *Generator) generate(ctx context.Context, block *gethtypes.Block) (*inpu
 		return nil, err
 	}
 
+	if s.Minimizer != nil {
+		in, err = s.minimize(ctx, in)
+		if err != nil {
+			s.generationTime.
+				WithLabelValues(MinimizeStep.String()).
+				Observe(time.Since(start).Seconds())
+			return nil, err
+		}
+	}
+
 	err = s.execute(ctx, in)
 	if err != nil {
 		s.generationTime.
@@ -308,6 +352,19 @@ func (s *Generator) Prepare(ctx context.Context, blockNumber *big.Int) (*input.P
 		return nil, err
 	}
 
+	if s.Minimizer != nil {
+		in, err = s.minimize(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+
+		// The minimizer does not validate the minimized prover input, so it is executed before being stored
+		err = s.execute(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = s.storeProverInput(ctx, in)
 	if err != nil {
 		return nil, err
@@ -367,12 +424,12 @@ func (s *Generator) runPreflight(ctx context.Context, block *gethtypes.Block) (*
 	return data, nil
 }
 
-func (s *Generator) prepare(ctx context.Context, data *steps.PreflightData) (*input.ProverInput, error) {
+func (s *Generator) prepare(ctx context.Context, data ...*steps.PreflightData) (*input.ProverInput, error) {
 	s.countOfBlocksPerStep.WithLabelValues(PrepareStep.String()).Inc()
 	defer s.countOfBlocksPerStep.WithLabelValues(PrepareStep.String()).Dec()
 
 	start := time.Now()
-	in, err := s.runPrepare(ctx, data)
+	in, err := s.runPrepare(ctx, data...)
 	s.generationTimePerStep.WithLabelValues(PrepareStep.String()).Observe(time.Since(start).Seconds())
 
 	if err != nil {
@@ -383,14 +440,38 @@ func (s *Generator) prepare(ctx context.Context, data *steps.PreflightData) (*in
 	return in, err
 }
 
-func (s *Generator) runPrepare(ctx context.Context, data *steps.PreflightData) (*input.ProverInput, error) {
-	in, err := s.Preparer.Prepare(ctx, data)
+func (s *Generator) runPrepare(ctx context.Context, data ...*steps.PreflightData) (*input.ProverInput, error) {
+	in, err := s.Preparer.Prepare(ctx, data...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare prover inputs: %v", err)
 	}
 	return in, nil
 }
 
+func (s *Generator) minimize(ctx context.Context, in *input.ProverInput) (*input.ProverInput, error) {
+	s.countOfBlocksPerStep.WithLabelValues(MinimizeStep.String()).Inc()
+	defer s.countOfBlocksPerStep.WithLabelValues(MinimizeStep.String()).Dec()
+
+	start := time.Now()
+	out, err := s.runMinimize(ctx, in)
+	s.generationTimePerStep.WithLabelValues(MinimizeStep.String()).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		s.generateErrorCount.WithLabelValues(MinimizeStep.String()).Inc()
+		s.countOfBlocksPerStep.WithLabelValues(ErrorStep.String()).Inc()
+	}
+
+	return out, err
+}
+
+func (s *Generator) runMinimize(ctx context.Context, in *input.ProverInput) (*input.ProverInput, error) {
+	out, err := s.Minimizer.Minimize(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to minimize prover input witness: %v", err)
+	}
+	return out, nil
+}
+
 func (s *Generator) execute(ctx context.Context, in *input.ProverInput) error {
 	s.countOfBlocksPerStep.WithLabelValues(ExecuteStep.String()).Inc()
 	defer s.countOfBlocksPerStep.WithLabelValues(ExecuteStep.String()).Dec()
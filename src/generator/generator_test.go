@@ -109,6 +109,99 @@ func TestGenerator(t *testing.T) {
 	})
 }
 
+func TestGeneratorWithMinimizer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ethrpc := mockethrpc.NewMockClient(ctrl)
+
+	preflighter := mocksteps.NewMockPreflight(ctrl)
+	preparer := mocksteps.NewMockPreparer(ctrl)
+	minimizer := mocksteps.NewMockMinimizer(ctrl)
+	executor := mocksteps.NewMockExecutor(ctrl)
+
+	proverInputStore := mockstore.NewMockProverInputStore(ctrl)
+
+	generator, err := NewGenerator(&Config{
+		RPC:              ethrpc,
+		Preflighter:      preflighter,
+		Preparer:         preparer,
+		Minimizer:        minimizer,
+		Executor:         executor,
+		ProverInputStore: proverInputStore,
+	})
+	require.NoError(t, err)
+
+	ethrpc.EXPECT().ChainID(gomock.Any()).Return(big.NewInt(1), nil)
+	generator.SetMetrics("test", "generator")
+	err = generator.Start(context.TODO())
+	require.NoError(t, err)
+
+	testBlock := gethtypes.NewBlockWithHeader(&gethtypes.Header{Number: big.NewInt(1)})
+	testData := new(steps.PreflightData)
+	testInput := &input.ProverInput{
+		Blocks: []*input.Block{{Header: testBlock.Header()}},
+	}
+	minimizedInput := &input.ProverInput{
+		Blocks: []*input.Block{{Header: testBlock.Header()}},
+	}
+
+	rpcCall := ethrpc.EXPECT().BlockByNumber(gomock.Any(), big.NewInt(1)).Return(testBlock, nil)
+	preflightCall := preflighter.EXPECT().Preflight(gomock.Any(), testBlock).Return(testData, nil).After(rpcCall)
+	prepareCall := preparer.EXPECT().Prepare(gomock.Any(), testData).Return(testInput, nil).After(preflightCall)
+	minimizeCall := minimizer.EXPECT().Minimize(gomock.Any(), testInput).Return(minimizedInput, nil).After(prepareCall)
+	executeCall := executor.EXPECT().Execute(gomock.Any(), minimizedInput).Return(nil, nil).After(minimizeCall)
+	proverInputStore.EXPECT().StoreProverInput(gomock.Any(), minimizedInput).After(executeCall)
+
+	in, err := generator.Generate(context.TODO(), big.NewInt(1))
+	require.NoError(t, err)
+	assert.Same(t, minimizedInput, in)
+}
+
+func TestGeneratorPrepareWithMinimizer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	preparer := mocksteps.NewMockPreparer(ctrl)
+	minimizer := mocksteps.NewMockMinimizer(ctrl)
+	executor := mocksteps.NewMockExecutor(ctrl)
+
+	proverInputStore := mockstore.NewMockProverInputStore(ctrl)
+	preflightDataStore := mockstore.NewMockPreflightDataStore(ctrl)
+
+	generator, err := NewGenerator(&Config{
+		ChainID:            big.NewInt(1),
+		Preparer:           preparer,
+		Minimizer:          minimizer,
+		Executor:           executor,
+		ProverInputStore:   proverInputStore,
+		PreflightDataStore: preflightDataStore,
+	})
+	require.NoError(t, err)
+
+	generator.SetMetrics("test", "generator")
+
+	testBlock := gethtypes.NewBlockWithHeader(&gethtypes.Header{Number: big.NewInt(1)})
+	testData := new(steps.PreflightData)
+	testInput := &input.ProverInput{
+		Blocks: []*input.Block{{Header: testBlock.Header()}},
+	}
+	minimizedInput := &input.ProverInput{
+		Blocks: []*input.Block{{Header: testBlock.Header()}},
+	}
+
+	// The minimized prover input is validated before being stored
+	loadDataCall := preflightDataStore.EXPECT().LoadPreflightData(gomock.Any(), uint64(1), uint64(1)).Return(testData, nil)
+	prepareCall := preparer.EXPECT().Prepare(gomock.Any(), testData).Return(testInput, nil).After(loadDataCall)
+	minimizeCall := minimizer.EXPECT().Minimize(gomock.Any(), testInput).Return(minimizedInput, nil).After(prepareCall)
+	executeCall := executor.EXPECT().Execute(gomock.Any(), minimizedInput).Return(nil, nil).After(minimizeCall)
+	proverInputStore.EXPECT().StoreProverInput(gomock.Any(), minimizedInput).After(executeCall)
+
+	in, err := generator.Prepare(context.TODO(), big.NewInt(1))
+	require.NoError(t, err)
+	assert.Same(t, minimizedInput, in)
+}
+
 func TestGeneratorConfigError(t *testing.T) {
 	t.Run("ChainNotConfigured", func(t *testing.T) {
 		generator, err := NewGenerator(&Config{})
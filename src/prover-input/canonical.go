@@ -0,0 +1,72 @@
+package input
+
+import (
+	"bytes"
+	"sort"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Canonicalize sorts in place the prover input data that has no natural order, so that generating
+// the prover input of a block always results in the same encoding (byte-for-byte).
+//
+// - witness state nodes and codes are sorted in lexicographic order
+// - witness ancestors are sorted by decreasing block number (0=parent, 1=parent's-parent, etc.)
+// - access list is sorted by address, and each entry storage keys are sorted
+// - committed nodes are sorted in lexicographic order
+// - state diffs are sorted by address, and each state diff storage is sorted by slot
+// - transaction state diffs keep the execution order, and within each of them accounts are sorted by address and storage by slot
+//
+// Pre-state is a map which is encoded with sorted keys.
+func (in *ProverInput) Canonicalize() {
+	if in.Witness != nil {
+		sortBytes(in.Witness.State)
+		sortBytes(in.Witness.Codes)
+		sort.SliceStable(in.Witness.Ancestors, func(i, j int) bool {
+			return in.Witness.Ancestors[i].Number.Cmp(in.Witness.Ancestors[j].Number) > 0
+		})
+	}
+
+	if in.Extra != nil {
+		sort.Slice(in.Extra.AccessList, func(i, j int) bool {
+			return in.Extra.AccessList[i].Address.Cmp(in.Extra.AccessList[j].Address) < 0
+		})
+		for _, tuple := range in.Extra.AccessList {
+			sortHashes(tuple.StorageKeys)
+		}
+
+		sortBytes(in.Extra.Committed)
+
+		sort.Slice(in.Extra.StateDiffs, func(i, j int) bool {
+			return in.Extra.StateDiffs[i].Address.Cmp(in.Extra.StateDiffs[j].Address) < 0
+		})
+		for _, diff := range in.Extra.StateDiffs {
+			sort.Slice(diff.Storage, func(i, j int) bool {
+				return diff.Storage[i].Slot.Cmp(diff.Storage[j].Slot) < 0
+			})
+		}
+
+		for _, txDiff := range in.Extra.TxStateDiffs {
+			sort.Slice(txDiff.Accounts, func(i, j int) bool {
+				return txDiff.Accounts[i].Address.Cmp(txDiff.Accounts[j].Address) < 0
+			})
+			for _, diff := range txDiff.Accounts {
+				sort.Slice(diff.Storage, func(i, j int) bool {
+					return diff.Storage[i].Slot.Cmp(diff.Storage[j].Slot) < 0
+				})
+			}
+		}
+	}
+}
+
+func sortBytes(s [][]byte) {
+	sort.Slice(s, func(i, j int) bool {
+		return bytes.Compare(s[i], s[j]) < 0
+	})
+}
+
+func sortHashes(s []gethcommon.Hash) {
+	sort.Slice(s, func(i, j int) bool {
+		return s[i].Cmp(s[j]) < 0
+	})
+}
@@ -0,0 +1,62 @@
+package input
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalize(t *testing.T) {
+	var (
+		addr1 = gethcommon.HexToAddress("0x1")
+		addr2 = gethcommon.HexToAddress("0x2")
+		slot1 = gethcommon.HexToHash("0x1")
+		slot2 = gethcommon.HexToHash("0x2")
+	)
+
+	in := &ProverInput{
+		Witness: &Witness{
+			Ancestors: []*gethtypes.Header{{Number: big.NewInt(8)}, {Number: big.NewInt(9)}},
+			Codes:     [][]byte{{0x2}, {0x1, 0x2}, {0x1}},
+			State:     [][]byte{{0xb}, {0xa}},
+		},
+		Extra: &Extra{
+			AccessList: gethtypes.AccessList{
+				{Address: addr2, StorageKeys: []gethcommon.Hash{slot2, slot1}},
+				{Address: addr1, StorageKeys: []gethcommon.Hash{}},
+			},
+			Committed: [][]byte{{0xd}, {0xc}},
+			StateDiffs: []*StateDiff{
+				{Address: addr2, Storage: []*StorageDiff{{Slot: slot2}, {Slot: slot1}}},
+				{Address: addr1},
+			},
+			TxStateDiffs: []*TxStateDiff{
+				{Type: TxStateDiffTypeSystemCall},
+				{
+					Type: TxStateDiffTypeTransaction,
+					Accounts: []*AccountDiff{
+						{Address: addr2, Storage: []*StorageDiff{{Slot: slot2}, {Slot: slot1}}},
+						{Address: addr1},
+					},
+				},
+			},
+		},
+	}
+
+	in.Canonicalize()
+
+	assert.Equal(t, []uint64{9, 8}, []uint64{in.Witness.Ancestors[0].Number.Uint64(), in.Witness.Ancestors[1].Number.Uint64()})
+	assert.Equal(t, [][]byte{{0x1}, {0x1, 0x2}, {0x2}}, in.Witness.Codes)
+	assert.Equal(t, [][]byte{{0xa}, {0xb}}, in.Witness.State)
+	assert.Equal(t, addr1, in.Extra.AccessList[0].Address)
+	assert.Equal(t, []gethcommon.Hash{slot1, slot2}, in.Extra.AccessList[1].StorageKeys)
+	assert.Equal(t, [][]byte{{0xc}, {0xd}}, in.Extra.Committed)
+	assert.Equal(t, addr1, in.Extra.StateDiffs[0].Address)
+	assert.Equal(t, slot1, in.Extra.StateDiffs[1].Storage[0].Slot)
+	assert.Equal(t, TxStateDiffTypeSystemCall, in.Extra.TxStateDiffs[0].Type)
+	assert.Equal(t, addr1, in.Extra.TxStateDiffs[1].Accounts[0].Address)
+	assert.Equal(t, slot1, in.Extra.TxStateDiffs[1].Accounts[1].Storage[0].Slot)
+}
@@ -0,0 +1,410 @@
+package input
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/trie"
+)
+
+// DiffKind is the kind of a difference on data that is present in one prover input only
+type DiffKind string
+
+const (
+	DiffMissing DiffKind = "missing" // Present in the first prover input only
+	DiffExtra   DiffKind = "extra"   // Present in the second prover input only
+)
+
+// Tries of the state nodes
+const (
+	TrieAccount     = "account"
+	TrieStorage     = "storage"
+	TrieUnreachable = "unreachable" // The node is not reachable from the parent state root
+)
+
+// Diff is the semantic difference between two prover inputs
+type Diff struct {
+	Version     *ValueDiff      `json:"version,omitempty"`
+	Blocks      []*ValueDiff    `json:"blocks,omitempty"`
+	State       []*NodeDiff     `json:"state,omitempty"`
+	Codes       []*CodeDiff     `json:"codes,omitempty"`
+	Ancestors   []*AncestorDiff `json:"ancestors,omitempty"`
+	ChainConfig []*ValueDiff    `json:"chainConfig,omitempty"`
+	Extra       []*ValueDiff    `json:"extra,omitempty"`
+}
+
+// Equal returns whether the prover inputs have no difference
+func (d *Diff) Equal() bool {
+	return d.Version == nil &&
+		len(d.Blocks) == 0 &&
+		len(d.State) == 0 &&
+		len(d.Codes) == 0 &&
+		len(d.Ancestors) == 0 &&
+		len(d.ChainConfig) == 0 &&
+		len(d.Extra) == 0
+}
+
+// ValueDiff is a difference on a value, located by its JSON path (e.g. "extra.stateDiffs[0x...].postAccount.nonce")
+//
+// A (resp. B) is the JSON value in the first (resp. second) prover input, nil if the value is absent.
+type ValueDiff struct {
+	Path string `json:"path"`
+	A    any    `json:"a,omitempty"`
+	B    any    `json:"b,omitempty"`
+}
+
+// NodeDiff is a state node present in one prover input only
+type NodeDiff struct {
+	Kind    DiffKind            `json:"kind"`
+	Hash    gethcommon.Hash     `json:"hash"`
+	Size    int                 `json:"size"`
+	Trie    string              `json:"trie"`
+	Account *gethcommon.Hash    `json:"account,omitempty"` // Key of the account owning the storage trie (i.e. keccak(address))
+	Address *gethcommon.Address `json:"address,omitempty"` // Address of the account owning the storage trie, if known
+	Path    string              `json:"path"`              // Path of the node in its trie, in hex encoded nibbles
+}
+
+// CodeDiff is a code present in one prover input only
+type CodeDiff struct {
+	Kind     DiffKind        `json:"kind"`
+	CodeHash gethcommon.Hash `json:"codeHash"`
+	Size     int             `json:"size"`
+}
+
+// AncestorDiff is an ancestor header present in one prover input only
+type AncestorDiff struct {
+	Kind   DiffKind        `json:"kind"`
+	Number uint64          `json:"number"`
+	Hash   gethcommon.Hash `json:"hash"`
+}
+
+// Compare computes the semantic difference between two prover inputs
+//
+// Both prover inputs are canonicalized (in place), so the order of data that has no natural order is ignored.
+// Witness state nodes, codes and ancestors are compared as sets, and state nodes are located in their trie by walking
+// the tries from the parent state root of the prover input they belong to.
+// Other data is compared on its JSON encoding, ignoring the difference between absent and empty values.
+func Compare(a, b *ProverInput) (*Diff, error) {
+	a.Canonicalize()
+	b.Canonicalize()
+
+	d := new(Diff)
+	if a.Version != b.Version {
+		d.Version = &ValueDiff{Path: "version", A: a.Version, B: b.Version}
+	}
+
+	var err error
+	if d.Blocks, err = diffJSON("blocks", a.Blocks, b.Blocks); err != nil {
+		return nil, err
+	}
+	if d.ChainConfig, err = diffJSON("chainConfig", a.ChainConfig, b.ChainConfig); err != nil {
+		return nil, err
+	}
+	if d.Extra, err = diffJSON("extra", a.Extra, b.Extra); err != nil {
+		return nil, err
+	}
+
+	wa, wb := a.Witness, b.Witness
+	if wa == nil {
+		wa = new(Witness)
+	}
+	if wb == nil {
+		wb = new(Witness)
+	}
+
+	if d.State, err = diffState(a, b); err != nil {
+		return nil, err
+	}
+	d.Codes = diffCodes(wa.Codes, wb.Codes)
+	d.Ancestors = diffAncestors(wa, wb)
+
+	return d, nil
+}
+
+func diffState(a, b *ProverInput) ([]*NodeDiff, error) {
+	addresses := knownAddresses(a)
+	for key, addr := range knownAddresses(b) {
+		addresses[key] = addr
+	}
+
+	nodesA, locationsA, err := locateNodes(a)
+	if err != nil {
+		return nil, fmt.Errorf("first prover input: %w", err)
+	}
+	nodesB, locationsB, err := locateNodes(b)
+	if err != nil {
+		return nil, fmt.Errorf("second prover input: %w", err)
+	}
+
+	var diffs []*NodeDiff
+	add := func(kind DiffKind, hash gethcommon.Hash, node []byte, location *trie.NodeLocation) {
+		diff := &NodeDiff{Kind: kind, Hash: hash, Size: len(node), Trie: TrieUnreachable}
+		if location != nil {
+			diff.Path = nibblesToHex(location.Path)
+			diff.Trie = TrieAccount
+			if !location.IsAccountTrie() {
+				owner := location.Owner
+				diff.Trie, diff.Account = TrieStorage, &owner
+				if addr, ok := addresses[owner]; ok {
+					diff.Address = &addr
+				}
+			}
+		}
+		diffs = append(diffs, diff)
+	}
+	for hash, node := range nodesA {
+		if _, ok := nodesB[hash]; !ok {
+			add(DiffMissing, hash, node, locationsA[hash])
+		}
+	}
+	for hash, node := range nodesB {
+		if _, ok := nodesA[hash]; !ok {
+			add(DiffExtra, hash, node, locationsB[hash])
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		x, y := diffs[i], diffs[j]
+		switch {
+		case x.Trie != y.Trie:
+			return x.Trie < y.Trie
+		case x.Account != nil && *x.Account != *y.Account:
+			return x.Account.Cmp(*y.Account) < 0
+		case x.Path != y.Path:
+			return x.Path < y.Path
+		case x.Kind != y.Kind:
+			return x.Kind > y.Kind // missing first
+		default:
+			return x.Hash.Cmp(y.Hash) < 0
+		}
+	})
+	return diffs, nil
+}
+
+// nibblesToHex returns the hex encoding of a path in nibbles (e.g. [0x3, 0xa] -> "3a")
+func nibblesToHex(nibbles []byte) string {
+	const digits = "0123456789abcdef"
+	b := make([]byte, len(nibbles))
+	for i, nibble := range nibbles {
+		b[i] = digits[nibble&0x0f]
+	}
+	return string(b)
+}
+
+// locateNodes returns the witness state nodes of a prover input and their location, indexed by hash
+func locateNodes(in *ProverInput) (map[gethcommon.Hash][]byte, map[gethcommon.Hash]*trie.NodeLocation, error) {
+	if in.Witness == nil {
+		return nil, nil, nil
+	}
+
+	nodes := make(map[gethcommon.Hash][]byte, len(in.Witness.State))
+	for _, node := range in.Witness.State {
+		nodes[crypto.Keccak256Hash(node)] = node
+	}
+
+	parent := parentHeader(in)
+	if parent == nil {
+		return nodes, nil, nil
+	}
+	locations, err := trie.NodeLocations(parent.Root, nodes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nodes, locations, nil
+}
+
+func diffCodes(a, b [][]byte) []*CodeDiff {
+	index := func(codes [][]byte) map[gethcommon.Hash]int {
+		sizes := make(map[gethcommon.Hash]int, len(codes))
+		for _, code := range codes {
+			sizes[crypto.Keccak256Hash(code)] = len(code)
+		}
+		return sizes
+	}
+	sizesA, sizesB := index(a), index(b)
+
+	var diffs []*CodeDiff
+	for codeHash, size := range sizesA {
+		if _, ok := sizesB[codeHash]; !ok {
+			diffs = append(diffs, &CodeDiff{Kind: DiffMissing, CodeHash: codeHash, Size: size})
+		}
+	}
+	for codeHash, size := range sizesB {
+		if _, ok := sizesA[codeHash]; !ok {
+			diffs = append(diffs, &CodeDiff{Kind: DiffExtra, CodeHash: codeHash, Size: size})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Kind != diffs[j].Kind {
+			return diffs[i].Kind > diffs[j].Kind
+		}
+		return diffs[i].CodeHash.Cmp(diffs[j].CodeHash) < 0
+	})
+	return diffs
+}
+
+func diffAncestors(a, b *Witness) []*AncestorDiff {
+	hashes := func(w *Witness) map[gethcommon.Hash]uint64 {
+		numbers := make(map[gethcommon.Hash]uint64, len(w.Ancestors))
+		for _, ancestor := range w.Ancestors {
+			numbers[ancestor.Hash()] = ancestor.Number.Uint64()
+		}
+		return numbers
+	}
+	numbersA, numbersB := hashes(a), hashes(b)
+
+	var diffs []*AncestorDiff
+	for hash, number := range numbersA {
+		if _, ok := numbersB[hash]; !ok {
+			diffs = append(diffs, &AncestorDiff{Kind: DiffMissing, Number: number, Hash: hash})
+		}
+	}
+	for hash, number := range numbersB {
+		if _, ok := numbersA[hash]; !ok {
+			diffs = append(diffs, &AncestorDiff{Kind: DiffExtra, Number: number, Hash: hash})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Number != diffs[j].Number {
+			return diffs[i].Number > diffs[j].Number
+		}
+		return diffs[i].Kind > diffs[j].Kind
+	})
+	return diffs
+}
+
+// diffJSON compares the JSON encodings of a and b
+func diffJSON(path string, a, b any) ([]*ValueDiff, error) {
+	va, err := toJSONValue(a)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	vb, err := toJSONValue(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	var diffs []*ValueDiff
+	diffJSONValues(path, va, vb, &diffs)
+	return diffs, nil
+}
+
+func toJSONValue(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber() // keeps big integers exact
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func diffJSONValues(path string, a, b any, diffs *[]*ValueDiff) {
+	if isEmptyJSON(a) && isEmptyJSON(b) {
+		return
+	}
+
+	switch va := a.(type) {
+	case map[string]any:
+		if vb, ok := b.(map[string]any); ok {
+			for _, key := range unionKeys(va, vb) {
+				diffJSONValues(path+"."+key, va[key], vb[key], diffs)
+			}
+			return
+		}
+	case []any:
+		if vb, ok := b.([]any); ok {
+			diffJSONArrays(path, va, vb, diffs)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		if isEmptyJSON(a) {
+			a = nil
+		}
+		if isEmptyJSON(b) {
+			b = nil
+		}
+		*diffs = append(*diffs, &ValueDiff{Path: path, A: a, B: b})
+	}
+}
+
+// diffJSONArrays compares arrays of objects having an address (e.g. state diffs) by address, and other arrays by index
+func diffJSONArrays(path string, a, b []any, diffs *[]*ValueDiff) {
+	keyedA, okA := keyByAddress(a)
+	keyedB, okB := keyByAddress(b)
+	if okA && okB {
+		for _, key := range unionKeys(keyedA, keyedB) {
+			diffJSONValues(fmt.Sprintf("%s[%s]", path, key), keyedA[key], keyedB[key], diffs)
+		}
+		return
+	}
+
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var ea, eb any
+		if i < len(a) {
+			ea = a[i]
+		}
+		if i < len(b) {
+			eb = b[i]
+		}
+		diffJSONValues(fmt.Sprintf("%s[%d]", path, i), ea, eb, diffs)
+	}
+}
+
+func keyByAddress(elems []any) (map[string]any, bool) {
+	keyed := make(map[string]any, len(elems))
+	for _, elem := range elems {
+		obj, ok := elem.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		addr, ok := obj["address"].(string)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := keyed[addr]; ok {
+			return nil, false
+		}
+		keyed[addr] = elem
+	}
+	return keyed, true
+}
+
+func unionKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isEmptyJSON returns whether a JSON value is null, an empty array or an empty object
+func isEmptyJSON(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
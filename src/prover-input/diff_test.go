@@ -0,0 +1,125 @@
+package input
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareEqual(t *testing.T) {
+	a, b := newTestProverInput(t), newTestProverInput(t)
+
+	// Order and empty values are ignored
+	b.Witness.State[0], b.Witness.State[1] = b.Witness.State[1], b.Witness.State[0]
+	b.Witness.Ancestors[0], b.Witness.Ancestors[1] = b.Witness.Ancestors[1], b.Witness.Ancestors[0]
+	b.Extra.Senders = []gethcommon.Address{}
+
+	diff, err := Compare(a, b)
+	require.NoError(t, err)
+	assert.True(t, diff.Equal())
+}
+
+func TestCompare(t *testing.T) {
+	a, b := newTestProverInput(t), newTestProverInput(t)
+
+	chainConfigA, chainConfigB := *params.TestChainConfig, *params.TestChainConfig
+	chainConfigB.ChainID = big.NewInt(2)
+	a.ChainConfig, b.ChainConfig = &chainConfigA, &chainConfigB
+
+	// Remove a storage node from b
+	nodes := make(map[gethcommon.Hash][]byte)
+	for _, node := range b.Witness.State {
+		nodes[crypto.Keccak256Hash(node)] = node
+	}
+	locations, err := trie.NodeLocations(b.Witness.Ancestors[0].Root, nodes)
+	require.NoError(t, err)
+	var (
+		removed     gethcommon.Hash
+		removedPath []byte
+	)
+	for hash, location := range locations {
+		if !location.IsAccountTrie() && len(location.Path) > 0 {
+			removed, removedPath = hash, location.Path
+			break
+		}
+	}
+	require.NotEqual(t, gethcommon.Hash{}, removed)
+	state := b.Witness.State[:0]
+	for _, node := range b.Witness.State {
+		if crypto.Keccak256Hash(node) != removed {
+			state = append(state, node)
+		}
+	}
+	b.Witness.State = state
+
+	// Add an unreachable node to b
+	extraNode := []byte{0xc1, 0x80}
+	b.Witness.State = append(b.Witness.State, extraNode)
+
+	// Replace a code and remove an ancestor
+	extraCode := []byte{0x01, 0x02}
+	b.Witness.Codes = [][]byte{testCode, extraCode}
+	b.Witness.Ancestors = b.Witness.Ancestors[:1]
+
+	// Change extra data
+	b.Extra.PreState[testContract].Nonce = 1
+	b.Extra.ProvingCost = nil
+
+	diff, err := Compare(a, b)
+	require.NoError(t, err)
+	assert.False(t, diff.Equal())
+
+	assert.Nil(t, diff.Version)
+	assert.Empty(t, diff.Blocks)
+
+	account := crypto.Keccak256Hash(testContract.Bytes())
+	assert.Equal(t, []*NodeDiff{
+		{Kind: DiffMissing, Hash: removed, Size: len(nodes[removed]), Trie: TrieStorage, Account: &account, Address: &testContract, Path: nibblesToHex(removedPath)},
+		{Kind: DiffExtra, Hash: crypto.Keccak256Hash(extraNode), Size: len(extraNode), Trie: TrieUnreachable},
+	}, diff.State)
+
+	assert.Equal(t, []*CodeDiff{
+		{Kind: DiffMissing, CodeHash: crypto.Keccak256Hash([]byte{0x00}), Size: 1},
+		{Kind: DiffExtra, CodeHash: crypto.Keccak256Hash(extraCode), Size: len(extraCode)},
+	}, diff.Codes)
+
+	require.Len(t, diff.Ancestors, 1)
+	assert.Equal(t, DiffMissing, diff.Ancestors[0].Kind)
+	assert.Equal(t, uint64(8), diff.Ancestors[0].Number)
+
+	require.Len(t, diff.ChainConfig, 1)
+	assert.Equal(t, "chainConfig.chainId", diff.ChainConfig[0].Path)
+	assert.Equal(t, json.Number("1"), diff.ChainConfig[0].A)
+	assert.Equal(t, json.Number("2"), diff.ChainConfig[0].B)
+
+	require.Len(t, diff.Extra, 2)
+	assert.Equal(t, "extra.preState.0x0000000000000000000000000000000000000002.nonce", diff.Extra[0].Path)
+	assert.Equal(t, "0x0", diff.Extra[0].A)
+	assert.Equal(t, "0x1", diff.Extra[0].B)
+	assert.Equal(t, "extra.provingCost", diff.Extra[1].Path)
+	assert.NotNil(t, diff.Extra[1].A)
+	assert.Nil(t, diff.Extra[1].B)
+}
+
+func TestDiffJSONArrays(t *testing.T) {
+	a := []map[string]any{{"address": "0x1", "nonce": 1}, {"address": "0x2", "nonce": 1}}
+	b := []map[string]any{{"address": "0x2", "nonce": 2}}
+
+	diffs, err := diffJSON("stateDiffs", a, b)
+	require.NoError(t, err)
+	require.Len(t, diffs, 2)
+	assert.Equal(t, "stateDiffs[0x1]", diffs[0].Path)
+	assert.Nil(t, diffs[0].B)
+	assert.Equal(t, &ValueDiff{Path: "stateDiffs[0x2].nonce", A: json.Number("1"), B: json.Number("2")}, diffs[1])
+
+	diffs, err = diffJSON("list", []int{1, 2}, []int{1})
+	require.NoError(t, err)
+	assert.Equal(t, []*ValueDiff{{Path: "list[1]", A: json.Number("2")}}, diffs)
+}
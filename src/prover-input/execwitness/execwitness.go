@@ -0,0 +1,137 @@
+// Package execwitness converts prover inputs to and from the execution witness format of go-ethereum
+// (as returned by the `debug_executionWitness` JSON-RPC method).
+package execwitness
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+)
+
+// ProverInput is a prover input in the go-ethereum execution witness format
+//
+// The witness fields are encoded at top level (so the encoding can be decoded as a `debug_executionWitness` result),
+// while blocks, chain config and extra data are carried in separate fields that execution witness decoders ignore.
+type ProverInput struct {
+	ExtWitness
+	Version     string              `json:"version"`
+	Blocks      []*input.Block      `json:"blocks"`
+	ChainConfig *params.ChainConfig `json:"chainConfig"`
+	Extra       *input.Extra        `json:"extra,omitempty"`
+}
+
+// ExtWitness is the execution witness format of go-ethereum
+type ExtWitness struct {
+	Headers []*gethtypes.Header `json:"headers"` // Ancestors headers in reverse order (0=parent, 1=parent's-parent, etc.)
+	Codes   []hexutil.Bytes     `json:"codes"`   // Contract bytecodes
+	State   []hexutil.Bytes     `json:"state"`   // MPT nodes
+	Keys    []hexutil.Bytes     `json:"keys"`    // Preimages of the trie keys (addresses and storage slots)
+}
+
+// ToExecWitness converts a prover input to the execution witness format
+//
+// Keys are set from the prover input keccak preimages (if included).
+func ToExecWitness(pi *input.ProverInput) *ProverInput {
+	if pi == nil {
+		return nil
+	}
+
+	var preimages *input.Preimages
+	if pi.Extra != nil {
+		preimages = pi.Extra.Preimages
+	}
+
+	w := pi.Witness
+	if w == nil {
+		w = &input.Witness{}
+	}
+
+	return &ProverInput{
+		ExtWitness:  *ToExtWitness(w, preimages),
+		Version:     pi.Version,
+		Blocks:      pi.Blocks,
+		ChainConfig: pi.ChainConfig,
+		Extra:       pi.Extra,
+	}
+}
+
+// FromExecWitness converts a prover input in the execution witness format to a prover input
+func FromExecWitness(pi *ProverInput) *input.ProverInput {
+	if pi == nil {
+		return nil
+	}
+
+	return &input.ProverInput{
+		Version:     pi.Version,
+		Blocks:      pi.Blocks,
+		Witness:     FromExtWitness(&pi.ExtWitness),
+		ChainConfig: pi.ChainConfig,
+		Extra:       pi.Extra,
+	}
+}
+
+// ToExtWitness converts a witness to the execution witness format, with keys sorted in lexicographic order
+func ToExtWitness(w *input.Witness, preimages *input.Preimages) *ExtWitness {
+	if w == nil {
+		return nil
+	}
+
+	ext := &ExtWitness{
+		Headers: w.Ancestors,
+		Codes:   toHex(w.Codes),
+		State:   toHex(w.State),
+		Keys:    make([]hexutil.Bytes, 0),
+	}
+	if ext.Headers == nil {
+		ext.Headers = make([]*gethtypes.Header, 0)
+	}
+
+	if preimages != nil {
+		for addr := range preimages.Accounts {
+			ext.Keys = append(ext.Keys, addr.Bytes())
+		}
+		for slot := range preimages.Slots {
+			ext.Keys = append(ext.Keys, slot.Bytes())
+		}
+		sort.Slice(ext.Keys, func(i, j int) bool {
+			return bytes.Compare(ext.Keys[i], ext.Keys[j]) < 0
+		})
+	}
+
+	return ext
+}
+
+// FromExtWitness converts a witness in the execution witness format to a witness
+//
+// Keys are dropped, as preimages are carried by the prover input extra data.
+func FromExtWitness(ext *ExtWitness) *input.Witness {
+	if ext == nil {
+		return nil
+	}
+
+	return &input.Witness{
+		Ancestors: ext.Headers,
+		Codes:     fromHex(ext.Codes),
+		State:     fromHex(ext.State),
+	}
+}
+
+func toHex(b [][]byte) []hexutil.Bytes {
+	h := make([]hexutil.Bytes, len(b))
+	for i := range b {
+		h[i] = b[i]
+	}
+	return h
+}
+
+func fromHex(h []hexutil.Bytes) [][]byte {
+	b := make([][]byte, len(h))
+	for i := range h {
+		b[i] = h[i]
+	}
+	return b
+}
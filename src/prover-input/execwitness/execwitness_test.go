@@ -0,0 +1,99 @@
+package execwitness
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtWitness(t *testing.T) {
+	var (
+		addr = gethcommon.HexToAddress("0xa")
+		slot = gethcommon.HexToHash("0x1")
+	)
+
+	w := &input.Witness{
+		Ancestors: []*gethtypes.Header{{Number: big.NewInt(9), Difficulty: big.NewInt(0)}},
+		State:     [][]byte{{0x01, 0x02}, {0x03}},
+		Codes:     [][]byte{{0x60, 0x00}},
+	}
+	preimages := &input.Preimages{
+		Accounts: map[gethcommon.Address]gethcommon.Hash{addr: {}},
+		Slots:    map[gethcommon.Hash]gethcommon.Hash{slot: {}},
+	}
+
+	ext := ToExtWitness(w, preimages)
+	assert.Equal(t, w.Ancestors, ext.Headers)
+	assert.Len(t, ext.Keys, 2)
+	assert.Equal(t, []byte(slot.Bytes()), []byte(ext.Keys[0]))
+	assert.Equal(t, []byte(addr.Bytes()), []byte(ext.Keys[1]))
+
+	b, err := json.Marshal(ext)
+	require.NoError(t, err)
+
+	var raw map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &raw))
+	assert.Equal(t, `["0x0102","0x03"]`, string(raw["state"]))
+	assert.Equal(t, `["0x6000"]`, string(raw["codes"]))
+
+	decoded := new(ExtWitness)
+	require.NoError(t, json.Unmarshal(b, decoded))
+	assert.Equal(t, w.State, FromExtWitness(decoded).State)
+	assert.Equal(t, w.Codes, FromExtWitness(decoded).Codes)
+
+	// Empty witness encodes to empty lists
+	b, err = json.Marshal(ToExtWitness(&input.Witness{}, nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"headers":[],"codes":[],"state":[],"keys":[]}`, string(b))
+}
+
+func TestExecWitnessRoundTrip(t *testing.T) {
+	in := &input.ProverInput{
+		Version: "1",
+		Blocks: []*input.Block{
+			{
+				Header:       &gethtypes.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0)},
+				Transactions: []*gethtypes.Transaction{},
+				Uncles:       []*gethtypes.Header{},
+				Withdrawals:  []*gethtypes.Withdrawal{},
+			},
+		},
+		Witness: &input.Witness{
+			Ancestors: []*gethtypes.Header{{Number: big.NewInt(9), Difficulty: big.NewInt(0)}},
+			State:     [][]byte{{0x01}},
+			Codes:     [][]byte{{0x60, 0x00}},
+		},
+		ChainConfig: params.MainnetChainConfig,
+		Extra:       &input.Extra{Senders: []gethcommon.Address{gethcommon.HexToAddress("0xa")}},
+	}
+
+	b, err := json.Marshal(ToExecWitness(in))
+	require.NoError(t, err)
+
+	// The encoding is a `debug_executionWitness` result (witness fields at top level)
+	var raw map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &raw))
+	assert.NotContains(t, raw, "witness")
+	ext := new(ExtWitness)
+	require.NoError(t, json.Unmarshal(b, ext))
+	assert.Equal(t, in.Witness.Ancestors[0].Hash(), ext.Headers[0].Hash())
+	assert.Equal(t, in.Witness.State, FromExtWitness(ext).State)
+	assert.Equal(t, in.Witness.Codes, FromExtWitness(ext).Codes)
+
+	decoded := new(ProverInput)
+	require.NoError(t, json.Unmarshal(b, decoded))
+	out := FromExecWitness(decoded)
+
+	expected, err := json.Marshal(in)
+	require.NoError(t, err)
+	actual, err := json.Marshal(out)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
@@ -89,25 +89,40 @@ func (b *Block) Block() *gethtypes.Block {
 
 // Extra contains additional data that can be included in the Prover Input.
 type Extra struct {
-	AccessList gethtypes.AccessList                 // Access list of addresses and storage slots that were accessed during block execution
-	Committed  [][]byte                             // Nodes committed during block execution
-	StateDiffs []*StateDiff                         // State diffs for accounts that have changes during block execution
-	PreState   map[gethcommon.Address]*AccountState // Pre-state for accounts that have changes during block execution
+	AccessList   gethtypes.AccessList                 // Access list of addresses and storage slots that were accessed during block execution
+	Committed    [][]byte                             // Nodes committed during block execution
+	StateDiffs   []*StateDiff                         // State diffs for accounts that have changes during block execution
+	PreState     map[gethcommon.Address]*AccountState // Pre-state for accounts that have changes during block execution
+	ProvingCost  *ProvingCost                         // Estimated cost of proving the blocks
+	Senders      []gethcommon.Address                 // Recovered sender of every transaction, in blocks and transactions order
+	Receipts     []*gethtypes.Receipt                 // Receipt of every transaction, in blocks and transactions order
+	TxStateDiffs []*TxStateDiff                       // State diffs of every transaction and system operation, in execution order
+	Preimages    *Preimages                           // Keccak preimages of the trie keys resolved during block execution
 }
 
 type extraMarshaling struct {
-	AccessList gethtypes.AccessList                 `json:"accessList,omitempty"`
-	Committed  []hexutil.Bytes                      `json:"committed,omitempty"`
-	StateDiffs []*StateDiff                         `json:"stateDiffs,omitempty"`
-	PreState   map[gethcommon.Address]*AccountState `json:"preState,omitempty"`
+	AccessList   gethtypes.AccessList                 `json:"accessList,omitempty"`
+	Committed    []hexutil.Bytes                      `json:"committed,omitempty"`
+	StateDiffs   []*StateDiff                         `json:"stateDiffs,omitempty"`
+	PreState     map[gethcommon.Address]*AccountState `json:"preState,omitempty"`
+	ProvingCost  *ProvingCost                         `json:"provingCost,omitempty"`
+	Senders      []gethcommon.Address                 `json:"senders,omitempty"`
+	Receipts     []*gethtypes.Receipt                 `json:"receipts,omitempty"`
+	TxStateDiffs []*TxStateDiff                       `json:"txStateDiffs,omitempty"`
+	Preimages    *Preimages                           `json:"preimages,omitempty"`
 }
 
 func (e *Extra) MarshalJSON() ([]byte, error) {
 	return json.Marshal(extraMarshaling{
-		AccessList: e.AccessList,
-		Committed:  bytesToHex(e.Committed),
-		StateDiffs: e.StateDiffs,
-		PreState:   e.PreState,
+		AccessList:   e.AccessList,
+		Committed:    bytesToHex(e.Committed),
+		StateDiffs:   e.StateDiffs,
+		PreState:     e.PreState,
+		ProvingCost:  e.ProvingCost,
+		Senders:      e.Senders,
+		Receipts:     e.Receipts,
+		TxStateDiffs: e.TxStateDiffs,
+		Preimages:    e.Preimages,
 	})
 }
 
@@ -120,6 +135,11 @@ func (e *Extra) UnmarshalJSON(b []byte) error {
 	e.Committed = hexToBytes(m.Committed)
 	e.StateDiffs = m.StateDiffs
 	e.PreState = m.PreState
+	e.ProvingCost = m.ProvingCost
+	e.Senders = m.Senders
+	e.Receipts = m.Receipts
+	e.TxStateDiffs = m.TxStateDiffs
+	e.Preimages = m.Preimages
 
 	return nil
 }
@@ -176,6 +196,115 @@ type StorageDiff struct {
 	PostValue gethcommon.Hash `json:"postValue,omitempty"`
 }
 
+// TxStateDiffType is the type of operation that a transaction state diff results from.
+type TxStateDiffType string
+
+const (
+	TxStateDiffTypeTransaction TxStateDiffType = "transaction" // Transaction
+	TxStateDiffTypeSystemCall  TxStateDiffType = "systemCall"  // System call (e.g. EIP-4788 beacon root, EIP-7002 withdrawal queue)
+	TxStateDiffTypeWithdrawals TxStateDiffType = "withdrawals" // Withdrawals processing
+	TxStateDiffTypeBlock       TxStateDiffType = "block"       // Other block level changes (e.g. mining rewards)
+)
+
+// TxStateDiff represents the state changes of a single operation of a block (transaction, system call or withdrawals).
+type TxStateDiff struct {
+	BlockNumber   uint64
+	Type          TxStateDiffType
+	TxIndex       *uint64             // Index of the transaction in the block (only set for transactions)
+	TxHash        *gethcommon.Hash    // Hash of the transaction (only set for transactions)
+	SystemAddress *gethcommon.Address // Address of the called system contract (only set for system calls)
+	Accounts      []*AccountDiff      // Accounts changed by the operation
+}
+
+type txStateDiffMarshaling struct {
+	BlockNumber   hexutil.Uint64      `json:"blockNumber"`
+	Type          TxStateDiffType     `json:"type"`
+	TxIndex       *hexutil.Uint64     `json:"txIndex,omitempty"`
+	TxHash        *gethcommon.Hash    `json:"txHash,omitempty"`
+	SystemAddress *gethcommon.Address `json:"systemAddress,omitempty"`
+	Accounts      []*AccountDiff      `json:"accounts"`
+}
+
+func (d *TxStateDiff) MarshalJSON() ([]byte, error) {
+	return json.Marshal(txStateDiffMarshaling{
+		BlockNumber:   hexutil.Uint64(d.BlockNumber),
+		Type:          d.Type,
+		TxIndex:       (*hexutil.Uint64)(d.TxIndex),
+		TxHash:        d.TxHash,
+		SystemAddress: d.SystemAddress,
+		Accounts:      d.Accounts,
+	})
+}
+
+func (d *TxStateDiff) UnmarshalJSON(b []byte) error {
+	var m txStateDiffMarshaling
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	d.BlockNumber = uint64(m.BlockNumber)
+	d.Type = m.Type
+	d.TxIndex = (*uint64)(m.TxIndex)
+	d.TxHash = m.TxHash
+	d.SystemAddress = m.SystemAddress
+	d.Accounts = m.Accounts
+
+	return nil
+}
+
+// AccountDiff represents the changes of an account during a single operation.
+// Only changed fields are set.
+type AccountDiff struct {
+	Address      gethcommon.Address
+	PreBalance   *big.Int
+	PostBalance  *big.Int
+	PreNonce     *uint64
+	PostNonce    *uint64
+	PreCodeHash  *gethcommon.Hash
+	PostCodeHash *gethcommon.Hash
+	Storage      []*StorageDiff
+}
+
+type accountDiffMarshaling struct {
+	Address      gethcommon.Address `json:"address"`
+	PreBalance   *hexutil.Big       `json:"preBalance,omitempty"`
+	PostBalance  *hexutil.Big       `json:"postBalance,omitempty"`
+	PreNonce     *hexutil.Uint64    `json:"preNonce,omitempty"`
+	PostNonce    *hexutil.Uint64    `json:"postNonce,omitempty"`
+	PreCodeHash  *gethcommon.Hash   `json:"preCodeHash,omitempty"`
+	PostCodeHash *gethcommon.Hash   `json:"postCodeHash,omitempty"`
+	Storage      []*StorageDiff     `json:"storage,omitempty"`
+}
+
+func (a *AccountDiff) MarshalJSON() ([]byte, error) {
+	return json.Marshal(accountDiffMarshaling{
+		Address:      a.Address,
+		PreBalance:   (*hexutil.Big)(a.PreBalance),
+		PostBalance:  (*hexutil.Big)(a.PostBalance),
+		PreNonce:     (*hexutil.Uint64)(a.PreNonce),
+		PostNonce:    (*hexutil.Uint64)(a.PostNonce),
+		PreCodeHash:  a.PreCodeHash,
+		PostCodeHash: a.PostCodeHash,
+		Storage:      a.Storage,
+	})
+}
+
+func (a *AccountDiff) UnmarshalJSON(b []byte) error {
+	var m accountDiffMarshaling
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	a.Address = m.Address
+	a.PreBalance = (*big.Int)(m.PreBalance)
+	a.PostBalance = (*big.Int)(m.PostBalance)
+	a.PreNonce = (*uint64)(m.PreNonce)
+	a.PostNonce = (*uint64)(m.PostNonce)
+	a.PreCodeHash = m.PreCodeHash
+	a.PostCodeHash = m.PostCodeHash
+	a.Storage = m.Storage
+
+	return nil
+}
+
 // AccountState represents the state of an account.
 type AccountState struct {
 	Balance     *big.Int
@@ -220,3 +349,24 @@ func (a *AccountState) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// ProvingCost is the estimated cost of proving blocks, computed from statistics collected during block execution.
+type ProvingCost struct {
+	Cycles       uint64                                  `json:"cycles"`                // Estimated number of proving cycles
+	Opcodes      map[string]uint64                       `json:"opcodes,omitempty"`     // Number of executions of each opcode
+	Precompiles  map[gethcommon.Address]*PrecompileUsage `json:"precompiles,omitempty"` // Calls to each precompile
+	KeccakBytes  uint64                                  `json:"keccakBytes"`           // Number of bytes hashed by KECCAK256
+	WitnessNodes uint64                                  `json:"witnessNodes"`          // Number of state nodes in the execution witness
+}
+
+// PrecompileUsage represents the calls to a precompile.
+type PrecompileUsage struct {
+	Calls      uint64 `json:"calls"`      // Number of calls
+	InputBytes uint64 `json:"inputBytes"` // Total size of the call inputs
+}
+
+// Preimages maps the accounts and storage slots to their keys in the MPT (see trie.AccountTrieKey and trie.StorageTrieKey).
+type Preimages struct {
+	Accounts map[gethcommon.Address]gethcommon.Hash `json:"accounts,omitempty"` // address -> keccak(address)
+	Slots    map[gethcommon.Hash]gethcommon.Hash    `json:"slots,omitempty"`    // slot -> keccak(slot)
+}
@@ -1,59 +1,80 @@
 package proto
 
 import (
+	"fmt"
+	"math/big"
+
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	input "github.com/kkrt-labs/zk-pig/src/prover-input"
 )
 
-func BlockToProto(b *input.Block) *Block {
+func BlockToProto(b *input.Block) (*Block, error) {
 	if b == nil {
-		return nil
+		return nil, nil
+	}
+
+	transactions, err := TransactionsToProto(b.Transactions)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Block{
 		Header:       HeaderToProto(b.Header),
-		Transactions: TransactionsToProto(b.Transactions),
+		Transactions: transactions,
 		Uncles:       HeadersToProto(b.Uncles), // we assume a post-merge
 		Withdrawals:  WithdrawalsToProto(b.Withdrawals),
-	}
+	}, nil
 }
 
-func BlockFromProto(b *Block) *input.Block {
+func BlockFromProto(b *Block) (*input.Block, error) {
 	if b == nil {
-		return nil
+		return nil, nil
+	}
+
+	transactions, err := TransactionsFromProto(b.Transactions)
+	if err != nil {
+		return nil, err
 	}
 
 	return &input.Block{
 		Header:       HeaderFromProto(b.Header),
-		Transactions: TransactionsFromProto(b.Transactions),
+		Transactions: transactions,
 		Uncles:       HeadersFromProto(b.Uncles), // we assume a post-merge
 		Withdrawals:  WithdrawalsFromProto(b.Withdrawals),
-	}
+	}, nil
 }
 
-func BlocksToProto(blocks []*input.Block) []*Block {
+func BlocksToProto(blocks []*input.Block) ([]*Block, error) {
 	if blocks == nil {
-		return nil
+		return nil, nil
 	}
 
 	result := make([]*Block, len(blocks))
 	for i, b := range blocks {
-		result[i] = BlockToProto(b)
+		block, err := BlockToProto(b)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+		result[i] = block
 	}
-	return result
+	return result, nil
 }
 
-func BlocksFromProto(blocks []*Block) []*input.Block {
+func BlocksFromProto(blocks []*Block) ([]*input.Block, error) {
 	if blocks == nil {
-		return nil
+		return nil, nil
 	}
 
 	result := make([]*input.Block, len(blocks))
 	for i, b := range blocks {
-		result[i] = BlockFromProto(b)
+		block, err := BlockFromProto(b)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+		result[i] = block
 	}
-	return result
+	return result, nil
 }
 
 func HeadersToProto(headers []*gethtypes.Header) []*Header {
@@ -139,8 +160,8 @@ func HeaderFromProto(h *Header) *gethtypes.Header {
 		TxHash:           gethcommon.BytesToHash(h.GetTransactionsRoot()),
 		ReceiptHash:      gethcommon.BytesToHash(h.GetReceiptsRoot()),
 		Bloom:            gethtypes.Bloom(h.GetLogsBloom()),
-		Difficulty:       bytesToBigInt(h.Difficulty),
-		Number:           bytesToBigInt(h.Number),
+		Difficulty:       new(big.Int).SetBytes(h.GetDifficulty()), // mandatory field, a zero value is encoded as empty bytes
+		Number:           new(big.Int).SetBytes(h.GetNumber()),
 		GasLimit:         h.GetGasLimit(),
 		GasUsed:          h.GetGasUsed(),
 		Time:             h.GetTimestamp(),
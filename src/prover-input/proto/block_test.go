@@ -10,6 +10,7 @@ import (
 	"github.com/kkrt-labs/go-utils/common"
 	input "github.com/kkrt-labs/zk-pig/src/prover-input"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBlock(t *testing.T) {
@@ -30,7 +31,7 @@ func TestBlock(t *testing.T) {
 		{
 			desc: "block with zero values",
 			block: &input.Block{
-				Header:       &gethtypes.Header{},
+				Header:       &gethtypes.Header{Difficulty: big.NewInt(0), Number: big.NewInt(0)},
 				Transactions: []*gethtypes.Transaction{},
 				Uncles:       []*gethtypes.Header{},
 				Withdrawals:  []*gethtypes.Withdrawal{},
@@ -40,13 +41,14 @@ func TestBlock(t *testing.T) {
 			desc: "block with non-zero values",
 			block: &input.Block{
 				Header: &gethtypes.Header{
-					Number: big.NewInt(1),
+					Difficulty: big.NewInt(0),
+					Number:     big.NewInt(1),
 				},
 				Transactions: []*gethtypes.Transaction{
 					gethtypes.NewTx(&gethtypes.LegacyTx{}),
 				},
 				Uncles: []*gethtypes.Header{
-					{},
+					{Difficulty: big.NewInt(0), Number: big.NewInt(0)},
 				},
 				Withdrawals: []*gethtypes.Withdrawal{
 					{},
@@ -62,8 +64,10 @@ func TestBlock(t *testing.T) {
 					tx.SetTime(time.Unix(0, 0)) // this is necessary to make the test deterministic
 				}
 			}
-			protoBlock := BlockToProto(tc.block)
-			blockFromProto := BlockFromProto(protoBlock)
+			protoBlock, err := BlockToProto(tc.block)
+			require.NoError(t, err)
+			blockFromProto, err := BlockFromProto(protoBlock)
+			require.NoError(t, err)
 			assert.Equal(t, tc.block, blockFromProto)
 		})
 	}
@@ -81,8 +85,8 @@ func TestHeader(t *testing.T) {
 			header: nil,
 		},
 		{
-			desc:   "empty header",
-			header: &gethtypes.Header{},
+			desc:   "header with only mandatory fields",
+			header: &gethtypes.Header{Difficulty: big.NewInt(0), Number: big.NewInt(0)},
 		},
 		{
 			desc: "header with zeros",
@@ -1,6 +1,9 @@
 package proto
 
 import (
+	"bytes"
+	"sort"
+
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	input "github.com/kkrt-labs/zk-pig/src/prover-input"
 )
@@ -11,9 +14,15 @@ func ExtraToProto(extra *input.Extra) *Extra {
 	}
 
 	return &Extra{
-		AccessList: AccessListToProto(extra.AccessList),
-		StateDiffs: StateDiffsToProto(extra.StateDiffs),
-		Committed:  extra.Committed,
+		AccessList:   AccessListToProto(extra.AccessList),
+		StateDiffs:   StateDiffsToProto(extra.StateDiffs),
+		Committed:    extra.Committed,
+		PreState:     PreStateToProto(extra.PreState),
+		ProvingCost:  ProvingCostToProto(extra.ProvingCost),
+		Senders:      AddressesToProto(extra.Senders),
+		Receipts:     ReceiptsToProto(extra.Receipts),
+		TxStateDiffs: TxStateDiffsToProto(extra.TxStateDiffs),
+		Preimages:    PreimagesToProto(extra.Preimages),
 	}
 }
 
@@ -23,9 +32,15 @@ func ExtraFromProto(extra *Extra) *input.Extra {
 	}
 
 	return &input.Extra{
-		AccessList: AccessListFromProto(extra.AccessList),
-		StateDiffs: StateDiffsFromProto(extra.StateDiffs),
-		Committed:  extra.Committed,
+		AccessList:   AccessListFromProto(extra.AccessList),
+		StateDiffs:   StateDiffsFromProto(extra.StateDiffs),
+		Committed:    extra.Committed,
+		PreState:     PreStateFromProto(extra.PreState),
+		ProvingCost:  ProvingCostFromProto(extra.ProvingCost),
+		Senders:      AddressesFromProto(extra.Senders),
+		Receipts:     ReceiptsFromProto(extra.Receipts),
+		TxStateDiffs: TxStateDiffsFromProto(extra.TxStateDiffs),
+		Preimages:    PreimagesFromProto(extra.Preimages),
 	}
 }
 
@@ -127,6 +142,130 @@ func StorageDiffFromProto(protoStorageDiff *StorageDiff) *input.StorageDiff {
 	}
 }
 
+func TxStateDiffsToProto(txStateDiffs []*input.TxStateDiff) []*TxStateDiff {
+	if txStateDiffs == nil {
+		return nil
+	}
+
+	protoTxStateDiffs := make([]*TxStateDiff, len(txStateDiffs))
+	for i, txStateDiff := range txStateDiffs {
+		protoTxStateDiffs[i] = TxStateDiffToProto(txStateDiff)
+	}
+	return protoTxStateDiffs
+}
+
+func TxStateDiffsFromProto(protoTxStateDiffs []*TxStateDiff) []*input.TxStateDiff {
+	if protoTxStateDiffs == nil {
+		return nil
+	}
+
+	txStateDiffs := make([]*input.TxStateDiff, len(protoTxStateDiffs))
+	for i, protoTxStateDiff := range protoTxStateDiffs {
+		txStateDiffs[i] = TxStateDiffFromProto(protoTxStateDiff)
+	}
+	return txStateDiffs
+}
+
+func TxStateDiffToProto(txStateDiff *input.TxStateDiff) *TxStateDiff {
+	if txStateDiff == nil {
+		return nil
+	}
+
+	protoTxStateDiff := &TxStateDiff{
+		BlockNumber:   txStateDiff.BlockNumber,
+		Type:          string(txStateDiff.Type),
+		TxIndex:       txStateDiff.TxIndex,
+		SystemAddress: addrToBytes(txStateDiff.SystemAddress),
+		Accounts:      AccountDiffsToProto(txStateDiff.Accounts),
+	}
+	if txStateDiff.TxHash != nil {
+		protoTxStateDiff.TxHash = txStateDiff.TxHash.Bytes()
+	}
+	return protoTxStateDiff
+}
+
+func TxStateDiffFromProto(protoTxStateDiff *TxStateDiff) *input.TxStateDiff {
+	if protoTxStateDiff == nil {
+		return nil
+	}
+
+	txStateDiff := &input.TxStateDiff{
+		BlockNumber: protoTxStateDiff.BlockNumber,
+		Type:        input.TxStateDiffType(protoTxStateDiff.Type),
+		TxIndex:     protoTxStateDiff.TxIndex,
+		TxHash:      bytesToHashPtr(protoTxStateDiff.TxHash),
+		Accounts:    AccountDiffsFromProto(protoTxStateDiff.Accounts),
+	}
+	if protoTxStateDiff.SystemAddress != nil {
+		addr := gethcommon.BytesToAddress(protoTxStateDiff.SystemAddress)
+		txStateDiff.SystemAddress = &addr
+	}
+	return txStateDiff
+}
+
+func AccountDiffsToProto(accountDiffs []*input.AccountDiff) []*AccountDiff {
+	if accountDiffs == nil {
+		return nil
+	}
+
+	protoAccountDiffs := make([]*AccountDiff, len(accountDiffs))
+	for i, accountDiff := range accountDiffs {
+		protoAccountDiffs[i] = AccountDiffToProto(accountDiff)
+	}
+	return protoAccountDiffs
+}
+
+func AccountDiffsFromProto(protoAccountDiffs []*AccountDiff) []*input.AccountDiff {
+	if protoAccountDiffs == nil {
+		return nil
+	}
+
+	accountDiffs := make([]*input.AccountDiff, len(protoAccountDiffs))
+	for i, protoAccountDiff := range protoAccountDiffs {
+		accountDiffs[i] = AccountDiffFromProto(protoAccountDiff)
+	}
+	return accountDiffs
+}
+
+func AccountDiffToProto(accountDiff *input.AccountDiff) *AccountDiff {
+	if accountDiff == nil {
+		return nil
+	}
+
+	protoAccountDiff := &AccountDiff{
+		Address:     accountDiff.Address.Bytes(),
+		PreBalance:  bigIntToBytes(accountDiff.PreBalance),
+		PostBalance: bigIntToBytes(accountDiff.PostBalance),
+		PreNonce:    accountDiff.PreNonce,
+		PostNonce:   accountDiff.PostNonce,
+		Storage:     StorageDiffsToProto(accountDiff.Storage),
+	}
+	if accountDiff.PreCodeHash != nil {
+		protoAccountDiff.PreCodeHash = accountDiff.PreCodeHash.Bytes()
+	}
+	if accountDiff.PostCodeHash != nil {
+		protoAccountDiff.PostCodeHash = accountDiff.PostCodeHash.Bytes()
+	}
+	return protoAccountDiff
+}
+
+func AccountDiffFromProto(protoAccountDiff *AccountDiff) *input.AccountDiff {
+	if protoAccountDiff == nil {
+		return nil
+	}
+
+	return &input.AccountDiff{
+		Address:      gethcommon.BytesToAddress(protoAccountDiff.Address),
+		PreBalance:   bytesToBigInt(protoAccountDiff.PreBalance),
+		PostBalance:  bytesToBigInt(protoAccountDiff.PostBalance),
+		PreNonce:     protoAccountDiff.PreNonce,
+		PostNonce:    protoAccountDiff.PostNonce,
+		PreCodeHash:  bytesToHashPtr(protoAccountDiff.PreCodeHash),
+		PostCodeHash: bytesToHashPtr(protoAccountDiff.PostCodeHash),
+		Storage:      StorageDiffsFromProto(protoAccountDiff.Storage),
+	}
+}
+
 func AccountToProto(account *input.Account) *Account {
 	if account == nil {
 		return nil
@@ -152,3 +291,246 @@ func AccountFromProto(account *Account) *input.Account {
 		StorageHash: gethcommon.BytesToHash(account.StorageHash),
 	}
 }
+
+// PreStateToProto converts the pre-state to protobuf format, with accounts sorted by address
+func PreStateToProto(preState map[gethcommon.Address]*input.AccountState) []*PreStateAccount {
+	if preState == nil {
+		return nil
+	}
+
+	protoPreState := make([]*PreStateAccount, 0, len(preState))
+	for addr, state := range preState {
+		protoPreState = append(protoPreState, &PreStateAccount{
+			Address: addr.Bytes(),
+			State:   AccountStateToProto(state),
+		})
+	}
+	sort.Slice(protoPreState, func(i, j int) bool {
+		return bytes.Compare(protoPreState[i].Address, protoPreState[j].Address) < 0
+	})
+	return protoPreState
+}
+
+func PreStateFromProto(protoPreState []*PreStateAccount) map[gethcommon.Address]*input.AccountState {
+	if protoPreState == nil {
+		return nil
+	}
+
+	preState := make(map[gethcommon.Address]*input.AccountState, len(protoPreState))
+	for _, account := range protoPreState {
+		preState[gethcommon.BytesToAddress(account.GetAddress())] = AccountStateFromProto(account.GetState())
+	}
+	return preState
+}
+
+func AccountStateToProto(state *input.AccountState) *AccountState {
+	if state == nil {
+		return nil
+	}
+
+	return &AccountState{
+		Balance:     bigIntToBytes(state.Balance),
+		CodeHash:    state.CodeHash.Bytes(),
+		Code:        state.Code,
+		Nonce:       state.Nonce,
+		StorageHash: state.StorageHash.Bytes(),
+		Storage:     StorageToProto(state.Storage),
+	}
+}
+
+func AccountStateFromProto(state *AccountState) *input.AccountState {
+	if state == nil {
+		return nil
+	}
+
+	return &input.AccountState{
+		Balance:     bytesToBigInt(state.GetBalance()),
+		CodeHash:    gethcommon.BytesToHash(state.GetCodeHash()),
+		Code:        state.GetCode(),
+		Nonce:       state.GetNonce(),
+		StorageHash: gethcommon.BytesToHash(state.GetStorageHash()),
+		Storage:     StorageFromProto(state.GetStorage()),
+	}
+}
+
+// StorageToProto converts account storage to protobuf format, with entries sorted by slot
+func StorageToProto(storage map[gethcommon.Hash]gethcommon.Hash) []*StorageEntry {
+	if storage == nil {
+		return nil
+	}
+
+	entries := make([]*StorageEntry, 0, len(storage))
+	for slot, value := range storage {
+		entries = append(entries, &StorageEntry{
+			Slot:  slot.Bytes(),
+			Value: value.Bytes(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].Slot, entries[j].Slot) < 0
+	})
+	return entries
+}
+
+func StorageFromProto(entries []*StorageEntry) map[gethcommon.Hash]gethcommon.Hash {
+	if entries == nil {
+		return nil
+	}
+
+	storage := make(map[gethcommon.Hash]gethcommon.Hash, len(entries))
+	for _, entry := range entries {
+		storage[gethcommon.BytesToHash(entry.GetSlot())] = gethcommon.BytesToHash(entry.GetValue())
+	}
+	return storage
+}
+
+// ProvingCostToProto converts the proving cost to protobuf format, with opcodes sorted by name and precompiles sorted by address
+func ProvingCostToProto(cost *input.ProvingCost) *ProvingCost {
+	if cost == nil {
+		return nil
+	}
+
+	protoCost := &ProvingCost{
+		Cycles:       cost.Cycles,
+		KeccakBytes:  cost.KeccakBytes,
+		WitnessNodes: cost.WitnessNodes,
+	}
+
+	if cost.Opcodes != nil {
+		protoCost.Opcodes = make([]*OpcodeCount, 0, len(cost.Opcodes))
+		for op, count := range cost.Opcodes {
+			protoCost.Opcodes = append(protoCost.Opcodes, &OpcodeCount{Opcode: op, Count: count})
+		}
+		sort.Slice(protoCost.Opcodes, func(i, j int) bool {
+			return protoCost.Opcodes[i].Opcode < protoCost.Opcodes[j].Opcode
+		})
+	}
+
+	if cost.Precompiles != nil {
+		protoCost.Precompiles = make([]*PrecompileUsage, 0, len(cost.Precompiles))
+		for addr, usage := range cost.Precompiles {
+			protoCost.Precompiles = append(protoCost.Precompiles, &PrecompileUsage{
+				Address:    addr.Bytes(),
+				Calls:      usage.Calls,
+				InputBytes: usage.InputBytes,
+			})
+		}
+		sort.Slice(protoCost.Precompiles, func(i, j int) bool {
+			return bytes.Compare(protoCost.Precompiles[i].Address, protoCost.Precompiles[j].Address) < 0
+		})
+	}
+
+	return protoCost
+}
+
+func ProvingCostFromProto(protoCost *ProvingCost) *input.ProvingCost {
+	if protoCost == nil {
+		return nil
+	}
+
+	cost := &input.ProvingCost{
+		Cycles:       protoCost.GetCycles(),
+		KeccakBytes:  protoCost.GetKeccakBytes(),
+		WitnessNodes: protoCost.GetWitnessNodes(),
+	}
+
+	if protoCost.Opcodes != nil {
+		cost.Opcodes = make(map[string]uint64, len(protoCost.Opcodes))
+		for _, op := range protoCost.Opcodes {
+			cost.Opcodes[op.GetOpcode()] = op.GetCount()
+		}
+	}
+
+	if protoCost.Precompiles != nil {
+		cost.Precompiles = make(map[gethcommon.Address]*input.PrecompileUsage, len(protoCost.Precompiles))
+		for _, usage := range protoCost.Precompiles {
+			cost.Precompiles[gethcommon.BytesToAddress(usage.GetAddress())] = &input.PrecompileUsage{
+				Calls:      usage.GetCalls(),
+				InputBytes: usage.GetInputBytes(),
+			}
+		}
+	}
+
+	return cost
+}
+
+func AddressesToProto(addrs []gethcommon.Address) [][]byte {
+	if addrs == nil {
+		return nil
+	}
+
+	protoAddrs := make([][]byte, len(addrs))
+	for i, addr := range addrs {
+		protoAddrs[i] = addr.Bytes()
+	}
+	return protoAddrs
+}
+
+func AddressesFromProto(protoAddrs [][]byte) []gethcommon.Address {
+	if protoAddrs == nil {
+		return nil
+	}
+
+	addrs := make([]gethcommon.Address, len(protoAddrs))
+	for i, addr := range protoAddrs {
+		addrs[i] = gethcommon.BytesToAddress(addr)
+	}
+	return addrs
+}
+
+// PreimagesToProto converts the preimages to protobuf format, with entries sorted by preimage
+func PreimagesToProto(preimages *input.Preimages) *Preimages {
+	if preimages == nil {
+		return nil
+	}
+
+	protoPreimages := new(Preimages)
+
+	if preimages.Accounts != nil {
+		protoPreimages.Accounts = make([]*Preimage, 0, len(preimages.Accounts))
+		for addr, key := range preimages.Accounts {
+			protoPreimages.Accounts = append(protoPreimages.Accounts, &Preimage{Preimage: addr.Bytes(), Key: key.Bytes()})
+		}
+		sortPreimages(protoPreimages.Accounts)
+	}
+
+	if preimages.Slots != nil {
+		protoPreimages.Slots = make([]*Preimage, 0, len(preimages.Slots))
+		for slot, key := range preimages.Slots {
+			protoPreimages.Slots = append(protoPreimages.Slots, &Preimage{Preimage: slot.Bytes(), Key: key.Bytes()})
+		}
+		sortPreimages(protoPreimages.Slots)
+	}
+
+	return protoPreimages
+}
+
+func PreimagesFromProto(protoPreimages *Preimages) *input.Preimages {
+	if protoPreimages == nil {
+		return nil
+	}
+
+	preimages := new(input.Preimages)
+
+	if protoPreimages.Accounts != nil {
+		preimages.Accounts = make(map[gethcommon.Address]gethcommon.Hash, len(protoPreimages.Accounts))
+		for _, preimage := range protoPreimages.Accounts {
+			preimages.Accounts[gethcommon.BytesToAddress(preimage.GetPreimage())] = gethcommon.BytesToHash(preimage.GetKey())
+		}
+	}
+
+	if protoPreimages.Slots != nil {
+		preimages.Slots = make(map[gethcommon.Hash]gethcommon.Hash, len(protoPreimages.Slots))
+		for _, preimage := range protoPreimages.Slots {
+			preimages.Slots[gethcommon.BytesToHash(preimage.GetPreimage())] = gethcommon.BytesToHash(preimage.GetKey())
+		}
+	}
+
+	return preimages
+}
+
+func sortPreimages(preimages []*Preimage) {
+	sort.Slice(preimages, func(i, j int) bool {
+		return bytes.Compare(preimages[i].Preimage, preimages[j].Preimage) < 0
+	})
+}
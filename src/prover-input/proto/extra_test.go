@@ -6,6 +6,7 @@ import (
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/kkrt-labs/go-utils/common"
 	input "github.com/kkrt-labs/zk-pig/src/prover-input"
 	"github.com/stretchr/testify/assert"
 )
@@ -37,6 +38,58 @@ func TestExtra(t *testing.T) {
 					},
 				},
 				Committed: [][]byte{gethcommon.HexToHash("0x456").Bytes()},
+				PreState: map[gethcommon.Address]*input.AccountState{
+					gethcommon.HexToAddress("0x123"): {
+						Balance:     big.NewInt(100),
+						CodeHash:    gethcommon.HexToHash("0x789"),
+						Code:        []byte{0x60, 0x00},
+						Nonce:       1,
+						StorageHash: gethcommon.HexToHash("0xabc"),
+						Storage: map[gethcommon.Hash]gethcommon.Hash{
+							gethcommon.HexToHash("0x1"): gethcommon.HexToHash("0x2"),
+							gethcommon.HexToHash("0x3"): gethcommon.HexToHash("0x4"),
+						},
+					},
+					gethcommon.HexToAddress("0x456"): nil,
+				},
+				ProvingCost: &input.ProvingCost{
+					Cycles:  1_000_000,
+					Opcodes: map[string]uint64{"PUSH1": 10, "SLOAD": 2, "KECCAK256": 1},
+					Precompiles: map[gethcommon.Address]*input.PrecompileUsage{
+						gethcommon.HexToAddress("0x1"): {Calls: 1, InputBytes: 128},
+						gethcommon.HexToAddress("0x2"): {Calls: 2, InputBytes: 64},
+					},
+					KeccakBytes:  64,
+					WitnessNodes: 12,
+				},
+				Senders: []gethcommon.Address{gethcommon.HexToAddress("0xa"), gethcommon.HexToAddress("0xb")},
+				Receipts: []*gethtypes.Receipt{
+					{
+						Type:              gethtypes.DynamicFeeTxType,
+						Status:            gethtypes.ReceiptStatusSuccessful,
+						CumulativeGasUsed: 21000,
+						Logs:              []*gethtypes.Log{},
+						TxHash:            gethcommon.HexToHash("0x789"),
+						GasUsed:           21000,
+						EffectiveGasPrice: big.NewInt(10),
+						BlockNumber:       big.NewInt(1),
+					},
+				},
+				TxStateDiffs: []*input.TxStateDiff{
+					{
+						BlockNumber: 1,
+						Type:        input.TxStateDiffTypeTransaction,
+						TxIndex:     common.Ptr(uint64(0)),
+						TxHash:      common.Ptr(gethcommon.HexToHash("0x789")),
+						Accounts: []*input.AccountDiff{
+							{Address: gethcommon.HexToAddress("0x123"), PreBalance: big.NewInt(100), PostBalance: big.NewInt(79)},
+						},
+					},
+				},
+				Preimages: &input.Preimages{
+					Accounts: map[gethcommon.Address]gethcommon.Hash{gethcommon.HexToAddress("0x123"): gethcommon.HexToHash("0xabc")},
+					Slots:    map[gethcommon.Hash]gethcommon.Hash{gethcommon.HexToHash("0x1"): gethcommon.HexToHash("0xdef")},
+				},
 			},
 		},
 		{
@@ -45,6 +98,18 @@ func TestExtra(t *testing.T) {
 				AccessList: []gethtypes.AccessTuple{},
 				StateDiffs: []*input.StateDiff{},
 				Committed:  [][]byte{},
+				PreState:   map[gethcommon.Address]*input.AccountState{},
+				ProvingCost: &input.ProvingCost{
+					Opcodes:     map[string]uint64{},
+					Precompiles: map[gethcommon.Address]*input.PrecompileUsage{},
+				},
+				Senders:      []gethcommon.Address{},
+				Receipts:     []*gethtypes.Receipt{},
+				TxStateDiffs: []*input.TxStateDiff{},
+				Preimages: &input.Preimages{
+					Accounts: map[gethcommon.Address]gethcommon.Hash{},
+					Slots:    map[gethcommon.Hash]gethcommon.Hash{},
+				},
 			},
 		},
 	}
@@ -154,3 +219,67 @@ func TestAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestTxStateDiff(t *testing.T) {
+	var testCases = []struct {
+		desc  string
+		input *input.TxStateDiff
+	}{
+		{
+			desc:  "nil tx state diff",
+			input: nil,
+		},
+		{
+			desc: "transaction state diff",
+			input: &input.TxStateDiff{
+				BlockNumber: 10,
+				Type:        input.TxStateDiffTypeTransaction,
+				TxIndex:     common.Ptr(uint64(2)),
+				TxHash:      common.Ptr(gethcommon.HexToHash("0xabc")),
+				Accounts: []*input.AccountDiff{
+					{
+						Address:      gethcommon.HexToAddress("0x123"),
+						PreBalance:   big.NewInt(100),
+						PostBalance:  big.NewInt(0),
+						PreNonce:     common.Ptr(uint64(0)),
+						PostNonce:    common.Ptr(uint64(1)),
+						PreCodeHash:  common.Ptr(gethtypes.EmptyCodeHash),
+						PostCodeHash: common.Ptr(gethcommon.HexToHash("0xdef")),
+						Storage: []*input.StorageDiff{
+							{Slot: gethcommon.HexToHash("0x1"), PreValue: gethcommon.HexToHash("0x0"), PostValue: gethcommon.HexToHash("0x2")},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "system call state diff",
+			input: &input.TxStateDiff{
+				BlockNumber:   10,
+				Type:          input.TxStateDiffTypeSystemCall,
+				SystemAddress: common.Ptr(gethcommon.HexToAddress("0x000F3df6D732807Ef1319fB7B8bB8522d0Beac02")),
+				Accounts: []*input.AccountDiff{
+					{
+						Address: gethcommon.HexToAddress("0x000F3df6D732807Ef1319fB7B8bB8522d0Beac02"),
+						Storage: []*input.StorageDiff{{Slot: gethcommon.HexToHash("0x1"), PostValue: gethcommon.HexToHash("0x2")}},
+					},
+				},
+			},
+		},
+		{
+			desc: "tx state diff with empty fields",
+			input: &input.TxStateDiff{
+				Type:     input.TxStateDiffTypeWithdrawals,
+				Accounts: []*input.AccountDiff{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			protoTxStateDiff := TxStateDiffToProto(tc.input)
+			txStateDiffFromProto := TxStateDiffFromProto(protoTxStateDiff)
+			assert.Equal(t, tc.input, txStateDiffFromProto)
+		})
+	}
+}
@@ -5,32 +5,43 @@ import (
 )
 
 // ToProto converts Go input.ProverInput to protobuf format
-func ToProto(pi *input.ProverInput) *ProverInput {
+func ToProto(pi *input.ProverInput) (*ProverInput, error) {
 	if pi == nil {
-		return nil
+		return nil, nil
+	}
+
+	blocks, err := BlocksToProto(pi.Blocks)
+	if err != nil {
+		return nil, err
 	}
 
 	return &ProverInput{
 		Version:     pi.Version,
-		Blocks:      BlocksToProto(pi.Blocks),
+		Blocks:      blocks,
 		Witness:     WitnessToProto(pi.Witness),
 		ChainConfig: ChainConfigToProto(pi.ChainConfig),
 		Extra:       ExtraToProto(pi.Extra),
-	}
+	}, nil
 }
 
-func FromProto(pi *ProverInput) *input.ProverInput {
+// FromProto converts protobuf ProverInput to Go input.ProverInput format
+func FromProto(pi *ProverInput) (*input.ProverInput, error) {
 	if pi == nil {
-		return nil
+		return nil, nil
+	}
+
+	blocks, err := BlocksFromProto(pi.Blocks)
+	if err != nil {
+		return nil, err
 	}
 
 	return &input.ProverInput{
 		Version:     pi.Version,
-		Blocks:      BlocksFromProto(pi.Blocks),
+		Blocks:      blocks,
 		Witness:     WitnessFromProto(pi.Witness),
 		ChainConfig: ChainConfigFromProto(pi.ChainConfig),
 		Extra:       ExtraFromProto(pi.Extra),
-	}
+	}, nil
 }
 
 func WitnessToProto(w *input.Witness) *Witness {
@@ -1,12 +1,20 @@
 package proto
 
 import (
+	"encoding/json"
+	"math/big"
 	"testing"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/kkrt-labs/go-utils/common"
 	input "github.com/kkrt-labs/zk-pig/src/prover-input"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestInput(t *testing.T) {
@@ -35,8 +43,10 @@ func TestInput(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
-			protoInput := ToProto(tc.input)
-			inputFromProto := FromProto(protoInput)
+			protoInput, err := ToProto(tc.input)
+			require.NoError(t, err)
+			inputFromProto, err := FromProto(protoInput)
+			require.NoError(t, err)
 			assert.Equal(t, tc.input, inputFromProto)
 		})
 	}
@@ -81,3 +91,242 @@ func TestWitness(t *testing.T) {
 		})
 	}
 }
+
+func testProverInput(t *testing.T) *input.ProverInput {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := gethtypes.LatestSignerForChainID(params.MainnetChainConfig.ChainID)
+	signTx := func(txData gethtypes.TxData) *gethtypes.Transaction {
+		tx, err := gethtypes.SignNewTx(key, signer, txData)
+		require.NoError(t, err)
+		return tx
+	}
+
+	to := gethcommon.HexToAddress("0x1")
+	accessList := gethtypes.AccessList{{Address: to, StorageKeys: []gethcommon.Hash{gethcommon.HexToHash("0x2")}}}
+	auth, err := gethtypes.SignSetCode(key, gethtypes.SetCodeAuthorization{
+		ChainID: *uint256.NewInt(1),
+		Address: to,
+		Nonce:   4,
+	})
+	require.NoError(t, err)
+
+	baseFee, blobGasUsed, excessBlobGas := big.NewInt(7), uint64(131072), uint64(0)
+	withdrawalsHash, beaconRoot, requestsHash := gethcommon.HexToHash("0xa"), gethcommon.HexToHash("0xb"), gethcommon.HexToHash("0xc")
+	header := &gethtypes.Header{
+		ParentHash:       gethcommon.HexToHash("0x3"),
+		UncleHash:        gethtypes.EmptyUncleHash,
+		Coinbase:         gethcommon.HexToAddress("0x4"),
+		Root:             gethcommon.HexToHash("0x5"),
+		TxHash:           gethcommon.HexToHash("0x6"),
+		ReceiptHash:      gethcommon.HexToHash("0x7"),
+		Difficulty:       big.NewInt(0),
+		Number:           big.NewInt(21000000),
+		GasLimit:         30000000,
+		GasUsed:          100000,
+		Time:             1700000000,
+		Extra:            []byte("extra"),
+		MixDigest:        gethcommon.HexToHash("0x8"),
+		BaseFee:          baseFee,
+		WithdrawalsHash:  &withdrawalsHash,
+		BlobGasUsed:      &blobGasUsed,
+		ExcessBlobGas:    &excessBlobGas,
+		ParentBeaconRoot: &beaconRoot,
+		RequestsHash:     &requestsHash,
+	}
+	parent := &gethtypes.Header{
+		ParentHash: gethcommon.HexToHash("0x9"),
+		UncleHash:  gethtypes.EmptyUncleHash,
+		Root:       gethcommon.HexToHash("0xd"),
+		Difficulty: big.NewInt(0),
+		Number:     big.NewInt(20999999),
+		BaseFee:    big.NewInt(8),
+	}
+
+	return &input.ProverInput{
+		Version: "1",
+		Blocks: []*input.Block{
+			{
+				Header: header,
+				Transactions: []*gethtypes.Transaction{
+					signTx(&gethtypes.LegacyTx{Nonce: 0, GasPrice: big.NewInt(10), Gas: 21000, To: &to, Value: big.NewInt(1)}),
+					signTx(&gethtypes.LegacyTx{Nonce: 1, GasPrice: big.NewInt(10), Gas: 60000, Data: []byte{0x60, 0x00}}),
+					signTx(&gethtypes.AccessListTx{ChainID: big.NewInt(1), Nonce: 2, GasPrice: big.NewInt(10), Gas: 30000, To: &to, AccessList: accessList}),
+					signTx(&gethtypes.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 3, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(10), Gas: 21000, To: &to, Value: big.NewInt(2)}),
+					signTx(&gethtypes.BlobTx{
+						ChainID: uint256.NewInt(1), Nonce: 4, GasTipCap: uint256.NewInt(1), GasFeeCap: uint256.NewInt(10), Gas: 21000, To: to,
+						Value: uint256.NewInt(0), BlobFeeCap: uint256.NewInt(3), BlobHashes: []gethcommon.Hash{gethcommon.HexToHash("0x01e")},
+					}),
+					signTx(&gethtypes.SetCodeTx{
+						ChainID: uint256.NewInt(1), Nonce: 5, GasTipCap: uint256.NewInt(1), GasFeeCap: uint256.NewInt(10), Gas: 50000, To: to,
+						Value: uint256.NewInt(0), AccessList: accessList, AuthList: []gethtypes.SetCodeAuthorization{auth},
+					}),
+				},
+				Uncles: []*gethtypes.Header{},
+				Withdrawals: []*gethtypes.Withdrawal{
+					{Index: 1, Validator: 2, Address: gethcommon.HexToAddress("0xe"), Amount: 3},
+				},
+			},
+		},
+		Witness: &input.Witness{
+			Ancestors: []*gethtypes.Header{parent},
+			State:     [][]byte{{0x01, 0x02}, {0x03}},
+			Codes:     [][]byte{{0x60, 0x00}},
+		},
+		ChainConfig: params.MainnetChainConfig,
+		Extra: &input.Extra{
+			AccessList: accessList,
+			Committed:  [][]byte{{0x04}},
+			StateDiffs: []*input.StateDiff{
+				{
+					Address:     to,
+					PreAccount:  &input.Account{Balance: big.NewInt(1), CodeHash: gethtypes.EmptyCodeHash, StorageHash: gethtypes.EmptyRootHash},
+					PostAccount: &input.Account{Balance: big.NewInt(2), CodeHash: gethtypes.EmptyCodeHash, Nonce: 1, StorageHash: gethtypes.EmptyRootHash},
+					Storage: []*input.StorageDiff{
+						{Slot: gethcommon.HexToHash("0x2"), PreValue: gethcommon.HexToHash("0x0"), PostValue: gethcommon.HexToHash("0x1")},
+					},
+				},
+			},
+			PreState: map[gethcommon.Address]*input.AccountState{
+				to: {
+					Balance:     big.NewInt(1),
+					CodeHash:    gethcommon.HexToHash("0xf"),
+					Code:        []byte{0x60, 0x00},
+					Nonce:       0,
+					StorageHash: gethcommon.HexToHash("0x10"),
+					Storage:     map[gethcommon.Hash]gethcommon.Hash{gethcommon.HexToHash("0x2"): gethcommon.HexToHash("0x0")},
+				},
+				gethcommon.HexToAddress("0x11"): nil,
+			},
+			ProvingCost: &input.ProvingCost{
+				Cycles:       42_000,
+				Opcodes:      map[string]uint64{"PUSH1": 2, "STOP": 1},
+				Precompiles:  map[gethcommon.Address]*input.PrecompileUsage{gethcommon.HexToAddress("0x1"): {Calls: 1, InputBytes: 128}},
+				KeccakBytes:  32,
+				WitnessNodes: 3,
+			},
+			Senders: []gethcommon.Address{gethcommon.HexToAddress("0xa")},
+			Receipts: []*gethtypes.Receipt{
+				{
+					Type:              gethtypes.DynamicFeeTxType,
+					Status:            gethtypes.ReceiptStatusSuccessful,
+					CumulativeGasUsed: 21000,
+					Logs: []*gethtypes.Log{
+						{Address: to, Topics: []gethcommon.Hash{gethcommon.HexToHash("0x12")}, Data: []byte{0x13}, BlockNumber: 21000000, TxIndex: 3, Index: 0},
+					},
+					TxHash:            gethcommon.HexToHash("0x14"),
+					GasUsed:           21000,
+					EffectiveGasPrice: big.NewInt(8),
+					BlockHash:         gethcommon.HexToHash("0x15"),
+					BlockNumber:       big.NewInt(21000000),
+					TransactionIndex:  3,
+				},
+			},
+			TxStateDiffs: []*input.TxStateDiff{
+				{
+					BlockNumber:   21000000,
+					Type:          input.TxStateDiffTypeSystemCall,
+					SystemAddress: &params.BeaconRootsAddress,
+					Accounts: []*input.AccountDiff{
+						{Address: params.BeaconRootsAddress, Storage: []*input.StorageDiff{{Slot: gethcommon.HexToHash("0x16"), PostValue: gethcommon.HexToHash("0x17")}}},
+					},
+				},
+				{
+					BlockNumber: 21000000,
+					Type:        input.TxStateDiffTypeTransaction,
+					TxIndex:     common.Ptr(uint64(0)),
+					TxHash:      common.Ptr(gethcommon.HexToHash("0x14")),
+					Accounts: []*input.AccountDiff{
+						{
+							Address:      to,
+							PreBalance:   big.NewInt(1),
+							PostBalance:  big.NewInt(2),
+							PreNonce:     common.Ptr(uint64(0)),
+							PostNonce:    common.Ptr(uint64(1)),
+							PreCodeHash:  common.Ptr(gethtypes.EmptyCodeHash),
+							PostCodeHash: common.Ptr(gethcommon.HexToHash("0x18")),
+						},
+					},
+				},
+				{
+					BlockNumber: 21000000,
+					Type:        input.TxStateDiffTypeWithdrawals,
+					Accounts: []*input.AccountDiff{
+						{Address: gethcommon.HexToAddress("0xe"), PreBalance: big.NewInt(5), PostBalance: big.NewInt(3000000005)},
+					},
+				},
+			},
+			Preimages: &input.Preimages{
+				Accounts: map[gethcommon.Address]gethcommon.Hash{to: crypto.Keccak256Hash(to.Bytes())},
+				Slots:    map[gethcommon.Hash]gethcommon.Hash{gethcommon.HexToHash("0x2"): crypto.Keccak256Hash(gethcommon.HexToHash("0x2").Bytes())},
+			},
+		},
+	}
+}
+
+// TestJSONProtoRoundTrip asserts that the JSON and protobuf encodings of the same prover input decode to identical values
+func TestJSONProtoRoundTrip(t *testing.T) {
+	in := testProverInput(t)
+
+	// JSON round-trip
+	jsonBytes, err := json.Marshal(in)
+	require.NoError(t, err)
+	fromJSON := new(input.ProverInput)
+	require.NoError(t, json.Unmarshal(jsonBytes, fromJSON))
+
+	// Protobuf round-trip
+	protoMsg, err := ToProto(in)
+	require.NoError(t, err)
+	protoBytes, err := proto.Marshal(protoMsg)
+	require.NoError(t, err)
+	decodedProtoMsg := new(ProverInput)
+	require.NoError(t, proto.Unmarshal(protoBytes, decodedProtoMsg))
+	fromProto, err := FromProto(decodedProtoMsg)
+	require.NoError(t, err)
+
+	assert.Equal(t, fromJSON.Version, fromProto.Version)
+	assert.Equal(t, fromJSON.ChainConfig, fromProto.ChainConfig)
+
+	require.Len(t, fromProto.Blocks, len(fromJSON.Blocks))
+	for i, block := range fromJSON.Blocks {
+		assert.Equal(t, normalizeJSONHeader(block.Header), fromProto.Blocks[i].Header)
+		assert.Equal(t, txHashes(block.Transactions), txHashes(fromProto.Blocks[i].Transactions))
+		assert.ElementsMatch(t, block.Uncles, fromProto.Blocks[i].Uncles) // post-merge blocks have no uncles, which protobuf decodes as nil
+		assert.Equal(t, block.Withdrawals, fromProto.Blocks[i].Withdrawals)
+	}
+
+	for _, h := range fromJSON.Witness.Ancestors {
+		normalizeJSONHeader(h)
+	}
+	assert.Equal(t, fromJSON.Witness, fromProto.Witness)
+
+	// JSON decoding sets an empty post-state on post-Byzantium receipts
+	for _, r := range fromJSON.Extra.Receipts {
+		if len(r.PostState) == 0 {
+			r.PostState = nil
+		}
+	}
+	assert.Equal(t, fromJSON.Extra, fromProto.Extra)
+}
+
+func txHashes(txs []*gethtypes.Transaction) []gethcommon.Hash {
+	hashes := make([]gethcommon.Hash, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash()
+	}
+	return hashes
+}
+
+// normalizeJSONHeader rewrites the values JSON decoding represents differently from the equivalent Go values:
+// zero quantities are decoded with a non-nil empty word slice and empty extra data as a non-nil empty slice
+func normalizeJSONHeader(h *gethtypes.Header) *gethtypes.Header {
+	h.Difficulty = new(big.Int).Set(h.Difficulty)
+	h.Number = new(big.Int).Set(h.Number)
+	if h.BaseFee != nil {
+		h.BaseFee = new(big.Int).Set(h.BaseFee)
+	}
+	if len(h.Extra) == 0 {
+		h.Extra = nil
+	}
+	return h
+}
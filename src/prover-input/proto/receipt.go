@@ -0,0 +1,148 @@
+package proto
+
+import (
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+func ReceiptsToProto(receipts []*gethtypes.Receipt) []*Receipt {
+	if receipts == nil {
+		return nil
+	}
+
+	protoReceipts := make([]*Receipt, len(receipts))
+	for i, receipt := range receipts {
+		protoReceipts[i] = ReceiptToProto(receipt)
+	}
+	return protoReceipts
+}
+
+func ReceiptsFromProto(protoReceipts []*Receipt) []*gethtypes.Receipt {
+	if protoReceipts == nil {
+		return nil
+	}
+
+	receipts := make([]*gethtypes.Receipt, len(protoReceipts))
+	for i, protoReceipt := range protoReceipts {
+		receipts[i] = ReceiptFromProto(protoReceipt)
+	}
+	return receipts
+}
+
+func ReceiptToProto(receipt *gethtypes.Receipt) *Receipt {
+	if receipt == nil {
+		return nil
+	}
+
+	return &Receipt{
+		Type:              uint32(receipt.Type),
+		PostState:         receipt.PostState,
+		Status:            receipt.Status,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		LogsBloom:         receipt.Bloom.Bytes(),
+		Logs:              LogsToProto(receipt.Logs),
+		TransactionHash:   receipt.TxHash.Bytes(),
+		ContractAddress:   receipt.ContractAddress.Bytes(),
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: bigIntToBytes(receipt.EffectiveGasPrice),
+		BlobGasUsed:       receipt.BlobGasUsed,
+		BlobGasPrice:      bigIntToBytes(receipt.BlobGasPrice),
+		BlockHash:         receipt.BlockHash.Bytes(),
+		BlockNumber:       bigIntToBytes(receipt.BlockNumber),
+		TransactionIndex:  uint64(receipt.TransactionIndex),
+	}
+}
+
+func ReceiptFromProto(protoReceipt *Receipt) *gethtypes.Receipt {
+	if protoReceipt == nil {
+		return nil
+	}
+
+	return &gethtypes.Receipt{
+		Type:              uint8(protoReceipt.GetType()),
+		PostState:         protoReceipt.GetPostState(),
+		Status:            protoReceipt.GetStatus(),
+		CumulativeGasUsed: protoReceipt.GetCumulativeGasUsed(),
+		Bloom:             gethtypes.BytesToBloom(protoReceipt.GetLogsBloom()),
+		Logs:              LogsFromProto(protoReceipt.GetLogs()),
+		TxHash:            gethcommon.BytesToHash(protoReceipt.GetTransactionHash()),
+		ContractAddress:   gethcommon.BytesToAddress(protoReceipt.GetContractAddress()),
+		GasUsed:           protoReceipt.GetGasUsed(),
+		EffectiveGasPrice: bytesToBigInt(protoReceipt.EffectiveGasPrice),
+		BlobGasUsed:       protoReceipt.GetBlobGasUsed(),
+		BlobGasPrice:      bytesToBigInt(protoReceipt.BlobGasPrice),
+		BlockHash:         gethcommon.BytesToHash(protoReceipt.GetBlockHash()),
+		BlockNumber:       bytesToBigInt(protoReceipt.BlockNumber),
+		TransactionIndex:  uint(protoReceipt.GetTransactionIndex()),
+	}
+}
+
+func LogsToProto(logs []*gethtypes.Log) []*Log {
+	if logs == nil {
+		return nil
+	}
+
+	protoLogs := make([]*Log, len(logs))
+	for i, log := range logs {
+		protoLogs[i] = LogToProto(log)
+	}
+	return protoLogs
+}
+
+func LogsFromProto(protoLogs []*Log) []*gethtypes.Log {
+	if protoLogs == nil {
+		return nil
+	}
+
+	logs := make([]*gethtypes.Log, len(protoLogs))
+	for i, protoLog := range protoLogs {
+		logs[i] = LogFromProto(protoLog)
+	}
+	return logs
+}
+
+func LogToProto(log *gethtypes.Log) *Log {
+	if log == nil {
+		return nil
+	}
+
+	topics := make([][]byte, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = topic.Bytes()
+	}
+
+	return &Log{
+		Address:          log.Address.Bytes(),
+		Topics:           topics,
+		Data:             log.Data,
+		BlockNumber:      log.BlockNumber,
+		TransactionHash:  log.TxHash.Bytes(),
+		TransactionIndex: uint64(log.TxIndex),
+		BlockHash:        log.BlockHash.Bytes(),
+		Index:            uint64(log.Index),
+		Removed:          log.Removed,
+	}
+}
+
+func LogFromProto(protoLog *Log) *gethtypes.Log {
+	if protoLog == nil {
+		return nil
+	}
+
+	topics := make([]gethcommon.Hash, len(protoLog.GetTopics()))
+	for i, topic := range protoLog.GetTopics() {
+		topics[i] = gethcommon.BytesToHash(topic)
+	}
+
+	return &gethtypes.Log{
+		Address:     gethcommon.BytesToAddress(protoLog.GetAddress()),
+		Topics:      topics,
+		Data:        protoLog.GetData(),
+		BlockNumber: protoLog.GetBlockNumber(),
+		TxHash:      gethcommon.BytesToHash(protoLog.GetTransactionHash()),
+		TxIndex:     uint(protoLog.GetTransactionIndex()),
+		BlockHash:   gethcommon.BytesToHash(protoLog.GetBlockHash()),
+		Index:       uint(protoLog.GetIndex()),
+		Removed:     protoLog.GetRemoved(),
+	}
+}
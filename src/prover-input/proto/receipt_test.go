@@ -0,0 +1,81 @@
+package proto
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceipt(t *testing.T) {
+	var testCases = []struct {
+		desc    string
+		receipt *gethtypes.Receipt
+	}{
+		{
+			desc:    "nil receipt",
+			receipt: nil,
+		},
+		{
+			desc: "receipt with all fields set",
+			receipt: &gethtypes.Receipt{
+				Type:              gethtypes.BlobTxType,
+				PostState:         []byte{0x01},
+				Status:            gethtypes.ReceiptStatusSuccessful,
+				CumulativeGasUsed: 42000,
+				Bloom:             gethtypes.BytesToBloom([]byte{0x02}),
+				Logs: []*gethtypes.Log{
+					{
+						Address:     gethcommon.HexToAddress("0x123"),
+						Topics:      []gethcommon.Hash{gethcommon.HexToHash("0x456"), gethcommon.HexToHash("0x789")},
+						Data:        []byte{0x03, 0x04},
+						BlockNumber: 10,
+						TxHash:      gethcommon.HexToHash("0xabc"),
+						TxIndex:     1,
+						BlockHash:   gethcommon.HexToHash("0xdef"),
+						Index:       2,
+						Removed:     true,
+					},
+				},
+				TxHash:            gethcommon.HexToHash("0xabc"),
+				ContractAddress:   gethcommon.HexToAddress("0x321"),
+				GasUsed:           21000,
+				EffectiveGasPrice: big.NewInt(7),
+				BlobGasUsed:       131072,
+				BlobGasPrice:      big.NewInt(1),
+				BlockHash:         gethcommon.HexToHash("0xdef"),
+				BlockNumber:       big.NewInt(10),
+				TransactionIndex:  1,
+			},
+		},
+		{
+			desc: "receipt with nil fields",
+			receipt: &gethtypes.Receipt{
+				PostState:         nil,
+				Logs:              nil,
+				EffectiveGasPrice: nil,
+				BlobGasPrice:      nil,
+				BlockNumber:       nil,
+			},
+		},
+		{
+			desc: "receipt with empty fields",
+			receipt: &gethtypes.Receipt{
+				PostState: []byte{},
+				Logs: []*gethtypes.Log{
+					{Topics: []gethcommon.Hash{}, Data: []byte{}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			protoReceipt := ReceiptToProto(tc.receipt)
+			receiptFromProto := ReceiptFromProto(protoReceipt)
+			assert.Equal(t, tc.receipt, receiptFromProto)
+		})
+	}
+}
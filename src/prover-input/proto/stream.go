@@ -0,0 +1,122 @@
+package proto
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultStreamChunkSize is the default maximum size of the state nodes or codes held by a stream chunk
+const DefaultStreamChunkSize = 1 << 20
+
+// maxStreamMessageSize bounds the size of the messages read from a stream, so a corrupted size prefix can not cause a huge allocation
+const maxStreamMessageSize = 1 << 30
+
+// WriteStream writes a prover input to w as a stream (see stream.proto)
+//
+// State nodes and codes are written in chunks of at most chunkSize bytes (a node or code larger than chunkSize is written alone in a chunk),
+// so the memory used on top of the prover input is bounded by the chunk size.
+// The header is written as a single message: it holds the blocks, ancestors and all the extra data (including receipts
+// and per-transaction state diffs), so the memory used to encode it grows with those.
+func WriteStream(w io.Writer, pi *input.ProverInput, chunkSize int) error {
+	blocks, err := BlocksToProto(pi.Blocks)
+	if err != nil {
+		return err
+	}
+
+	header := &StreamHeader{
+		Version:     pi.Version,
+		Blocks:      blocks,
+		ChainConfig: ChainConfigToProto(pi.ChainConfig),
+		Extra:       ExtraToProto(pi.Extra),
+	}
+	var state, codes [][]byte
+	if pi.Witness != nil {
+		header.Ancestors = HeadersToProto(pi.Witness.Ancestors)
+		state, codes = pi.Witness.State, pi.Witness.Codes
+	}
+	header.StateCount, header.CodeCount = uint64(len(state)), uint64(len(codes))
+
+	bw := bufio.NewWriter(w)
+	opts := protodelim.MarshalOptions{MarshalOptions: proto.MarshalOptions{Deterministic: true}}
+	if _, err := opts.MarshalTo(bw, header); err != nil {
+		return fmt.Errorf("failed to write stream header: %w", err)
+	}
+	if err := writeChunks(bw, opts, state, chunkSize, func(items [][]byte) *StreamChunk { return &StreamChunk{State: items} }); err != nil {
+		return err
+	}
+	if err := writeChunks(bw, opts, codes, chunkSize, func(items [][]byte) *StreamChunk { return &StreamChunk{Codes: items} }); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func writeChunks(w io.Writer, opts protodelim.MarshalOptions, items [][]byte, chunkSize int, newChunk func([][]byte) *StreamChunk) error {
+	for start := 0; start < len(items); {
+		end, size := start+1, len(items[start])
+		for end < len(items) && size+len(items[end]) <= chunkSize {
+			size += len(items[end])
+			end++
+		}
+		if _, err := opts.MarshalTo(w, newChunk(items[start:end])); err != nil {
+			return fmt.Errorf("failed to write stream chunk: %w", err)
+		}
+		start = end
+	}
+	return nil
+}
+
+// ReadStream reads a prover input stream from r (see stream.proto)
+//
+// Messages are read one at a time, so the memory used on top of the prover input is bounded by the chunk size
+// (and by the header size, as the header is read as a single message).
+// Reading stops after the last chunk announced by the header, so r is not read past the end of the stream
+// if it implements io.ByteReader (otherwise r is buffered).
+func ReadStream(r io.Reader) (*input.ProverInput, error) {
+	br, ok := r.(protodelim.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	opts := protodelim.UnmarshalOptions{MaxSize: maxStreamMessageSize}
+
+	header := new(StreamHeader)
+	if err := opts.UnmarshalFrom(br, header); err != nil {
+		return nil, fmt.Errorf("failed to read stream header: %w", err)
+	}
+
+	blocks, err := BlocksFromProto(header.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	witness := &input.Witness{Ancestors: HeadersFromProto(header.Ancestors)}
+
+	for uint64(len(witness.State)) < header.StateCount || uint64(len(witness.Codes)) < header.CodeCount {
+		chunk := new(StreamChunk)
+		if err := opts.UnmarshalFrom(br, chunk); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("failed to read stream chunk (%d/%d state nodes, %d/%d codes read): %w",
+				len(witness.State), header.StateCount, len(witness.Codes), header.CodeCount, err)
+		}
+		witness.State = append(witness.State, chunk.State...)
+		witness.Codes = append(witness.Codes, chunk.Codes...)
+	}
+	if uint64(len(witness.State)) != header.StateCount || uint64(len(witness.Codes)) != header.CodeCount {
+		return nil, fmt.Errorf("invalid stream: %d state nodes and %d codes read, expected %d and %d",
+			len(witness.State), len(witness.Codes), header.StateCount, header.CodeCount)
+	}
+
+	return &input.ProverInput{
+		Version:     header.Version,
+		Blocks:      blocks,
+		Witness:     witness,
+		ChainConfig: ChainConfigFromProto(header.ChainConfig),
+		Extra:       ExtraFromProto(header.Extra),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package proto
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestStreamInput() *input.ProverInput {
+	pi := &input.ProverInput{
+		Version: input.SchemaVersion,
+		Blocks: []*input.Block{
+			{Header: &gethtypes.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0), ParentHash: gethcommon.HexToHash("0x9")}},
+		},
+		Witness: &input.Witness{
+			Ancestors: []*gethtypes.Header{{Number: big.NewInt(9), Difficulty: big.NewInt(0)}},
+		},
+		ChainConfig: params.MainnetChainConfig,
+		Extra:       &input.Extra{Committed: [][]byte{{0x01}}},
+	}
+	for i := 0; i < 100; i++ {
+		pi.Witness.State = append(pi.Witness.State, bytes.Repeat([]byte{byte(i)}, 10))
+	}
+	for i := 0; i < 5; i++ {
+		pi.Witness.Codes = append(pi.Witness.Codes, bytes.Repeat([]byte{byte(i)}, 100))
+	}
+	return pi
+}
+
+func TestStream(t *testing.T) {
+	pi := newTestStreamInput()
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, WriteStream(buf, pi, 64))
+	encoded := buf.Bytes()
+
+	// 100 state nodes of 10 bytes in chunks of 6 nodes, and 5 codes larger than the chunk size in chunks of 1 code
+	r := bytes.NewReader(encoded)
+	header := new(StreamHeader)
+	require.NoError(t, protodelim.UnmarshalFrom(r, header))
+	assert.Equal(t, uint64(100), header.StateCount)
+	assert.Equal(t, uint64(5), header.CodeCount)
+	chunks := 0
+	for r.Len() > 0 {
+		chunk := new(StreamChunk)
+		require.NoError(t, protodelim.UnmarshalFrom(r, chunk))
+		assert.LessOrEqual(t, len(chunk.State), 6)
+		assert.LessOrEqual(t, len(chunk.Codes), 1)
+		chunks++
+	}
+	assert.Equal(t, 17+5, chunks)
+
+	// Decode from a reader that is not a byte reader
+	decoded, err := ReadStream(io.MultiReader(bytes.NewReader(encoded)))
+	require.NoError(t, err)
+	expected, err := ToProto(pi)
+	require.NoError(t, err)
+	actual, err := ToProto(decoded)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(expected, actual))
+
+	// The stream is not read past its end
+	r = bytes.NewReader(append(encoded, 0xff))
+	_, err = ReadStream(r)
+	require.NoError(t, err)
+	assert.Equal(t, 1, r.Len())
+
+	// Truncated streams are detected
+	_, err = ReadStream(bytes.NewReader(encoded[:len(encoded)-120]))
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestStreamEmptyWitness(t *testing.T) {
+	pi := &input.ProverInput{Version: input.SchemaVersion}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, WriteStream(buf, pi, DefaultStreamChunkSize))
+	decoded, err := ReadStream(buf)
+	require.NoError(t, err)
+	assert.Equal(t, input.SchemaVersion, decoded.Version)
+	assert.Empty(t, decoded.Witness.State)
+	assert.Empty(t, decoded.Witness.Codes)
+}
@@ -1,6 +1,7 @@
 package proto
 
 import (
+	"fmt"
 	"math/big"
 	"time"
 
@@ -10,9 +11,11 @@ import (
 	"github.com/holiman/uint256"
 )
 
-func TransactionToProto(tx *gethtypes.Transaction) *Transaction {
+// TransactionToProto converts a Go transaction to protobuf format
+// It returns an error if the transaction type is not supported
+func TransactionToProto(tx *gethtypes.Transaction) (*Transaction, error) {
 	if tx == nil {
-		return nil
+		return nil, nil
 	}
 
 	switch tx.Type() {
@@ -21,42 +24,56 @@ func TransactionToProto(tx *gethtypes.Transaction) *Transaction {
 			TransactionType: &Transaction_LegacyTransaction{
 				LegacyTransaction: LegacyTransactionToProto(tx),
 			},
-		}
+		}, nil
 	case gethtypes.AccessListTxType:
 		return &Transaction{
 			TransactionType: &Transaction_AccessListTransaction{
 				AccessListTransaction: AccessListTransactionToProto(tx),
 			},
-		}
+		}, nil
 	case gethtypes.BlobTxType:
 		return &Transaction{
 			TransactionType: &Transaction_BlobTransaction{
 				BlobTransaction: BlobTransactionToProto(tx),
 			},
-		}
+		}, nil
 	case gethtypes.DynamicFeeTxType:
 		return &Transaction{
 			TransactionType: &Transaction_DynamicFeeTransaction{
 				DynamicFeeTransaction: DynamicFeeTransactionToProto(tx),
 			},
-		}
+		}, nil
+	case gethtypes.SetCodeTxType:
+		return &Transaction{
+			TransactionType: &Transaction_SetCodeTransaction{
+				SetCodeTransaction: SetCodeTransactionToProto(tx),
+			},
+		}, nil
 	default:
-		return nil
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
 	}
 }
 
-func TransactionFromProto(t *Transaction) *gethtypes.Transaction {
+// TransactionFromProto converts a protobuf transaction to Go format
+// It returns an error if the transaction type is not supported
+func TransactionFromProto(t *Transaction) (*gethtypes.Transaction, error) {
+	if t == nil {
+		return nil, nil
+	}
+
 	switch tx := t.GetTransactionType().(type) {
 	case *Transaction_LegacyTransaction:
-		return LegacyTransactionFromProto(tx.LegacyTransaction)
+		return LegacyTransactionFromProto(tx.LegacyTransaction), nil
 	case *Transaction_AccessListTransaction:
-		return AccessListTransactionFromProto(tx.AccessListTransaction)
+		return AccessListTransactionFromProto(tx.AccessListTransaction), nil
 	case *Transaction_BlobTransaction:
-		return BlobTransactionFromProto(tx.BlobTransaction)
+		return BlobTransactionFromProto(tx.BlobTransaction), nil
 	case *Transaction_DynamicFeeTransaction:
-		return DynamicFeeTransactionFromProto(tx.DynamicFeeTransaction)
+		return DynamicFeeTransactionFromProto(tx.DynamicFeeTransaction), nil
+	case *Transaction_SetCodeTransaction:
+		return SetCodeTransactionFromProto(tx.SetCodeTransaction), nil
 	default:
-		return nil
+		return nil, fmt.Errorf("unsupported transaction type %T", tx)
 	}
 }
 
@@ -282,6 +299,94 @@ func DynamicFeeTransactionFromProto(t *DynamicFeeTransaction) *gethtypes.Transac
 	return tx
 }
 
+func SetCodeTransactionToProto(tx *gethtypes.Transaction) *SetCodeTransaction {
+	v, r, s := tx.RawSignatureValues()
+	return &SetCodeTransaction{
+		ChainId:    tx.ChainId().Bytes(),
+		Nonce:      tx.Nonce(),
+		GasTipCap:  tx.GasTipCap().Bytes(),
+		GasFeeCap:  tx.GasFeeCap().Bytes(),
+		Gas:        tx.Gas(),
+		To:         tx.To().Bytes(),
+		Value:      tx.Value().Bytes(),
+		Data:       tx.Data(),
+		AccessList: AccessListToProto(tx.AccessList()),
+		AuthList:   AuthListToProto(tx.SetCodeAuthorizations()),
+		V:          v.Bytes(),
+		R:          r.Bytes(),
+		S:          s.Bytes(),
+	}
+}
+
+func SetCodeTransactionFromProto(t *SetCodeTransaction) *gethtypes.Transaction {
+	innerTx := &gethtypes.SetCodeTx{
+		ChainID:    new(uint256.Int).SetBytes(t.GetChainId()),
+		Nonce:      t.GetNonce(),
+		GasTipCap:  new(uint256.Int).SetBytes(t.GetGasTipCap()),
+		GasFeeCap:  new(uint256.Int).SetBytes(t.GetGasFeeCap()),
+		Gas:        t.GetGas(),
+		To:         gethcommon.BytesToAddress(t.GetTo()),
+		Value:      new(uint256.Int).SetBytes(t.GetValue()),
+		Data:       t.GetData(),
+		AccessList: AccessListFromProto(t.GetAccessList()),
+		AuthList:   AuthListFromProto(t.GetAuthList()),
+		V:          new(uint256.Int).SetBytes(t.GetV()),
+		R:          new(uint256.Int).SetBytes(t.GetR()),
+		S:          new(uint256.Int).SetBytes(t.GetS()),
+	}
+
+	tx := gethtypes.NewTx(innerTx)
+	tx.SetTime(time.Unix(0, 0))
+	return tx
+}
+
+func AuthListToProto(auths []gethtypes.SetCodeAuthorization) []*SetCodeAuthorization {
+	if auths == nil {
+		return nil
+	}
+
+	result := make([]*SetCodeAuthorization, len(auths))
+	for i := range auths {
+		result[i] = SetCodeAuthorizationToProto(&auths[i])
+	}
+	return result
+}
+
+func AuthListFromProto(p []*SetCodeAuthorization) []gethtypes.SetCodeAuthorization {
+	if p == nil {
+		return nil
+	}
+
+	result := make([]gethtypes.SetCodeAuthorization, len(p))
+	for i, auth := range p {
+		result[i] = *(SetCodeAuthorizationFromProto(auth))
+	}
+	return result
+}
+
+func SetCodeAuthorizationToProto(auth *gethtypes.SetCodeAuthorization) *SetCodeAuthorization {
+	return &SetCodeAuthorization{
+		ChainId: auth.ChainID.Bytes(),
+		Address: auth.Address.Bytes(),
+		Nonce:   auth.Nonce,
+		V:       uint32(auth.V),
+		R:       auth.R.Bytes(),
+		S:       auth.S.Bytes(),
+	}
+}
+
+func SetCodeAuthorizationFromProto(p *SetCodeAuthorization) *gethtypes.SetCodeAuthorization {
+	auth := &gethtypes.SetCodeAuthorization{
+		Address: gethcommon.BytesToAddress(p.GetAddress()),
+		Nonce:   p.GetNonce(),
+		V:       uint8(p.GetV()), //nolint:gosec // yParity is a single byte
+	}
+	auth.ChainID.SetBytes(p.GetChainId())
+	auth.R.SetBytes(p.GetR())
+	auth.S.SetBytes(p.GetS())
+	return auth
+}
+
 func AccessListToProto(al gethtypes.AccessList) []*AccessTuple {
 	if al == nil {
 		return nil
@@ -328,26 +433,34 @@ func AccessTupleFromProto(p *AccessTuple) *gethtypes.AccessTuple {
 	}
 }
 
-func TransactionsToProto(transactions []*gethtypes.Transaction) []*Transaction {
+func TransactionsToProto(transactions []*gethtypes.Transaction) ([]*Transaction, error) {
 	if transactions == nil {
-		return nil
+		return nil, nil
 	}
 
 	result := make([]*Transaction, len(transactions))
 	for i, t := range transactions {
-		result[i] = TransactionToProto(t)
+		tx, err := TransactionToProto(t)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		result[i] = tx
 	}
-	return result
+	return result, nil
 }
 
-func TransactionsFromProto(transactions []*Transaction) []*gethtypes.Transaction {
+func TransactionsFromProto(transactions []*Transaction) ([]*gethtypes.Transaction, error) {
 	if transactions == nil {
-		return nil
+		return nil, nil
 	}
 
 	result := make([]*gethtypes.Transaction, len(transactions))
 	for i, t := range transactions {
-		result[i] = TransactionFromProto(t)
+		tx, err := TransactionFromProto(t)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		result[i] = tx
 	}
-	return result
+	return result, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func blob(data []byte) kzg4844.Blob {
@@ -143,6 +144,45 @@ func TestTransaction(t *testing.T) {
 			desc: "BlobTransaction#Empty",
 			tx:   gethtypes.NewTx(&gethtypes.BlobTx{}),
 		},
+		{
+			desc: "SetCodeTransaction#NonEmpty",
+			tx: gethtypes.NewTx(&gethtypes.SetCodeTx{
+				ChainID:   uint256.NewInt(1),
+				Nonce:     1,
+				GasTipCap: uint256.NewInt(1000000000000000000),
+				GasFeeCap: uint256.NewInt(2000000000000000000),
+				Gas:       100000,
+				To:        gethcommon.Address{1},
+				Value:     uint256.NewInt(3000000000000000000),
+				Data:      []byte("test"),
+				AccessList: gethtypes.AccessList{
+					{
+						Address:     gethcommon.Address{1},
+						StorageKeys: []gethcommon.Hash{gethcommon.HexToHash("0x123")},
+					},
+				},
+				AuthList: []gethtypes.SetCodeAuthorization{
+					{
+						ChainID: *uint256.NewInt(1),
+						Address: gethcommon.Address{2},
+						Nonce:   2,
+						V:       1,
+						R:       *uint256.NewInt(5),
+						S:       *uint256.NewInt(4),
+					},
+					{
+						Address: gethcommon.Address{3},
+					},
+				},
+				V: uint256.NewInt(1),
+				R: uint256.NewInt(2),
+				S: uint256.NewInt(3),
+			}),
+		},
+		{
+			desc: "SetCodeTransaction#Empty",
+			tx:   gethtypes.NewTx(&gethtypes.SetCodeTx{}),
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -150,9 +190,22 @@ func TestTransaction(t *testing.T) {
 			if testCase.tx != nil {
 				testCase.tx.SetTime(time.Unix(0, 0)) // this is necessary to make the test deterministic
 			}
-			protoTx := TransactionToProto(testCase.tx)
-			fromProto := TransactionFromProto(protoTx)
+			protoTx, err := TransactionToProto(testCase.tx)
+			require.NoError(t, err)
+			fromProto, err := TransactionFromProto(protoTx)
+			require.NoError(t, err)
 			assert.Equal(t, testCase.tx, fromProto)
 		})
 	}
 }
+
+func TestTransactionFromProtoUnknownType(t *testing.T) {
+	_, err := TransactionFromProto(&Transaction{})
+	require.Error(t, err)
+
+	_, err = TransactionsFromProto([]*Transaction{{}})
+	require.Error(t, err)
+
+	_, err = FromProto(&ProverInput{Blocks: []*Block{{Transactions: []*Transaction{{}}}}})
+	require.Error(t, err)
+}
@@ -0,0 +1,177 @@
+package ssz
+
+import (
+	"fmt"
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// chainConfig is the SSZ container of a chain configuration
+type chainConfig struct {
+	ChainID                 *uint256
+	HomesteadBlock          *uint256
+	DAOForkBlock            *uint256
+	DAOForkSupport          bool
+	EIP150Block             *uint256
+	EIP155Block             *uint256
+	EIP158Block             *uint256
+	ByzantiumBlock          *uint256
+	ConstantinopleBlock     *uint256
+	PetersburgBlock         *uint256
+	IstanbulBlock           *uint256
+	MuirGlacierBlock        *uint256
+	BerlinBlock             *uint256
+	LondonBlock             *uint256
+	ArrowGlacierBlock       *uint256
+	GrayGlacierBlock        *uint256
+	MergeNetsplitBlock      *uint256
+	ShanghaiTime            *uint64
+	CancunTime              *uint64
+	PragueTime              *uint64
+	OsakaTime               *uint64
+	VerkleTime              *uint64
+	TerminalTotalDifficulty *uint256
+	DepositContractAddress  gethcommon.Address
+	EnableVerkleAtGenesis   bool
+	Ethash                  bool
+	Clique                  *cliqueConfig
+	BlobSchedule            *blobScheduleConfig
+}
+
+type cliqueConfig struct {
+	Period uint64
+	Epoch  uint64
+}
+
+type blobScheduleConfig struct {
+	Cancun *blobConfig
+	Prague *blobConfig
+	Osaka  *blobConfig
+	Verkle *blobConfig
+}
+
+type blobConfig struct {
+	Target         uint64
+	Max            uint64
+	UpdateFraction uint64
+}
+
+// bigField is a big integer field of a chain configuration
+type bigField struct {
+	name string
+	big  **big.Int
+	ssz  **uint256
+}
+
+func (v *chainConfig) bigFields(c *params.ChainConfig) []bigField {
+	return []bigField{
+		{"chain id", &c.ChainID, &v.ChainID},
+		{"homestead block", &c.HomesteadBlock, &v.HomesteadBlock},
+		{"dao fork block", &c.DAOForkBlock, &v.DAOForkBlock},
+		{"eip150 block", &c.EIP150Block, &v.EIP150Block},
+		{"eip155 block", &c.EIP155Block, &v.EIP155Block},
+		{"eip158 block", &c.EIP158Block, &v.EIP158Block},
+		{"byzantium block", &c.ByzantiumBlock, &v.ByzantiumBlock},
+		{"constantinople block", &c.ConstantinopleBlock, &v.ConstantinopleBlock},
+		{"petersburg block", &c.PetersburgBlock, &v.PetersburgBlock},
+		{"istanbul block", &c.IstanbulBlock, &v.IstanbulBlock},
+		{"muir glacier block", &c.MuirGlacierBlock, &v.MuirGlacierBlock},
+		{"berlin block", &c.BerlinBlock, &v.BerlinBlock},
+		{"london block", &c.LondonBlock, &v.LondonBlock},
+		{"arrow glacier block", &c.ArrowGlacierBlock, &v.ArrowGlacierBlock},
+		{"gray glacier block", &c.GrayGlacierBlock, &v.GrayGlacierBlock},
+		{"merge netsplit block", &c.MergeNetsplitBlock, &v.MergeNetsplitBlock},
+		{"terminal total difficulty", &c.TerminalTotalDifficulty, &v.TerminalTotalDifficulty},
+	}
+}
+
+func fromChainConfig(c *params.ChainConfig) (*chainConfig, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	v := &chainConfig{
+		DAOForkSupport:         c.DAOForkSupport,
+		ShanghaiTime:           c.ShanghaiTime,
+		CancunTime:             c.CancunTime,
+		PragueTime:             c.PragueTime,
+		OsakaTime:              c.OsakaTime,
+		VerkleTime:             c.VerkleTime,
+		DepositContractAddress: c.DepositContractAddress,
+		EnableVerkleAtGenesis:  c.EnableVerkleAtGenesis,
+		Ethash:                 c.Ethash != nil,
+	}
+	for _, f := range v.bigFields(c) {
+		u, err := toOptionalUint256(*f.big)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", f.name, err)
+		}
+		*f.ssz = u
+	}
+
+	if c.Clique != nil {
+		v.Clique = &cliqueConfig{Period: c.Clique.Period, Epoch: c.Clique.Epoch}
+	}
+
+	if s := c.BlobScheduleConfig; s != nil {
+		v.BlobSchedule = &blobScheduleConfig{
+			Cancun: fromBlobConfig(s.Cancun),
+			Prague: fromBlobConfig(s.Prague),
+			Osaka:  fromBlobConfig(s.Osaka),
+			Verkle: fromBlobConfig(s.Verkle),
+		}
+	}
+
+	return v, nil
+}
+
+func (v *chainConfig) toChainConfig() *params.ChainConfig {
+	c := &params.ChainConfig{
+		DAOForkSupport:         v.DAOForkSupport,
+		ShanghaiTime:           v.ShanghaiTime,
+		CancunTime:             v.CancunTime,
+		PragueTime:             v.PragueTime,
+		OsakaTime:              v.OsakaTime,
+		VerkleTime:             v.VerkleTime,
+		DepositContractAddress: v.DepositContractAddress,
+		EnableVerkleAtGenesis:  v.EnableVerkleAtGenesis,
+	}
+	for _, f := range v.bigFields(c) {
+		*f.big = fromOptionalUint256(*f.ssz)
+	}
+
+	if v.Ethash {
+		c.Ethash = &params.EthashConfig{}
+	}
+
+	if v.Clique != nil {
+		c.Clique = &params.CliqueConfig{Period: v.Clique.Period, Epoch: v.Clique.Epoch}
+	}
+
+	if s := v.BlobSchedule; s != nil {
+		c.BlobScheduleConfig = &params.BlobScheduleConfig{
+			Cancun: s.Cancun.toBlobConfig(),
+			Prague: s.Prague.toBlobConfig(),
+			Osaka:  s.Osaka.toBlobConfig(),
+			Verkle: s.Verkle.toBlobConfig(),
+		}
+	}
+
+	return c
+}
+
+func fromBlobConfig(c *params.BlobConfig) *blobConfig {
+	if c == nil {
+		return nil
+	}
+	return &blobConfig{Target: uint64(c.Target), Max: uint64(c.Max), UpdateFraction: c.UpdateFraction}
+}
+
+func (v *blobConfig) toBlobConfig() *params.BlobConfig {
+	if v == nil {
+		return nil
+	}
+	return &params.BlobConfig{Target: int(v.Target), Max: int(v.Max), UpdateFraction: v.UpdateFraction}
+}
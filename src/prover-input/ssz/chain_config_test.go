@@ -0,0 +1,35 @@
+package ssz
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/kkrt-labs/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainConfig(t *testing.T) {
+	clique := *params.AllCliqueProtocolChanges
+	clique.Clique = &params.CliqueConfig{Period: 15, Epoch: 30000}
+	clique.OsakaTime = common.Ptr(uint64(10))
+
+	for _, cfg := range []*params.ChainConfig{params.MainnetChainConfig, params.SepoliaChainConfig, &clique, {ChainID: big.NewInt(7)}} {
+		v, err := fromChainConfig(cfg)
+		require.NoError(t, err)
+		decoded, err := decodeValue(encodeValue(reflect.ValueOf(v)), reflect.TypeOf(v), nil)
+		require.NoError(t, err)
+
+		expected, err := json.Marshal(cfg)
+		require.NoError(t, err)
+		actual, err := json.Marshal(decoded.Interface().(*chainConfig).toChainConfig())
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(actual))
+	}
+
+	_, err := fromChainConfig(&params.ChainConfig{ChainID: new(big.Int).Lsh(big.NewInt(1), 256)})
+	assert.Error(t, err, "chain id overflows uint256")
+}
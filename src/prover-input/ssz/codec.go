@@ -0,0 +1,362 @@
+package ssz
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// This file implements a reflection based SSZ codec for the containers declared as Go structs (chain config and extra data)
+//
+// Go types are mapped to SSZ types as follows:
+//   - bool, uint8 and uint64 are basic types
+//   - [N]byte is a ByteVector[N] (uint256 values are ByteVector[32] holding the little-endian encoding, which have the same encoding and root)
+//   - []byte is a ByteList and []T is a List[T], which limits are set by the `ssz-max` tag of the struct field
+//     (comma separated names of the limit constants, for each nesting level e.g. `ssz-max:"MaxNodes,MaxNodeSize"`)
+//   - *T is an optional value, encoded as a List[T, 1]
+//   - struct is a Container of its fields (all fields must be exported)
+
+var limits = map[string]int{
+	"MaxNodes":               MaxNodes,
+	"MaxNodeSize":            MaxNodeSize,
+	"MaxCodeSize":            MaxCodeSize,
+	"MaxTransactions":        MaxTransactions,
+	"MaxAccounts":            MaxAccounts,
+	"MaxStorageSlots":        MaxStorageSlots,
+	"MaxLogs":                MaxLogs,
+	"MaxLogTopics":           MaxLogTopics,
+	"MaxLogDataSize":         MaxLogDataSize,
+	"MaxPostStateSize":       MaxPostStateSize,
+	"MaxOpcodes":             MaxOpcodes,
+	"MaxOpcodeNameSize":      MaxOpcodeNameSize,
+	"MaxPrecompiles":         MaxPrecompiles,
+	"MaxTxStateDiffs":        MaxTxStateDiffs,
+	"MaxTxStateDiffTypeSize": MaxTxStateDiffTypeSize,
+}
+
+// fieldLimits returns the limits of a struct field (one per nesting level)
+func fieldLimits(f *reflect.StructField) []int {
+	tag, ok := f.Tag.Lookup("ssz-max")
+	if !ok {
+		return nil
+	}
+
+	names := strings.Split(tag, ",")
+	fLimits := make([]int, len(names))
+	for i, name := range names {
+		limit, ok := limits[name]
+		if !ok {
+			panic(fmt.Sprintf("ssz: unknown limit %q of field %s", name, f.Name))
+		}
+		fLimits[i] = limit
+	}
+	return fLimits
+}
+
+// listLimit returns the limit of a list at the current nesting level
+func listLimit(t reflect.Type, fLimits []int) int {
+	if len(fLimits) == 0 {
+		panic(fmt.Sprintf("ssz: missing limit of %s", t))
+	}
+	return fLimits[0]
+}
+
+// fixedSize returns the size of the encoding of a fixed-size type, and 0 for a variable-size type
+func fixedSize(t reflect.Type) int {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Uint8:
+		return 1
+	case reflect.Uint64:
+		return 8
+	case reflect.Array:
+		return t.Len()
+	case reflect.Struct:
+		size := 0
+		for i := 0; i < t.NumField(); i++ {
+			fieldSize := fixedSize(t.Field(i).Type)
+			if fieldSize == 0 {
+				return 0
+			}
+			size += fieldSize
+		}
+		return size
+	case reflect.Slice, reflect.Ptr:
+		return 0
+	default:
+		panic(fmt.Sprintf("ssz: unsupported type %s", t))
+	}
+}
+
+func isBasic(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Uint8, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isByteList(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// checkValue checks the lists of a value do not exceed their limits
+func checkValue(name string, v reflect.Value, fLimits []int) error {
+	switch v.Kind() {
+	case reflect.Slice:
+		if limit := listLimit(v.Type(), fLimits); v.Len() > limit {
+			return fmt.Errorf("%s exceeds SSZ limit: %d > %d", name, v.Len(), limit)
+		}
+		if isByteList(v.Type()) {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := checkValue(fmt.Sprintf("%s %d", name, i), v.Index(i), fLimits[1:]); err != nil {
+				return err
+			}
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			return checkValue(name, v.Elem(), fLimits)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if err := checkValue(fmt.Sprintf("%s %s", name, f.Name), v.Field(i), fieldLimits(&f)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// encodeValue encodes a value
+func encodeValue(v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return []byte{1}
+		}
+		return []byte{0}
+	case reflect.Uint8:
+		return []byte{uint8(v.Uint())}
+	case reflect.Uint64:
+		return encodeUint64(v.Uint())
+	case reflect.Array:
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return b
+	case reflect.Slice:
+		if isByteList(v.Type()) {
+			return v.Bytes()
+		}
+		elems := make([][]byte, v.Len())
+		for i := range elems {
+			elems[i] = encodeValue(v.Index(i))
+		}
+		if fixedSize(v.Type().Elem()) > 0 {
+			return encodeFixedList(elems)
+		}
+		return encodeVariableList(elems)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return []byte{}
+		}
+		if fixedSize(v.Type().Elem()) > 0 {
+			return encodeValue(v.Elem())
+		}
+		return encodeVariableList([][]byte{encodeValue(v.Elem())})
+	case reflect.Struct:
+		fields := make([]field, v.NumField())
+		for i := range fields {
+			if fixedSize(v.Field(i).Type()) > 0 {
+				fields[i] = fixedField(encodeValue(v.Field(i)))
+			} else {
+				fields[i] = variableField(encodeValue(v.Field(i)))
+			}
+		}
+		return encodeContainer(fields...)
+	default:
+		panic(fmt.Sprintf("ssz: unsupported type %s", v.Type()))
+	}
+}
+
+// decodeValue decodes a value of type t (empty lists are decoded as nil slices)
+func decodeValue(b []byte, t reflect.Type, fLimits []int) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if size := fixedSize(t); size > 0 && len(b) != size {
+		return v, fmt.Errorf("invalid size: %d != %d", len(b), size)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if b[0] > 1 {
+			return v, fmt.Errorf("invalid boolean: %d", b[0])
+		}
+		v.SetBool(b[0] == 1)
+	case reflect.Uint8:
+		v.SetUint(uint64(b[0]))
+	case reflect.Uint64:
+		v.SetUint(decodeUint64(b))
+	case reflect.Array:
+		reflect.Copy(v, reflect.ValueOf(b))
+	case reflect.Slice:
+		limit := listLimit(t, fLimits)
+		if isByteList(t) {
+			if len(b) > limit {
+				return v, fmt.Errorf("list too long: %d > %d", len(b), limit)
+			}
+			if len(b) > 0 {
+				v.SetBytes(b)
+			}
+			return v, nil
+		}
+		elems, err := decodeList(b, t.Elem(), limit)
+		if err != nil {
+			return v, err
+		}
+		if len(elems) == 0 {
+			return v, nil
+		}
+		v.Set(reflect.MakeSlice(t, len(elems), len(elems)))
+		for i, elem := range elems {
+			decoded, err := decodeValue(elem, t.Elem(), fLimits[1:])
+			if err != nil {
+				return v, fmt.Errorf("element %d: %v", i, err)
+			}
+			v.Index(i).Set(decoded)
+		}
+	case reflect.Ptr:
+		elems, err := decodeList(b, t.Elem(), 1)
+		if err != nil {
+			return v, err
+		}
+		if len(elems) == 0 {
+			return v, nil
+		}
+		decoded, err := decodeValue(elems[0], t.Elem(), fLimits)
+		if err != nil {
+			return v, err
+		}
+		v.Set(reflect.New(t.Elem()))
+		v.Elem().Set(decoded)
+	case reflect.Struct:
+		sizes := make([]int, t.NumField())
+		for i := range sizes {
+			sizes[i] = fixedSize(t.Field(i).Type)
+		}
+		fields, err := decodeContainer(b, sizes...)
+		if err != nil {
+			return v, err
+		}
+		for i, encoded := range fields {
+			f := t.Field(i)
+			decoded, err := decodeValue(encoded, f.Type, fieldLimits(&f))
+			if err != nil {
+				return v, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			v.Field(i).Set(decoded)
+		}
+	default:
+		panic(fmt.Sprintf("ssz: unsupported type %s", t))
+	}
+	return v, nil
+}
+
+// decodeList splits the encoding of a list of elements of type t into the encoding of its elements
+func decodeList(b []byte, t reflect.Type, limit int) ([][]byte, error) {
+	if size := fixedSize(t); size > 0 {
+		return decodeFixedList(b, size, limit)
+	}
+	return decodeVariableList(b, limit)
+}
+
+// hashValue computes the hash tree root of a value
+func hashValue(v reflect.Value, fLimits []int) [chunkSize]byte {
+	switch v.Kind() {
+	case reflect.Bool, reflect.Uint8, reflect.Uint64:
+		var chunk [chunkSize]byte
+		copy(chunk[:], encodeValue(v))
+		return chunk
+	case reflect.Array:
+		return hashByteVector(encodeValue(v))
+	case reflect.Slice:
+		limit := listLimit(v.Type(), fLimits)
+		if isByteList(v.Type()) {
+			return hashByteList(v.Bytes(), limit)
+		}
+		if elem := v.Type().Elem(); isBasic(elem) {
+			// Basic elements are packed into chunks
+			size := fixedSize(elem)
+			return mixInLength(merkleize(pack(encodeValue(v)), (limit*size+chunkSize-1)/chunkSize), v.Len())
+		}
+		roots := make([][chunkSize]byte, v.Len())
+		for i := range roots {
+			roots[i] = hashValue(v.Index(i), fLimits[1:])
+		}
+		return hashList(roots, limit)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return hashList(nil, 1)
+		}
+		if isBasic(v.Type().Elem()) {
+			return mixInLength(hashValue(v.Elem(), fLimits), 1)
+		}
+		return hashList([][chunkSize]byte{hashValue(v.Elem(), fLimits)}, 1)
+	case reflect.Struct:
+		t := v.Type()
+		roots := make([][chunkSize]byte, v.NumField())
+		for i := range roots {
+			f := t.Field(i)
+			roots[i] = hashValue(v.Field(i), fieldLimits(&f))
+		}
+		return hashContainer(roots...)
+	default:
+		panic(fmt.Sprintf("ssz: unsupported type %s", v.Type()))
+	}
+}
+
+// uint256 is the little-endian encoding of a 256-bit unsigned integer
+type uint256 [32]byte
+
+func toUint256(x *big.Int) (uint256, error) {
+	var u uint256
+	if x == nil {
+		return u, nil
+	}
+	if x.Sign() < 0 || x.BitLen() > 256 {
+		return u, fmt.Errorf("%v does not fit in uint256", x)
+	}
+	x.FillBytes(u[:])
+	slices.Reverse(u[:])
+	return u, nil
+}
+
+func (u *uint256) toBig() *big.Int {
+	be := *u
+	slices.Reverse(be[:])
+	return new(big.Int).SetBytes(be[:])
+}
+
+// toOptionalUint256 converts an optional big integer (nil if x is nil)
+func toOptionalUint256(x *big.Int) (*uint256, error) {
+	if x == nil {
+		return nil, nil
+	}
+	u, err := toUint256(x)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// fromOptionalUint256 converts an optional uint256 (nil if u is nil)
+func fromOptionalUint256(u *uint256) *big.Int {
+	if u == nil {
+		return nil
+	}
+	return u.toBig()
+}
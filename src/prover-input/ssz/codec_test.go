@@ -0,0 +1,105 @@
+package ssz
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/kkrt-labs/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testContainer struct {
+	A uint64
+	B []byte `ssz-max:"MaxPostStateSize"`
+	C *uint64
+	D []gethcommon.Hash `ssz-max:"MaxLogTopics"`
+	E bool
+}
+
+// Vectors are computed following the SSZ specification
+// (see https://github.com/ethereum/consensus-specs/blob/dev/ssz/simple-serialize.md)
+func TestCodecVectors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    *testContainer
+		encoding string
+		root     string
+	}{
+		{
+			desc: "all fields set",
+			value: &testContainer{
+				A: 1,
+				B: []byte{0x01, 0x02},
+				C: common.Ptr(uint64(5)),
+				D: []gethcommon.Hash{gethcommon.HexToHash("0x1"), gethcommon.HexToHash("0x2")},
+				E: true,
+			},
+			encoding: "010000000000000015000000170000001f000000010102050000000000000000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+			root:     "f84ff81fb8c654f2db8f5ddf8563d683b5a8aa2da89f9868458d0d9756451fe6",
+		},
+		{
+			desc:     "empty fields",
+			value:    &testContainer{},
+			encoding: "000000000000000015000000150000001500000000",
+			root:     "b2fec59245127e0649697340c22fe1e50a305426e10391727038bd359816b751",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			v := reflect.ValueOf(*tc.value)
+			require.NoError(t, checkValue("test", v, nil))
+			assert.Equal(t, tc.encoding, hex.EncodeToString(encodeValue(v)))
+			root := hashValue(v, nil)
+			assert.Equal(t, tc.root, hex.EncodeToString(root[:]))
+
+			b, err := hex.DecodeString(tc.encoding)
+			require.NoError(t, err)
+			decoded, err := decodeValue(b, v.Type(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.value, decoded.Interface())
+		})
+	}
+}
+
+func TestZeroHashes(t *testing.T) {
+	// Zero hashes of the deposit contract
+	assert.Equal(t, "f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b", hex.EncodeToString(zeroHashes[1][:]))
+	assert.Equal(t, "db56114e00fdd4c1f85c892bf35ac9a89289aaecb1ebd0a96cde606a748b5d71", hex.EncodeToString(zeroHashes[2][:]))
+	assert.Equal(t, "c78009fdf07fc56a11f122370658a353aaa542ed63e44c4bc15ff4cd105ab33c", hex.EncodeToString(zeroHashes[3][:]))
+}
+
+func TestCodecErrors(t *testing.T) {
+	typ := reflect.TypeOf(testContainer{})
+
+	err := checkValue("test", reflect.ValueOf(testContainer{D: make([]gethcommon.Hash, MaxLogTopics+1)}), nil)
+	assert.EqualError(t, err, "test D exceeds SSZ limit: 5 > 4")
+
+	// Boolean other than 0 or 1
+	_, err = decodeValue([]byte{0, 0, 0, 0, 0, 0, 0, 0, 21, 0, 0, 0, 21, 0, 0, 0, 21, 0, 0, 0, 2}, typ, nil)
+	assert.EqualError(t, err, "E: invalid boolean: 2")
+
+	// Optional with more than one element
+	_, err = decodeValue(append([]byte{0, 0, 0, 0, 0, 0, 0, 0, 21, 0, 0, 0, 21, 0, 0, 0, 37, 0, 0, 0, 0}, make([]byte, 16)...), typ, nil)
+	assert.EqualError(t, err, "C: list too long: 2 > 1")
+}
+
+func TestUint256(t *testing.T) {
+	// uint256 is little-endian
+	u, err := toUint256(big.NewInt(0x0102))
+	require.NoError(t, err)
+	assert.Equal(t, uint256{0x02, 0x01}, u)
+
+	x, ok := new(big.Int).SetString("58750000000000000000000", 10)
+	require.True(t, ok)
+	u, err = toUint256(x)
+	require.NoError(t, err)
+	assert.Equal(t, x, u.toBig())
+
+	_, err = toUint256(big.NewInt(-1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,577 @@
+package ssz
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+)
+
+// extra is the SSZ container of the prover input extra data
+//
+// Maps are encoded as lists sorted by key.
+type extra struct {
+	AccessList   []accessTuple     `ssz-max:"MaxAccounts"`
+	Committed    [][]byte          `ssz-max:"MaxNodes,MaxNodeSize"`
+	StateDiffs   []stateDiff       `ssz-max:"MaxAccounts"`
+	PreState     []preStateAccount `ssz-max:"MaxAccounts"`
+	ProvingCost  *provingCost
+	Senders      []gethcommon.Address `ssz-max:"MaxTransactions"`
+	Receipts     []receipt            `ssz-max:"MaxTransactions"`
+	TxStateDiffs []txStateDiff        `ssz-max:"MaxTxStateDiffs"`
+	Preimages    *preimages
+}
+
+type accessTuple struct {
+	Address     gethcommon.Address
+	StorageKeys []gethcommon.Hash `ssz-max:"MaxStorageSlots"`
+}
+
+type stateDiff struct {
+	Address     gethcommon.Address
+	PreAccount  *account
+	PostAccount *account
+	Storage     []storageDiff `ssz-max:"MaxStorageSlots"`
+}
+
+type account struct {
+	Balance     uint256
+	CodeHash    gethcommon.Hash
+	Nonce       uint64
+	StorageHash gethcommon.Hash
+}
+
+type storageDiff struct {
+	Slot      gethcommon.Hash
+	PreValue  gethcommon.Hash
+	PostValue gethcommon.Hash
+}
+
+type preStateAccount struct {
+	Address gethcommon.Address
+	State   *accountState // unset if the account does not exist in the pre-state
+}
+
+type accountState struct {
+	Balance     uint256
+	CodeHash    gethcommon.Hash
+	Code        []byte `ssz-max:"MaxCodeSize"`
+	Nonce       uint64
+	StorageHash gethcommon.Hash
+	Storage     []storageEntry `ssz-max:"MaxStorageSlots"`
+}
+
+type storageEntry struct {
+	Slot  gethcommon.Hash
+	Value gethcommon.Hash
+}
+
+type provingCost struct {
+	Cycles       uint64
+	Opcodes      []opcodeCount     `ssz-max:"MaxOpcodes"`
+	Precompiles  []precompileUsage `ssz-max:"MaxPrecompiles"`
+	KeccakBytes  uint64
+	WitnessNodes uint64
+}
+
+type opcodeCount struct {
+	Opcode []byte `ssz-max:"MaxOpcodeNameSize"`
+	Count  uint64
+}
+
+type precompileUsage struct {
+	Address    gethcommon.Address
+	Calls      uint64
+	InputBytes uint64
+}
+
+type receipt struct {
+	Type              uint8
+	PostState         []byte `ssz-max:"MaxPostStateSize"`
+	Status            uint64
+	CumulativeGasUsed uint64
+	Bloom             gethtypes.Bloom
+	Logs              []log `ssz-max:"MaxLogs"`
+	TxHash            gethcommon.Hash
+	ContractAddress   gethcommon.Address
+	GasUsed           uint64
+	EffectiveGasPrice *uint256
+	BlobGasUsed       uint64
+	BlobGasPrice      *uint256
+	BlockHash         gethcommon.Hash
+	BlockNumber       *uint256
+	TransactionIndex  uint64
+}
+
+type log struct {
+	Address     gethcommon.Address
+	Topics      []gethcommon.Hash `ssz-max:"MaxLogTopics"`
+	Data        []byte            `ssz-max:"MaxLogDataSize"`
+	BlockNumber uint64
+	TxHash      gethcommon.Hash
+	TxIndex     uint64
+	BlockHash   gethcommon.Hash
+	Index       uint64
+	Removed     bool
+}
+
+type txStateDiff struct {
+	BlockNumber   uint64
+	Type          []byte `ssz-max:"MaxTxStateDiffTypeSize"`
+	TxIndex       *uint64
+	TxHash        *gethcommon.Hash
+	SystemAddress *gethcommon.Address
+	Accounts      []accountDiff `ssz-max:"MaxAccounts"`
+}
+
+type accountDiff struct {
+	Address      gethcommon.Address
+	PreBalance   *uint256
+	PostBalance  *uint256
+	PreNonce     *uint64
+	PostNonce    *uint64
+	PreCodeHash  *gethcommon.Hash
+	PostCodeHash *gethcommon.Hash
+	Storage      []storageDiff `ssz-max:"MaxStorageSlots"`
+}
+
+type preimages struct {
+	Accounts []accountPreimage `ssz-max:"MaxAccounts"`
+	Slots    []slotPreimage    `ssz-max:"MaxStorageSlots"`
+}
+
+type accountPreimage struct {
+	Address gethcommon.Address
+	Key     gethcommon.Hash
+}
+
+type slotPreimage struct {
+	Slot gethcommon.Hash
+	Key  gethcommon.Hash
+}
+
+func fromExtra(e *input.Extra) (*extra, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	v := &extra{
+		Committed:   e.Committed,
+		ProvingCost: fromProvingCost(e.ProvingCost),
+		Senders:     e.Senders,
+		Preimages:   fromPreimages(e.Preimages),
+	}
+
+	for _, tuple := range e.AccessList {
+		v.AccessList = append(v.AccessList, accessTuple{Address: tuple.Address, StorageKeys: tuple.StorageKeys})
+	}
+
+	for i, d := range e.StateDiffs {
+		sd, err := fromStateDiff(d)
+		if err != nil {
+			return nil, fmt.Errorf("state diff %d: %v", i, err)
+		}
+		v.StateDiffs = append(v.StateDiffs, *sd)
+	}
+
+	addresses := make([]gethcommon.Address, 0, len(e.PreState))
+	for addr := range e.PreState {
+		addresses = append(addresses, addr)
+	}
+	sort.Slice(addresses, func(i, j int) bool { return bytes.Compare(addresses[i][:], addresses[j][:]) < 0 })
+	for _, addr := range addresses {
+		state, err := fromAccountState(e.PreState[addr])
+		if err != nil {
+			return nil, fmt.Errorf("pre-state %s: %v", addr.Hex(), err)
+		}
+		v.PreState = append(v.PreState, preStateAccount{Address: addr, State: state})
+	}
+
+	for i, r := range e.Receipts {
+		rcpt, err := fromReceipt(r)
+		if err != nil {
+			return nil, fmt.Errorf("receipt %d: %v", i, err)
+		}
+		v.Receipts = append(v.Receipts, *rcpt)
+	}
+
+	for i, d := range e.TxStateDiffs {
+		txd, err := fromTxStateDiff(d)
+		if err != nil {
+			return nil, fmt.Errorf("tx state diff %d: %v", i, err)
+		}
+		v.TxStateDiffs = append(v.TxStateDiffs, *txd)
+	}
+
+	return v, nil
+}
+
+func (v *extra) toExtra() *input.Extra {
+	e := &input.Extra{
+		Committed:   v.Committed,
+		ProvingCost: v.ProvingCost.toProvingCost(),
+		Senders:     v.Senders,
+		Preimages:   v.Preimages.toPreimages(),
+	}
+
+	for _, tuple := range v.AccessList {
+		storageKeys := tuple.StorageKeys
+		if storageKeys == nil {
+			storageKeys = []gethcommon.Hash{}
+		}
+		e.AccessList = append(e.AccessList, gethtypes.AccessTuple{Address: tuple.Address, StorageKeys: storageKeys})
+	}
+
+	for i := range v.StateDiffs {
+		e.StateDiffs = append(e.StateDiffs, v.StateDiffs[i].toStateDiff())
+	}
+
+	if len(v.PreState) > 0 {
+		e.PreState = make(map[gethcommon.Address]*input.AccountState, len(v.PreState))
+		for _, acc := range v.PreState {
+			e.PreState[acc.Address] = acc.State.toAccountState()
+		}
+	}
+
+	for i := range v.Receipts {
+		e.Receipts = append(e.Receipts, v.Receipts[i].toReceipt())
+	}
+
+	for i := range v.TxStateDiffs {
+		e.TxStateDiffs = append(e.TxStateDiffs, v.TxStateDiffs[i].toTxStateDiff())
+	}
+
+	return e
+}
+
+func fromStateDiff(d *input.StateDiff) (*stateDiff, error) {
+	pre, err := fromAccount(d.PreAccount)
+	if err != nil {
+		return nil, fmt.Errorf("pre-account: %v", err)
+	}
+	post, err := fromAccount(d.PostAccount)
+	if err != nil {
+		return nil, fmt.Errorf("post-account: %v", err)
+	}
+	return &stateDiff{
+		Address:     d.Address,
+		PreAccount:  pre,
+		PostAccount: post,
+		Storage:     fromStorageDiffs(d.Storage),
+	}, nil
+}
+
+func (v *stateDiff) toStateDiff() *input.StateDiff {
+	return &input.StateDiff{
+		Address:     v.Address,
+		PreAccount:  v.PreAccount.toAccount(),
+		PostAccount: v.PostAccount.toAccount(),
+		Storage:     toStorageDiffs(v.Storage),
+	}
+}
+
+func fromAccount(a *input.Account) (*account, error) {
+	if a == nil {
+		return nil, nil
+	}
+	balance, err := toUint256(a.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("balance: %v", err)
+	}
+	return &account{Balance: balance, CodeHash: a.CodeHash, Nonce: a.Nonce, StorageHash: a.StorageHash}, nil
+}
+
+func (v *account) toAccount() *input.Account {
+	if v == nil {
+		return nil
+	}
+	return &input.Account{Balance: v.Balance.toBig(), CodeHash: v.CodeHash, Nonce: v.Nonce, StorageHash: v.StorageHash}
+}
+
+func fromStorageDiffs(diffs []*input.StorageDiff) []storageDiff {
+	var v []storageDiff
+	for _, d := range diffs {
+		v = append(v, storageDiff{Slot: d.Slot, PreValue: d.PreValue, PostValue: d.PostValue})
+	}
+	return v
+}
+
+func toStorageDiffs(v []storageDiff) []*input.StorageDiff {
+	var diffs []*input.StorageDiff
+	for _, d := range v {
+		diffs = append(diffs, &input.StorageDiff{Slot: d.Slot, PreValue: d.PreValue, PostValue: d.PostValue})
+	}
+	return diffs
+}
+
+func fromAccountState(a *input.AccountState) (*accountState, error) {
+	if a == nil {
+		return nil, nil
+	}
+	balance, err := toUint256(a.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("balance: %v", err)
+	}
+
+	v := &accountState{
+		Balance:     balance,
+		CodeHash:    a.CodeHash,
+		Code:        a.Code,
+		Nonce:       a.Nonce,
+		StorageHash: a.StorageHash,
+	}
+	for slot, value := range a.Storage {
+		v.Storage = append(v.Storage, storageEntry{Slot: slot, Value: value})
+	}
+	sort.Slice(v.Storage, func(i, j int) bool { return bytes.Compare(v.Storage[i].Slot[:], v.Storage[j].Slot[:]) < 0 })
+	return v, nil
+}
+
+func (v *accountState) toAccountState() *input.AccountState {
+	if v == nil {
+		return nil
+	}
+
+	a := &input.AccountState{
+		Balance:     v.Balance.toBig(),
+		CodeHash:    v.CodeHash,
+		Code:        v.Code,
+		Nonce:       v.Nonce,
+		StorageHash: v.StorageHash,
+	}
+	if len(v.Storage) > 0 {
+		a.Storage = make(map[gethcommon.Hash]gethcommon.Hash, len(v.Storage))
+		for _, entry := range v.Storage {
+			a.Storage[entry.Slot] = entry.Value
+		}
+	}
+	return a
+}
+
+func fromProvingCost(c *input.ProvingCost) *provingCost {
+	if c == nil {
+		return nil
+	}
+
+	v := &provingCost{
+		Cycles:       c.Cycles,
+		KeccakBytes:  c.KeccakBytes,
+		WitnessNodes: c.WitnessNodes,
+	}
+	for opcode, count := range c.Opcodes {
+		v.Opcodes = append(v.Opcodes, opcodeCount{Opcode: []byte(opcode), Count: count})
+	}
+	sort.Slice(v.Opcodes, func(i, j int) bool { return bytes.Compare(v.Opcodes[i].Opcode, v.Opcodes[j].Opcode) < 0 })
+	for addr, usage := range c.Precompiles {
+		v.Precompiles = append(v.Precompiles, precompileUsage{Address: addr, Calls: usage.Calls, InputBytes: usage.InputBytes})
+	}
+	sort.Slice(v.Precompiles, func(i, j int) bool {
+		return bytes.Compare(v.Precompiles[i].Address[:], v.Precompiles[j].Address[:]) < 0
+	})
+	return v
+}
+
+func (v *provingCost) toProvingCost() *input.ProvingCost {
+	if v == nil {
+		return nil
+	}
+
+	c := &input.ProvingCost{
+		Cycles:       v.Cycles,
+		KeccakBytes:  v.KeccakBytes,
+		WitnessNodes: v.WitnessNodes,
+	}
+	if len(v.Opcodes) > 0 {
+		c.Opcodes = make(map[string]uint64, len(v.Opcodes))
+		for _, op := range v.Opcodes {
+			c.Opcodes[string(op.Opcode)] = op.Count
+		}
+	}
+	if len(v.Precompiles) > 0 {
+		c.Precompiles = make(map[gethcommon.Address]*input.PrecompileUsage, len(v.Precompiles))
+		for _, usage := range v.Precompiles {
+			c.Precompiles[usage.Address] = &input.PrecompileUsage{Calls: usage.Calls, InputBytes: usage.InputBytes}
+		}
+	}
+	return c
+}
+
+func fromReceipt(r *gethtypes.Receipt) (*receipt, error) {
+	effectiveGasPrice, err := toOptionalUint256(r.EffectiveGasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("effective gas price: %v", err)
+	}
+	blobGasPrice, err := toOptionalUint256(r.BlobGasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("blob gas price: %v", err)
+	}
+	blockNumber, err := toOptionalUint256(r.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("block number: %v", err)
+	}
+
+	v := &receipt{
+		Type:              r.Type,
+		PostState:         r.PostState,
+		Status:            r.Status,
+		CumulativeGasUsed: r.CumulativeGasUsed,
+		Bloom:             r.Bloom,
+		TxHash:            r.TxHash,
+		ContractAddress:   r.ContractAddress,
+		GasUsed:           r.GasUsed,
+		EffectiveGasPrice: effectiveGasPrice,
+		BlobGasUsed:       r.BlobGasUsed,
+		BlobGasPrice:      blobGasPrice,
+		BlockHash:         r.BlockHash,
+		BlockNumber:       blockNumber,
+		TransactionIndex:  uint64(r.TransactionIndex),
+	}
+	for _, l := range r.Logs {
+		v.Logs = append(v.Logs, log{
+			Address:     l.Address,
+			Topics:      l.Topics,
+			Data:        l.Data,
+			BlockNumber: l.BlockNumber,
+			TxHash:      l.TxHash,
+			TxIndex:     uint64(l.TxIndex),
+			BlockHash:   l.BlockHash,
+			Index:       uint64(l.Index),
+			Removed:     l.Removed,
+		})
+	}
+	return v, nil
+}
+
+func (v *receipt) toReceipt() *gethtypes.Receipt {
+	r := &gethtypes.Receipt{
+		Type:              v.Type,
+		PostState:         v.PostState,
+		Status:            v.Status,
+		CumulativeGasUsed: v.CumulativeGasUsed,
+		Bloom:             v.Bloom,
+		Logs:              make([]*gethtypes.Log, len(v.Logs)), // logs are required in receipts JSON
+		TxHash:            v.TxHash,
+		ContractAddress:   v.ContractAddress,
+		GasUsed:           v.GasUsed,
+		EffectiveGasPrice: fromOptionalUint256(v.EffectiveGasPrice),
+		BlobGasUsed:       v.BlobGasUsed,
+		BlobGasPrice:      fromOptionalUint256(v.BlobGasPrice),
+		BlockHash:         v.BlockHash,
+		BlockNumber:       fromOptionalUint256(v.BlockNumber),
+		TransactionIndex:  uint(v.TransactionIndex),
+	}
+	for i, l := range v.Logs {
+		topics := l.Topics
+		if topics == nil {
+			topics = []gethcommon.Hash{} // topics are required in logs JSON
+		}
+		r.Logs[i] = &gethtypes.Log{
+			Address:     l.Address,
+			Topics:      topics,
+			Data:        l.Data,
+			BlockNumber: l.BlockNumber,
+			TxHash:      l.TxHash,
+			TxIndex:     uint(l.TxIndex),
+			BlockHash:   l.BlockHash,
+			Index:       uint(l.Index),
+			Removed:     l.Removed,
+		}
+	}
+	return r
+}
+
+func fromTxStateDiff(d *input.TxStateDiff) (*txStateDiff, error) {
+	v := &txStateDiff{
+		BlockNumber:   d.BlockNumber,
+		Type:          []byte(d.Type),
+		TxIndex:       d.TxIndex,
+		TxHash:        d.TxHash,
+		SystemAddress: d.SystemAddress,
+	}
+	for i, a := range d.Accounts {
+		preBalance, err := toOptionalUint256(a.PreBalance)
+		if err != nil {
+			return nil, fmt.Errorf("account %d pre-balance: %v", i, err)
+		}
+		postBalance, err := toOptionalUint256(a.PostBalance)
+		if err != nil {
+			return nil, fmt.Errorf("account %d post-balance: %v", i, err)
+		}
+		v.Accounts = append(v.Accounts, accountDiff{
+			Address:      a.Address,
+			PreBalance:   preBalance,
+			PostBalance:  postBalance,
+			PreNonce:     a.PreNonce,
+			PostNonce:    a.PostNonce,
+			PreCodeHash:  a.PreCodeHash,
+			PostCodeHash: a.PostCodeHash,
+			Storage:      fromStorageDiffs(a.Storage),
+		})
+	}
+	return v, nil
+}
+
+func (v *txStateDiff) toTxStateDiff() *input.TxStateDiff {
+	d := &input.TxStateDiff{
+		BlockNumber:   v.BlockNumber,
+		Type:          input.TxStateDiffType(v.Type),
+		TxIndex:       v.TxIndex,
+		TxHash:        v.TxHash,
+		SystemAddress: v.SystemAddress,
+		Accounts:      make([]*input.AccountDiff, len(v.Accounts)), // accounts are always set in JSON
+	}
+	for i, a := range v.Accounts {
+		d.Accounts[i] = &input.AccountDiff{
+			Address:      a.Address,
+			PreBalance:   fromOptionalUint256(a.PreBalance),
+			PostBalance:  fromOptionalUint256(a.PostBalance),
+			PreNonce:     a.PreNonce,
+			PostNonce:    a.PostNonce,
+			PreCodeHash:  a.PreCodeHash,
+			PostCodeHash: a.PostCodeHash,
+			Storage:      toStorageDiffs(a.Storage),
+		}
+	}
+	return d
+}
+
+func fromPreimages(p *input.Preimages) *preimages {
+	if p == nil {
+		return nil
+	}
+
+	v := &preimages{}
+	for addr, key := range p.Accounts {
+		v.Accounts = append(v.Accounts, accountPreimage{Address: addr, Key: key})
+	}
+	sort.Slice(v.Accounts, func(i, j int) bool { return bytes.Compare(v.Accounts[i].Address[:], v.Accounts[j].Address[:]) < 0 })
+	for slot, key := range p.Slots {
+		v.Slots = append(v.Slots, slotPreimage{Slot: slot, Key: key})
+	}
+	sort.Slice(v.Slots, func(i, j int) bool { return bytes.Compare(v.Slots[i].Slot[:], v.Slots[j].Slot[:]) < 0 })
+	return v
+}
+
+func (v *preimages) toPreimages() *input.Preimages {
+	if v == nil {
+		return nil
+	}
+
+	p := &input.Preimages{}
+	if len(v.Accounts) > 0 {
+		p.Accounts = make(map[gethcommon.Address]gethcommon.Hash, len(v.Accounts))
+		for _, preimage := range v.Accounts {
+			p.Accounts[preimage.Address] = preimage.Key
+		}
+	}
+	if len(v.Slots) > 0 {
+		p.Slots = make(map[gethcommon.Hash]gethcommon.Hash, len(v.Slots))
+		for _, preimage := range v.Slots {
+			p.Slots[preimage.Slot] = preimage.Key
+		}
+	}
+	return p
+}
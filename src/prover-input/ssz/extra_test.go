@@ -0,0 +1,128 @@
+package ssz
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/kkrt-labs/go-utils/common"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testExtra() *input.Extra {
+	return &input.Extra{
+		AccessList: gethtypes.AccessList{
+			{Address: gethcommon.HexToAddress("0x123"), StorageKeys: []gethcommon.Hash{gethcommon.HexToHash("0x456")}},
+			{Address: gethcommon.HexToAddress("0x456"), StorageKeys: []gethcommon.Hash{}},
+		},
+		StateDiffs: []*input.StateDiff{
+			{
+				Address:     gethcommon.HexToAddress("0x123"),
+				PreAccount:  &input.Account{Balance: big.NewInt(100), Nonce: 1},
+				PostAccount: &input.Account{Balance: big.NewInt(200), Nonce: 2},
+				Storage:     []*input.StorageDiff{{Slot: gethcommon.HexToHash("0x1"), PostValue: gethcommon.HexToHash("0x2")}},
+			},
+			{Address: gethcommon.HexToAddress("0x456"), PostAccount: &input.Account{Balance: big.NewInt(1)}},
+		},
+		Committed: [][]byte{gethcommon.HexToHash("0x456").Bytes()},
+		PreState: map[gethcommon.Address]*input.AccountState{
+			gethcommon.HexToAddress("0x123"): {
+				Balance:     big.NewInt(100),
+				CodeHash:    gethcommon.HexToHash("0x789"),
+				Code:        []byte{0x60, 0x00},
+				Nonce:       1,
+				StorageHash: gethcommon.HexToHash("0xabc"),
+				Storage: map[gethcommon.Hash]gethcommon.Hash{
+					gethcommon.HexToHash("0x1"): gethcommon.HexToHash("0x2"),
+					gethcommon.HexToHash("0x3"): gethcommon.HexToHash("0x4"),
+				},
+			},
+			gethcommon.HexToAddress("0x456"): nil,
+		},
+		ProvingCost: &input.ProvingCost{
+			Cycles:  1_000_000,
+			Opcodes: map[string]uint64{"PUSH1": 10, "SLOAD": 2, "KECCAK256": 1},
+			Precompiles: map[gethcommon.Address]*input.PrecompileUsage{
+				gethcommon.HexToAddress("0x1"): {Calls: 1, InputBytes: 128},
+				gethcommon.HexToAddress("0x2"): {Calls: 2, InputBytes: 64},
+			},
+			KeccakBytes:  64,
+			WitnessNodes: 12,
+		},
+		Senders: []gethcommon.Address{gethcommon.HexToAddress("0xa"), gethcommon.HexToAddress("0xb")},
+		Receipts: []*gethtypes.Receipt{
+			{
+				Type:              gethtypes.DynamicFeeTxType,
+				Status:            gethtypes.ReceiptStatusSuccessful,
+				CumulativeGasUsed: 21000,
+				Logs: []*gethtypes.Log{
+					{Address: gethcommon.HexToAddress("0xc"), Topics: []gethcommon.Hash{gethcommon.HexToHash("0xd")}, Data: []byte{0x01}, BlockNumber: 1, Index: 0},
+					{Address: gethcommon.HexToAddress("0xc"), Topics: []gethcommon.Hash{}, BlockNumber: 1, Index: 1},
+				},
+				TxHash:            gethcommon.HexToHash("0x789"),
+				GasUsed:           21000,
+				EffectiveGasPrice: big.NewInt(10),
+				BlockNumber:       big.NewInt(1),
+			},
+			{
+				Type:              gethtypes.LegacyTxType,
+				PostState:         gethcommon.HexToHash("0x1").Bytes(),
+				CumulativeGasUsed: 42000,
+				Logs:              []*gethtypes.Log{},
+				TransactionIndex:  1,
+			},
+		},
+		TxStateDiffs: []*input.TxStateDiff{
+			{
+				BlockNumber: 1,
+				Type:        input.TxStateDiffTypeTransaction,
+				TxIndex:     common.Ptr(uint64(0)),
+				TxHash:      common.Ptr(gethcommon.HexToHash("0x789")),
+				Accounts: []*input.AccountDiff{
+					{Address: gethcommon.HexToAddress("0x123"), PreBalance: big.NewInt(100), PostBalance: big.NewInt(79), PreNonce: common.Ptr(uint64(1)), PostNonce: common.Ptr(uint64(2))},
+				},
+			},
+			{
+				BlockNumber:   1,
+				Type:          input.TxStateDiffTypeSystemCall,
+				SystemAddress: common.Ptr(gethcommon.HexToAddress("0xf")),
+				Accounts:      []*input.AccountDiff{},
+			},
+		},
+		Preimages: &input.Preimages{
+			Accounts: map[gethcommon.Address]gethcommon.Hash{gethcommon.HexToAddress("0x123"): gethcommon.HexToHash("0xabc")},
+			Slots:    map[gethcommon.Hash]gethcommon.Hash{gethcommon.HexToHash("0x1"): gethcommon.HexToHash("0xdef")},
+		},
+	}
+}
+
+func TestExtra(t *testing.T) {
+	in := testExtra()
+
+	v, err := fromExtra(in)
+	require.NoError(t, err)
+	b := encodeValue(reflect.ValueOf(v))
+	decoded, err := decodeValue(b, reflect.TypeOf(v), nil)
+	require.NoError(t, err)
+	assert.Equal(t, hashValue(reflect.ValueOf(v), nil), hashValue(decoded, nil))
+
+	// Compare on JSON encodings as decoded big integers and empty lists have a different representation
+	expected, err := json.Marshal(in)
+	require.NoError(t, err)
+	actual, err := json.Marshal(decoded.Interface().(*extra).toExtra())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+
+	// Maps are encoded in key order, so the encoding is deterministic
+	v2, err := fromExtra(testExtra())
+	require.NoError(t, err)
+	assert.Equal(t, b, encodeValue(reflect.ValueOf(v2)))
+
+	_, err = fromExtra(&input.Extra{StateDiffs: []*input.StateDiff{{PreAccount: &input.Account{Balance: big.NewInt(-1)}}}})
+	assert.Error(t, err, "negative balance")
+}
@@ -0,0 +1,564 @@
+// Package ssz encodes prover inputs with SimpleSerialize (SSZ) and computes their hash tree root.
+//
+// Schema:
+//
+//	ProverInput {
+//	  version: ByteList[MaxVersionSize]
+//	  blocks: List[Block, MaxBlocks]
+//	  witness: Witness
+//	  chain_config: List[ChainConfig, 1]  # empty if unset
+//	  extra: List[Extra, 1]               # empty if unset
+//	}
+//
+//	Block {
+//	  header: ByteList[MaxHeaderSize]                                 # RLP encoded header
+//	  transactions: List[ByteList[MaxTransactionSize], MaxTransactions] # EIP-2718 encoded transactions
+//	  uncles: List[ByteList[MaxHeaderSize], MaxUncles]                  # RLP encoded headers
+//	  withdrawals: List[Withdrawal, MaxWithdrawals]
+//	}
+//
+//	Withdrawal {
+//	  index: uint64
+//	  validator_index: uint64
+//	  address: ByteVector[20]
+//	  amount: uint64
+//	}
+//
+//	Witness {
+//	  state: List[ByteList[MaxNodeSize], MaxNodes]         # MPT nodes
+//	  ancestors: List[ByteList[MaxHeaderSize], MaxAncestors] # RLP encoded headers
+//	  codes: List[ByteList[MaxCodeSize], MaxCodes]
+//	}
+//
+// ChainConfig and Extra are containers mirroring params.ChainConfig and input.Extra (see chainConfig and extra),
+// where optional values are encoded as List[T, 1], big integers as uint256 and maps as lists sorted by key.
+package ssz
+
+import (
+	"fmt"
+	"reflect"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+)
+
+// Maximum sizes of the SSZ lists (they define the depth of the merkle trees)
+const (
+	MaxVersionSize         = 64
+	MaxBlocks              = 1 << 10
+	MaxHeaderSize          = 1 << 10
+	MaxTransactionSize     = 1 << 24
+	MaxTransactions        = 1 << 20
+	MaxUncles              = 1 << 4
+	MaxWithdrawals         = 1 << 16
+	MaxNodeSize            = 1 << 10
+	MaxNodes               = 1 << 24
+	MaxAncestors           = 1 << 8
+	MaxCodeSize            = 1 << 24
+	MaxCodes               = 1 << 16
+	MaxAccounts            = 1 << 20
+	MaxStorageSlots        = 1 << 24
+	MaxLogs                = 1 << 16
+	MaxLogTopics           = 4
+	MaxLogDataSize         = 1 << 24
+	MaxPostStateSize       = 32
+	MaxOpcodes             = 256
+	MaxOpcodeNameSize      = 32
+	MaxPrecompiles         = 256
+	MaxTxStateDiffs        = 1 << 20
+	MaxTxStateDiffTypeSize = 32
+)
+
+const withdrawalSize = 8 + 8 + gethcommon.AddressLength + 8
+
+// Marshal encodes a prover input in SSZ
+func Marshal(pi *input.ProverInput) ([]byte, error) {
+	v, err := fromProverInput(pi)
+	if err != nil {
+		return nil, err
+	}
+	return v.encode(), nil
+}
+
+// Unmarshal decodes a prover input from SSZ
+func Unmarshal(b []byte) (*input.ProverInput, error) {
+	v, err := decodeProverInput(b)
+	if err != nil {
+		return nil, err
+	}
+	return v.toProverInput()
+}
+
+// HashTreeRoot computes the SSZ hash tree root of a prover input
+func HashTreeRoot(pi *input.ProverInput) (gethcommon.Hash, error) {
+	v, err := fromProverInput(pi)
+	if err != nil {
+		return gethcommon.Hash{}, err
+	}
+	return v.hashTreeRoot(), nil
+}
+
+// proverInput is the SSZ representation of a prover input
+type proverInput struct {
+	version     []byte
+	blocks      []*block
+	witness     *witness
+	chainConfig *chainConfig
+	extra       *extra
+}
+
+type block struct {
+	header       []byte
+	transactions [][]byte
+	uncles       [][]byte
+	withdrawals  []*gethtypes.Withdrawal
+}
+
+type witness struct {
+	state     [][]byte
+	ancestors [][]byte
+	codes     [][]byte
+}
+
+func fromProverInput(pi *input.ProverInput) (*proverInput, error) {
+	if pi == nil {
+		return nil, fmt.Errorf("nil prover input")
+	}
+
+	v := &proverInput{
+		version: []byte(pi.Version),
+		blocks:  make([]*block, len(pi.Blocks)),
+		witness: &witness{},
+	}
+
+	for i, b := range pi.Blocks {
+		blk, err := fromBlock(b)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %v", i, err)
+		}
+		v.blocks[i] = blk
+	}
+
+	if pi.Witness != nil {
+		ancestors, err := encodeHeaders(pi.Witness.Ancestors)
+		if err != nil {
+			return nil, fmt.Errorf("witness ancestors: %v", err)
+		}
+		v.witness = &witness{
+			state:     pi.Witness.State,
+			ancestors: ancestors,
+			codes:     pi.Witness.Codes,
+		}
+	}
+
+	var err error
+	v.chainConfig, err = fromChainConfig(pi.ChainConfig)
+	if err != nil {
+		return nil, fmt.Errorf("chain config: %v", err)
+	}
+
+	v.extra, err = fromExtra(pi.Extra)
+	if err != nil {
+		return nil, fmt.Errorf("extra: %v", err)
+	}
+
+	if err := v.checkLimits(); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func fromBlock(b *input.Block) (*block, error) {
+	header, err := rlp.EncodeToBytes(b.Header)
+	if err != nil {
+		return nil, fmt.Errorf("header: %v", err)
+	}
+
+	transactions := make([][]byte, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		transactions[i], err = tx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %v", i, err)
+		}
+	}
+
+	uncles, err := encodeHeaders(b.Uncles)
+	if err != nil {
+		return nil, fmt.Errorf("uncles: %v", err)
+	}
+
+	return &block{
+		header:       header,
+		transactions: transactions,
+		uncles:       uncles,
+		withdrawals:  b.Withdrawals,
+	}, nil
+}
+
+func encodeHeaders(headers []*gethtypes.Header) ([][]byte, error) {
+	encoded := make([][]byte, len(headers))
+	for i, header := range headers {
+		b, err := rlp.EncodeToBytes(header)
+		if err != nil {
+			return nil, fmt.Errorf("header %d: %v", i, err)
+		}
+		encoded[i] = b
+	}
+	return encoded, nil
+}
+
+func decodeHeaders(encoded [][]byte) ([]*gethtypes.Header, error) {
+	headers := make([]*gethtypes.Header, len(encoded))
+	for i, b := range encoded {
+		header := new(gethtypes.Header)
+		if err := rlp.DecodeBytes(b, header); err != nil {
+			return nil, fmt.Errorf("header %d: %v", i, err)
+		}
+		headers[i] = header
+	}
+	return headers, nil
+}
+
+func (v *proverInput) toProverInput() (*input.ProverInput, error) {
+	pi := &input.ProverInput{
+		Version: string(v.version),
+		Blocks:  make([]*input.Block, len(v.blocks)),
+	}
+
+	for i, b := range v.blocks {
+		blk, err := b.toBlock()
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %v", i, err)
+		}
+		pi.Blocks[i] = blk
+	}
+
+	ancestors, err := decodeHeaders(v.witness.ancestors)
+	if err != nil {
+		return nil, fmt.Errorf("witness ancestors: %v", err)
+	}
+	pi.Witness = &input.Witness{
+		State:     v.witness.state,
+		Ancestors: ancestors,
+		Codes:     v.witness.codes,
+	}
+
+	if v.chainConfig != nil {
+		pi.ChainConfig = v.chainConfig.toChainConfig()
+	}
+
+	if v.extra != nil {
+		pi.Extra = v.extra.toExtra()
+	}
+
+	return pi, nil
+}
+
+func (b *block) toBlock() (*input.Block, error) {
+	header := new(gethtypes.Header)
+	if err := rlp.DecodeBytes(b.header, header); err != nil {
+		return nil, fmt.Errorf("header: %v", err)
+	}
+
+	transactions := make([]*gethtypes.Transaction, len(b.transactions))
+	for i, encoded := range b.transactions {
+		tx := new(gethtypes.Transaction)
+		if err := tx.UnmarshalBinary(encoded); err != nil {
+			return nil, fmt.Errorf("transaction %d: %v", i, err)
+		}
+		transactions[i] = tx
+	}
+
+	uncles, err := decodeHeaders(b.uncles)
+	if err != nil {
+		return nil, fmt.Errorf("uncles: %v", err)
+	}
+
+	// Blocks before Shanghai have no withdrawals
+	withdrawals := b.withdrawals
+	if header.WithdrawalsHash == nil && len(withdrawals) == 0 {
+		withdrawals = nil
+	}
+
+	return &input.Block{
+		Header:       header,
+		Transactions: transactions,
+		Uncles:       uncles,
+		Withdrawals:  withdrawals,
+	}, nil
+}
+
+// limitCheck is the size of a list and its SSZ limit
+type limitCheck struct {
+	name  string
+	size  int
+	limit int
+}
+
+func (v *proverInput) checkLimits() error {
+	checks := []limitCheck{
+		{"version", len(v.version), MaxVersionSize},
+		{"blocks", len(v.blocks), MaxBlocks},
+		{"witness state", len(v.witness.state), MaxNodes},
+		{"witness ancestors", len(v.witness.ancestors), MaxAncestors},
+		{"witness codes", len(v.witness.codes), MaxCodes},
+	}
+	for _, node := range v.witness.state {
+		checks = append(checks, limitCheck{"witness state node", len(node), MaxNodeSize})
+	}
+	for _, code := range v.witness.codes {
+		checks = append(checks, limitCheck{"witness code", len(code), MaxCodeSize})
+	}
+	for _, ancestor := range v.witness.ancestors {
+		checks = append(checks, limitCheck{"witness ancestor", len(ancestor), MaxHeaderSize})
+	}
+	for _, b := range v.blocks {
+		checks = append(checks,
+			limitCheck{"block header", len(b.header), MaxHeaderSize},
+			limitCheck{"block transactions", len(b.transactions), MaxTransactions},
+			limitCheck{"block uncles", len(b.uncles), MaxUncles},
+			limitCheck{"block withdrawals", len(b.withdrawals), MaxWithdrawals},
+		)
+		for _, tx := range b.transactions {
+			checks = append(checks, limitCheck{"block transaction", len(tx), MaxTransactionSize})
+		}
+		for _, uncle := range b.uncles {
+			checks = append(checks, limitCheck{"block uncle", len(uncle), MaxHeaderSize})
+		}
+	}
+
+	for _, check := range checks {
+		if check.size > check.limit {
+			return fmt.Errorf("%s exceeds SSZ limit: %d > %d", check.name, check.size, check.limit)
+		}
+	}
+	return checkValue("extra", reflect.ValueOf(v.extra), nil)
+}
+
+func (v *proverInput) encode() []byte {
+	blocks := make([][]byte, len(v.blocks))
+	for i, b := range v.blocks {
+		blocks[i] = b.encode()
+	}
+
+	return encodeContainer(
+		variableField(v.version),
+		variableField(encodeVariableList(blocks)),
+		variableField(v.witness.encode()),
+		variableField(encodeValue(reflect.ValueOf(v.chainConfig))),
+		variableField(encodeValue(reflect.ValueOf(v.extra))),
+	)
+}
+
+func decodeProverInput(b []byte) (*proverInput, error) {
+	fields, err := decodeContainer(b, 0, 0, 0, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("prover input: %v", err)
+	}
+
+	encodedBlocks, err := decodeVariableList(fields[1], MaxBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("blocks: %v", err)
+	}
+	blocks := make([]*block, len(encodedBlocks))
+	for i, encoded := range encodedBlocks {
+		blocks[i], err = decodeBlock(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %v", i, err)
+		}
+	}
+
+	w, err := decodeWitness(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("witness: %v", err)
+	}
+
+	c, err := decodeValue(fields[3], reflect.TypeOf(new(chainConfig)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("chain config: %v", err)
+	}
+
+	e, err := decodeValue(fields[4], reflect.TypeOf(new(extra)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("extra: %v", err)
+	}
+
+	return &proverInput{
+		version:     fields[0],
+		blocks:      blocks,
+		witness:     w,
+		chainConfig: c.Interface().(*chainConfig),
+		extra:       e.Interface().(*extra),
+	}, nil
+}
+
+func (v *proverInput) hashTreeRoot() gethcommon.Hash {
+	blocks := make([][chunkSize]byte, len(v.blocks))
+	for i, b := range v.blocks {
+		blocks[i] = b.hashTreeRoot()
+	}
+
+	return hashContainer(
+		hashByteList(v.version, MaxVersionSize),
+		hashList(blocks, MaxBlocks),
+		v.witness.hashTreeRoot(),
+		hashValue(reflect.ValueOf(v.chainConfig), nil),
+		hashValue(reflect.ValueOf(v.extra), nil),
+	)
+}
+
+func (b *block) encode() []byte {
+	withdrawals := make([][]byte, len(b.withdrawals))
+	for i, w := range b.withdrawals {
+		withdrawals[i] = encodeWithdrawal(w)
+	}
+
+	return encodeContainer(
+		variableField(b.header),
+		variableField(encodeVariableList(b.transactions)),
+		variableField(encodeVariableList(b.uncles)),
+		variableField(encodeFixedList(withdrawals)),
+	)
+}
+
+func decodeBlock(b []byte) (*block, error) {
+	fields, err := decodeContainer(b, 0, 0, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := decodeVariableList(fields[1], MaxTransactions)
+	if err != nil {
+		return nil, fmt.Errorf("transactions: %v", err)
+	}
+
+	uncles, err := decodeVariableList(fields[2], MaxUncles)
+	if err != nil {
+		return nil, fmt.Errorf("uncles: %v", err)
+	}
+
+	encodedWithdrawals, err := decodeFixedList(fields[3], withdrawalSize, MaxWithdrawals)
+	if err != nil {
+		return nil, fmt.Errorf("withdrawals: %v", err)
+	}
+	withdrawals := make([]*gethtypes.Withdrawal, len(encodedWithdrawals))
+	for i, encoded := range encodedWithdrawals {
+		withdrawals[i] = decodeWithdrawal(encoded)
+	}
+
+	return &block{
+		header:       fields[0],
+		transactions: transactions,
+		uncles:       uncles,
+		withdrawals:  withdrawals,
+	}, nil
+}
+
+func (b *block) hashTreeRoot() [chunkSize]byte {
+	transactions := make([][chunkSize]byte, len(b.transactions))
+	for i, tx := range b.transactions {
+		transactions[i] = hashByteList(tx, MaxTransactionSize)
+	}
+
+	uncles := make([][chunkSize]byte, len(b.uncles))
+	for i, uncle := range b.uncles {
+		uncles[i] = hashByteList(uncle, MaxHeaderSize)
+	}
+
+	withdrawals := make([][chunkSize]byte, len(b.withdrawals))
+	for i, w := range b.withdrawals {
+		withdrawals[i] = hashContainer(
+			hashUint64(w.Index),
+			hashUint64(w.Validator),
+			hashByteVector(w.Address.Bytes()),
+			hashUint64(w.Amount),
+		)
+	}
+
+	return hashContainer(
+		hashByteList(b.header, MaxHeaderSize),
+		hashList(transactions, MaxTransactions),
+		hashList(uncles, MaxUncles),
+		hashList(withdrawals, MaxWithdrawals),
+	)
+}
+
+func encodeWithdrawal(w *gethtypes.Withdrawal) []byte {
+	return encodeContainer(
+		fixedField(encodeUint64(w.Index)),
+		fixedField(encodeUint64(w.Validator)),
+		fixedField(w.Address.Bytes()),
+		fixedField(encodeUint64(w.Amount)),
+	)
+}
+
+func decodeWithdrawal(b []byte) *gethtypes.Withdrawal {
+	return &gethtypes.Withdrawal{
+		Index:     decodeUint64(b[0:8]),
+		Validator: decodeUint64(b[8:16]),
+		Address:   gethcommon.BytesToAddress(b[16 : 16+gethcommon.AddressLength]),
+		Amount:    decodeUint64(b[16+gethcommon.AddressLength:]),
+	}
+}
+
+func (w *witness) encode() []byte {
+	return encodeContainer(
+		variableField(encodeVariableList(w.state)),
+		variableField(encodeVariableList(w.ancestors)),
+		variableField(encodeVariableList(w.codes)),
+	)
+}
+
+func decodeWitness(b []byte) (*witness, error) {
+	fields, err := decodeContainer(b, 0, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := decodeVariableList(fields[0], MaxNodes)
+	if err != nil {
+		return nil, fmt.Errorf("state: %v", err)
+	}
+
+	ancestors, err := decodeVariableList(fields[1], MaxAncestors)
+	if err != nil {
+		return nil, fmt.Errorf("ancestors: %v", err)
+	}
+
+	codes, err := decodeVariableList(fields[2], MaxCodes)
+	if err != nil {
+		return nil, fmt.Errorf("codes: %v", err)
+	}
+
+	return &witness{
+		state:     state,
+		ancestors: ancestors,
+		codes:     codes,
+	}, nil
+}
+
+func (w *witness) hashTreeRoot() [chunkSize]byte {
+	state := make([][chunkSize]byte, len(w.state))
+	for i, node := range w.state {
+		state[i] = hashByteList(node, MaxNodeSize)
+	}
+
+	ancestors := make([][chunkSize]byte, len(w.ancestors))
+	for i, ancestor := range w.ancestors {
+		ancestors[i] = hashByteList(ancestor, MaxHeaderSize)
+	}
+
+	codes := make([][chunkSize]byte, len(w.codes))
+	for i, code := range w.codes {
+		codes[i] = hashByteList(code, MaxCodeSize)
+	}
+
+	return hashContainer(
+		hashList(state, MaxNodes),
+		hashList(ancestors, MaxAncestors),
+		hashList(codes, MaxCodes),
+	)
+}
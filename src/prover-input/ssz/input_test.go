@@ -0,0 +1,112 @@
+package ssz
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSSZInput returns a prover input with every SSZ encoded field set
+func newTestSSZInput() *input.ProverInput {
+	to := gethcommon.HexToAddress("0x1")
+	withdrawalsHash := gethcommon.HexToHash("0xa")
+	return &input.ProverInput{
+		Version: "1",
+		Blocks: []*input.Block{
+			{
+				Header: &gethtypes.Header{
+					Difficulty:      big.NewInt(0),
+					Number:          big.NewInt(21000000),
+					BaseFee:         big.NewInt(7),
+					WithdrawalsHash: &withdrawalsHash,
+				},
+				Transactions: []*gethtypes.Transaction{
+					gethtypes.NewTx(&gethtypes.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 3, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(10), Gas: 21000, To: &to, Value: big.NewInt(2)}),
+				},
+				Uncles:      []*gethtypes.Header{},
+				Withdrawals: []*gethtypes.Withdrawal{{Index: 1, Validator: 2, Address: to, Amount: 3}},
+			},
+		},
+		Witness: &input.Witness{
+			State:     [][]byte{{0x01, 0x02}, {0x03}},
+			Ancestors: []*gethtypes.Header{{Difficulty: big.NewInt(0), Number: big.NewInt(20999999), BaseFee: big.NewInt(8)}},
+			Codes:     [][]byte{{0x60, 0x00}},
+		},
+		ChainConfig: params.MainnetChainConfig,
+		Extra: &input.Extra{
+			Committed: [][]byte{{0x01}},
+		},
+	}
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	pi := newTestSSZInput()
+
+	b, err := Marshal(pi)
+	require.NoError(t, err)
+	decoded, err := Unmarshal(b)
+	require.NoError(t, err)
+
+	// Compare on JSON encodings as decoded transactions have a different time
+	expected, err := json.Marshal(pi)
+	require.NoError(t, err)
+	actual, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+
+	// Re-encoding is stable
+	reencoded, err := Marshal(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, b, reencoded)
+
+	_, err = Unmarshal(b[:len(b)-1])
+	assert.Error(t, err)
+}
+
+func TestMarshalPreShanghaiBlock(t *testing.T) {
+	pi := &input.ProverInput{
+		Blocks: []*input.Block{
+			{Header: &gethtypes.Header{Difficulty: big.NewInt(0), Number: big.NewInt(1)}},
+		},
+		Witness: &input.Witness{},
+	}
+
+	b, err := Marshal(pi)
+	require.NoError(t, err)
+	decoded, err := Unmarshal(b)
+	require.NoError(t, err)
+	assert.Nil(t, decoded.Blocks[0].Withdrawals)
+	assert.Nil(t, decoded.ChainConfig)
+	assert.Nil(t, decoded.Extra)
+}
+
+func TestHashTreeRoot(t *testing.T) {
+	pi := newTestSSZInput()
+	root, err := HashTreeRoot(pi)
+	require.NoError(t, err)
+
+	// Root is preserved through encoding
+	b, err := Marshal(pi)
+	require.NoError(t, err)
+	decoded, err := Unmarshal(b)
+	require.NoError(t, err)
+	decodedRoot, err := HashTreeRoot(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, root, decodedRoot)
+
+	// Root commits to the witness
+	decoded.Witness.State[0] = []byte{0x01, 0x03}
+	modifiedRoot, err := HashTreeRoot(decoded)
+	require.NoError(t, err)
+	assert.NotEqual(t, root, modifiedRoot)
+
+	_, err = HashTreeRoot(&input.ProverInput{Version: string(make([]byte, MaxVersionSize+1))})
+	assert.Error(t, err, "version exceeds SSZ limit")
+}
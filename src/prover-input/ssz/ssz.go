@@ -0,0 +1,262 @@
+package ssz
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// This file implements the subset of SimpleSerialize (SSZ) needed to encode prover inputs
+// (see https://github.com/ethereum/consensus-specs/blob/dev/ssz/simple-serialize.md)
+
+const (
+	offsetSize = 4  // size of an offset to a variable-size part
+	chunkSize  = 32 // size of a merkleization chunk
+)
+
+// field is a field of a container
+type field struct {
+	fixed    []byte // encoding of a fixed-size field
+	variable []byte // encoding of a variable-size field
+}
+
+func fixedField(b []byte) field    { return field{fixed: b} }
+func variableField(b []byte) field { return field{variable: b} }
+
+// encodeContainer encodes a container
+// Fixed-size fields are encoded in place, variable-size fields are replaced by an offset to their encoding, appended after the fixed part
+func encodeContainer(fields ...field) []byte {
+	fixedLen := 0
+	for _, f := range fields {
+		if f.fixed != nil {
+			fixedLen += len(f.fixed)
+		} else {
+			fixedLen += offsetSize
+		}
+	}
+
+	out := make([]byte, 0, fixedLen)
+	offset := fixedLen
+	for _, f := range fields {
+		if f.fixed != nil {
+			out = append(out, f.fixed...)
+		} else {
+			out = binary.LittleEndian.AppendUint32(out, uint32(offset))
+			offset += len(f.variable)
+		}
+	}
+	for _, f := range fields {
+		if f.fixed == nil {
+			out = append(out, f.variable...)
+		}
+	}
+	return out
+}
+
+// decodeContainer splits a container encoding into the encoding of its fields
+// sizes holds the size of each fixed-size field, and 0 for variable-size fields
+func decodeContainer(b []byte, sizes ...int) ([][]byte, error) {
+	fixedLen := 0
+	for _, size := range sizes {
+		if size > 0 {
+			fixedLen += size
+		} else {
+			fixedLen += offsetSize
+		}
+	}
+	if len(b) < fixedLen {
+		return nil, fmt.Errorf("container too short: %d < %d", len(b), fixedLen)
+	}
+
+	fields := make([][]byte, len(sizes))
+	var (
+		pos       = 0
+		variables []int // index of variable-size fields
+		offsets   []int
+	)
+	for i, size := range sizes {
+		if size > 0 {
+			fields[i] = b[pos : pos+size]
+			pos += size
+			continue
+		}
+		variables = append(variables, i)
+		offsets = append(offsets, int(binary.LittleEndian.Uint32(b[pos:])))
+		pos += offsetSize
+	}
+
+	if len(offsets) == 0 {
+		if len(b) != fixedLen {
+			return nil, fmt.Errorf("invalid container size: %d != %d", len(b), fixedLen)
+		}
+		return fields, nil
+	}
+
+	if offsets[0] != fixedLen {
+		return nil, fmt.Errorf("invalid first offset: %d != %d", offsets[0], fixedLen)
+	}
+	for j, i := range variables {
+		end := len(b)
+		if j+1 < len(offsets) {
+			end = offsets[j+1]
+		}
+		if offsets[j] > end || end > len(b) {
+			return nil, fmt.Errorf("invalid offset %d", offsets[j])
+		}
+		fields[i] = b[offsets[j]:end]
+	}
+	return fields, nil
+}
+
+// encodeVariableList encodes a list of variable-size elements from the encoding of its elements
+func encodeVariableList(elems [][]byte) []byte {
+	fields := make([]field, len(elems))
+	for i, elem := range elems {
+		fields[i] = variableField(elem)
+	}
+	return encodeContainer(fields...)
+}
+
+// decodeVariableList splits the encoding of a list of variable-size elements into the encoding of its elements
+func decodeVariableList(b []byte, limit int) ([][]byte, error) {
+	if len(b) == 0 {
+		return [][]byte{}, nil
+	}
+	if len(b) < offsetSize {
+		return nil, fmt.Errorf("list too short: %d", len(b))
+	}
+
+	first := int(binary.LittleEndian.Uint32(b))
+	if first%offsetSize != 0 || first == 0 {
+		return nil, fmt.Errorf("invalid first offset: %d", first)
+	}
+	count := first / offsetSize
+	if count > limit {
+		return nil, fmt.Errorf("list too long: %d > %d", count, limit)
+	}
+
+	sizes := make([]int, count)
+	return decodeContainer(b, sizes...)
+}
+
+// encodeFixedList encodes a list of fixed-size elements from the encoding of its elements
+func encodeFixedList(elems [][]byte) []byte {
+	out := make([]byte, 0)
+	for _, elem := range elems {
+		out = append(out, elem...)
+	}
+	return out
+}
+
+// decodeFixedList splits the encoding of a list of fixed-size elements into the encoding of its elements
+func decodeFixedList(b []byte, size, limit int) ([][]byte, error) {
+	if len(b)%size != 0 {
+		return nil, fmt.Errorf("invalid list size: %d is not a multiple of %d", len(b), size)
+	}
+	count := len(b) / size
+	if count > limit {
+		return nil, fmt.Errorf("list too long: %d > %d", count, limit)
+	}
+
+	elems := make([][]byte, count)
+	for i := range elems {
+		elems[i] = b[i*size : (i+1)*size]
+	}
+	return elems, nil
+}
+
+func encodeUint64(v uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, v)
+}
+
+func decodeUint64(b []byte) uint64 {
+	return binary.LittleEndian.Uint64(b)
+}
+
+// zeroHashes[i] is the root of a tree of depth i with only zero chunks
+var zeroHashes = func() [][chunkSize]byte {
+	hashes := make([][chunkSize]byte, 65)
+	for i := 1; i < len(hashes); i++ {
+		hashes[i] = hashPair(hashes[i-1], hashes[i-1])
+	}
+	return hashes
+}()
+
+func hashPair(a, b [chunkSize]byte) [chunkSize]byte {
+	return sha256.Sum256(append(a[:], b[:]...))
+}
+
+// merkleize computes the root of a tree which leaves are the given chunks, padded with zero chunks up to limit (rounded to the next power of two)
+// If limit is 0, the tree is padded up to the number of chunks
+func merkleize(chunks [][chunkSize]byte, limit int) [chunkSize]byte {
+	if limit == 0 {
+		limit = len(chunks)
+	}
+	if len(chunks) > limit {
+		panic(fmt.Sprintf("ssz: %d chunks exceed limit %d", len(chunks), limit))
+	}
+
+	depth := 0
+	if limit > 1 {
+		depth = bits.Len(uint(limit - 1))
+	}
+	if len(chunks) == 0 {
+		return zeroHashes[depth]
+	}
+
+	layer := append(make([][chunkSize]byte, 0, len(chunks)+1), chunks...)
+	for d := 0; d < depth; d++ {
+		if len(layer)%2 == 1 {
+			layer = append(layer, zeroHashes[d])
+		}
+		next := make([][chunkSize]byte, len(layer)/2)
+		for i := range next {
+			next[i] = hashPair(layer[2*i], layer[2*i+1])
+		}
+		layer = next
+	}
+	return layer[0]
+}
+
+// mixInLength mixes the length of a list into its root
+func mixInLength(root [chunkSize]byte, length int) [chunkSize]byte {
+	var lengthChunk [chunkSize]byte
+	binary.LittleEndian.PutUint64(lengthChunk[:], uint64(length))
+	return hashPair(root, lengthChunk)
+}
+
+// pack packs bytes into chunks (the last chunk is right-padded with zeros)
+func pack(b []byte) [][chunkSize]byte {
+	chunks := make([][chunkSize]byte, (len(b)+chunkSize-1)/chunkSize)
+	for i := range chunks {
+		copy(chunks[i][:], b[i*chunkSize:])
+	}
+	return chunks
+}
+
+func hashUint64(v uint64) [chunkSize]byte {
+	var chunk [chunkSize]byte
+	binary.LittleEndian.PutUint64(chunk[:], v)
+	return chunk
+}
+
+// hashByteVector computes the root of a fixed-size byte vector
+func hashByteVector(b []byte) [chunkSize]byte {
+	return merkleize(pack(b), 0)
+}
+
+// hashByteList computes the root of a byte list of the given maximum size
+func hashByteList(b []byte, maxSize int) [chunkSize]byte {
+	return mixInLength(merkleize(pack(b), (maxSize+chunkSize-1)/chunkSize), len(b))
+}
+
+// hashList computes the root of a list of composite elements from the roots of its elements
+func hashList(roots [][chunkSize]byte, limit int) [chunkSize]byte {
+	return mixInLength(merkleize(roots, limit), len(roots))
+}
+
+// hashContainer computes the root of a container from the roots of its fields
+func hashContainer(roots ...[chunkSize]byte) [chunkSize]byte {
+	return merkleize(roots, 0)
+}
@@ -0,0 +1,82 @@
+package ssz
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer(t *testing.T) {
+	encoded := encodeContainer(
+		fixedField(encodeUint64(1)),
+		variableField([]byte{0x01, 0x02}),
+		fixedField([]byte{0x03}),
+		variableField(nil),
+		variableField([]byte{0x04}),
+	)
+	assert.Equal(t, []byte{
+		0x01, 0, 0, 0, 0, 0, 0, 0, // uint64
+		21, 0, 0, 0, // offset
+		0x03,        // byte
+		23, 0, 0, 0, // offset
+		23, 0, 0, 0, // offset
+		0x01, 0x02, 0x04,
+	}, encoded)
+
+	fields, err := decodeContainer(encoded, 8, 0, 1, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{encodeUint64(1), {0x01, 0x02}, {0x03}, {}, {0x04}}, fields)
+
+	_, err = decodeContainer(encoded[:10], 8, 0, 1, 0, 0)
+	assert.Error(t, err, "container too short")
+
+	_, err = decodeContainer(append([]byte{0x01, 0, 0, 0}, encoded[4:]...), 0, 0, 0, 0, 0)
+	assert.Error(t, err, "invalid first offset")
+}
+
+func TestLists(t *testing.T) {
+	elems := [][]byte{{0x01}, {}, {0x02, 0x03}}
+	decoded, err := decodeVariableList(encodeVariableList(elems), 3)
+	require.NoError(t, err)
+	assert.Equal(t, elems, decoded)
+
+	_, err = decodeVariableList(encodeVariableList(elems), 2)
+	assert.Error(t, err, "list too long")
+
+	decoded, err = decodeVariableList(encodeVariableList(nil), 3)
+	require.NoError(t, err)
+	assert.Empty(t, decoded)
+
+	elems = [][]byte{{0x01, 0x02}, {0x03, 0x04}}
+	decoded, err = decodeFixedList(encodeFixedList(elems), 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, elems, decoded)
+
+	_, err = decodeFixedList([]byte{0x01, 0x02, 0x03}, 2, 2)
+	assert.Error(t, err, "invalid list size")
+}
+
+func TestMerkleize(t *testing.T) {
+	var a, b, c [chunkSize]byte
+	a[0], b[0], c[0] = 1, 2, 3
+
+	assert.Equal(t, a, merkleize([][chunkSize]byte{a}, 0))
+	assert.Equal(t, hashPair(a, b), merkleize([][chunkSize]byte{a, b}, 0))
+	assert.Equal(t, hashPair(hashPair(a, b), hashPair(c, zeroHashes[0])), merkleize([][chunkSize]byte{a, b, c}, 0))
+	assert.Equal(t, hashPair(hashPair(a, zeroHashes[0]), zeroHashes[1]), merkleize([][chunkSize]byte{a}, 4))
+	assert.Equal(t, zeroHashes[3], merkleize(nil, 8))
+
+	// zeroHashes[1] is the hash of two zero chunks
+	assert.Equal(t, [chunkSize]byte(sha256.Sum256(make([]byte, 2*chunkSize))), zeroHashes[1])
+}
+
+func TestHashByteList(t *testing.T) {
+	// A byte list of up to 64 bytes is packed into 2 chunks, and its length is mixed in
+	var chunk [chunkSize]byte
+	chunk[0], chunk[1] = 0x01, 0x02
+	assert.Equal(t, mixInLength(hashPair(chunk, zeroHashes[0]), 2), hashByteList([]byte{0x01, 0x02}, 64))
+
+	assert.NotEqual(t, hashByteList([]byte{0x01, 0x02}, 64), hashByteList([]byte{0x01, 0x02, 0x00}, 64))
+}
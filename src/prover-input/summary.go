@@ -0,0 +1,292 @@
+package input
+
+import (
+	"fmt"
+	"sort"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/trie"
+)
+
+// Summary is a summary of the content of a prover input
+type Summary struct {
+	Version            string             `json:"version"`
+	FirstBlock         uint64             `json:"firstBlock"`
+	LastBlock          uint64             `json:"lastBlock"`
+	Transactions       int                `json:"transactions"`
+	TransactionsByType map[string]int     `json:"transactionsByType"`
+	Witness            *WitnessSummary    `json:"witness"`
+	LargestContracts   []*ContractSummary `json:"largestContracts"`
+	Ancestors          int                `json:"ancestors"`     // Number of ancestor headers
+	AncestorDepth      uint64             `json:"ancestorDepth"` // Distance between the first block and its oldest ancestor
+	Extra              []string           `json:"extra"`         // Extra sections present
+}
+
+// WitnessSummary is a summary of the witness of a prover input
+type WitnessSummary struct {
+	Nodes        int                   `json:"nodes"`
+	Bytes        int                   `json:"bytes"`
+	AccountTrie  *TrieSummary          `json:"accountTrie"`
+	StorageTries []*StorageTrieSummary `json:"storageTries"` // Storage tries, largest first
+	Unreachable  *TrieSummary          `json:"unreachable"`  // Nodes that are not reachable from the parent state root
+	Codes        int                   `json:"codes"`
+	CodeBytes    int                   `json:"codeBytes"`
+}
+
+// TrieSummary is the number and size of the nodes of a trie
+type TrieSummary struct {
+	Nodes int `json:"nodes"`
+	Bytes int `json:"bytes"`
+}
+
+// StorageTrieSummary is the number and size of the nodes of the storage trie of an account
+type StorageTrieSummary struct {
+	Account gethcommon.Hash     `json:"account"`           // Key of the account in the account trie (i.e. keccak(address))
+	Address *gethcommon.Address `json:"address,omitempty"` // Address of the account, if known
+	TrieSummary
+}
+
+// ContractSummary is the size of a contract code
+type ContractSummary struct {
+	CodeHash  gethcommon.Hash      `json:"codeHash"`
+	Size      int                  `json:"size"`
+	Addresses []gethcommon.Address `json:"addresses,omitempty"` // Addresses of the accounts having the code, if known
+}
+
+// Summarize computes the summary of a prover input
+//
+// Witness nodes are attributed to the account trie or to a storage trie by walking the tries from the parent state root.
+// Addresses are resolved from the addresses appearing in the prover input (transactions, extra data), so they may be unknown.
+func Summarize(in *ProverInput) (*Summary, error) {
+	s := &Summary{
+		Version:            in.Version,
+		TransactionsByType: make(map[string]int),
+		Witness: &WitnessSummary{
+			AccountTrie: new(TrieSummary),
+			Unreachable: new(TrieSummary),
+		},
+		Extra: extraSections(in.Extra),
+	}
+
+	for _, block := range in.Blocks {
+		if block == nil || block.Header == nil {
+			return nil, fmt.Errorf("missing block header")
+		}
+		for _, tx := range block.Transactions {
+			s.Transactions++
+			s.TransactionsByType[txTypeName(tx.Type())]++
+		}
+	}
+	if len(in.Blocks) > 0 {
+		s.FirstBlock = in.Blocks[0].Header.Number.Uint64()
+		s.LastBlock = in.Blocks[len(in.Blocks)-1].Header.Number.Uint64()
+	}
+
+	if in.Witness == nil {
+		return s, nil
+	}
+
+	s.Ancestors = len(in.Witness.Ancestors)
+	for _, ancestor := range in.Witness.Ancestors {
+		if depth := s.FirstBlock - ancestor.Number.Uint64(); depth > s.AncestorDepth {
+			s.AncestorDepth = depth
+		}
+	}
+
+	addresses := knownAddresses(in)
+	if err := summarizeState(s.Witness, in, addresses); err != nil {
+		return nil, err
+	}
+	s.LargestContracts = summarizeCodes(s.Witness, in)
+
+	return s, nil
+}
+
+func summarizeState(ws *WitnessSummary, in *ProverInput, addresses map[gethcommon.Hash]gethcommon.Address) error {
+	nodes := make(map[gethcommon.Hash][]byte, len(in.Witness.State))
+	for _, node := range in.Witness.State {
+		nodes[crypto.Keccak256Hash(node)] = node
+	}
+
+	var locations map[gethcommon.Hash]*trie.NodeLocation
+	if parent := parentHeader(in); parent != nil {
+		var err error
+		if locations, err = trie.NodeLocations(parent.Root, nodes); err != nil {
+			return err
+		}
+	}
+
+	storageTries := make(map[gethcommon.Hash]*StorageTrieSummary)
+	for hash, node := range nodes {
+		ws.Nodes++
+		ws.Bytes += len(node)
+
+		location, ok := locations[hash]
+		switch {
+		case !ok:
+			ws.Unreachable.add(node)
+		case location.IsAccountTrie():
+			ws.AccountTrie.add(node)
+		default:
+			storageTrie, ok := storageTries[location.Owner]
+			if !ok {
+				storageTrie = &StorageTrieSummary{Account: location.Owner}
+				if addr, ok := addresses[location.Owner]; ok {
+					storageTrie.Address = &addr
+				}
+				storageTries[location.Owner] = storageTrie
+			}
+			storageTrie.add(node)
+		}
+	}
+
+	for _, storageTrie := range storageTries {
+		ws.StorageTries = append(ws.StorageTries, storageTrie)
+	}
+	sort.Slice(ws.StorageTries, func(i, j int) bool {
+		a, b := ws.StorageTries[i], ws.StorageTries[j]
+		if a.Bytes != b.Bytes {
+			return a.Bytes > b.Bytes
+		}
+		return a.Account.Cmp(b.Account) < 0
+	})
+
+	return nil
+}
+
+// summarizeCodes returns the contracts of the witness, largest first
+func summarizeCodes(ws *WitnessSummary, in *ProverInput) []*ContractSummary {
+	codeAddresses := make(map[gethcommon.Hash][]gethcommon.Address)
+	if in.Extra != nil {
+		for addr, account := range in.Extra.PreState {
+			if account != nil {
+				codeAddresses[account.CodeHash] = append(codeAddresses[account.CodeHash], addr)
+			}
+		}
+	}
+
+	contracts := make([]*ContractSummary, 0, len(in.Witness.Codes))
+	for _, code := range in.Witness.Codes {
+		ws.Codes++
+		ws.CodeBytes += len(code)
+
+		codeHash := crypto.Keccak256Hash(code)
+		addrs := codeAddresses[codeHash]
+		sort.Slice(addrs, func(i, j int) bool { return addrs[i].Cmp(addrs[j]) < 0 })
+		contracts = append(contracts, &ContractSummary{CodeHash: codeHash, Size: len(code), Addresses: addrs})
+	}
+	sort.Slice(contracts, func(i, j int) bool {
+		if contracts[i].Size != contracts[j].Size {
+			return contracts[i].Size > contracts[j].Size
+		}
+		return contracts[i].CodeHash.Cmp(contracts[j].CodeHash) < 0
+	})
+
+	return contracts
+}
+
+func (t *TrieSummary) add(node []byte) {
+	t.Nodes++
+	t.Bytes += len(node)
+}
+
+// parentHeader returns the parent header of the first block, if present in the ancestors
+func parentHeader(in *ProverInput) *gethtypes.Header {
+	if len(in.Blocks) == 0 || in.Blocks[0] == nil || in.Blocks[0].Header == nil {
+		return nil
+	}
+	for _, ancestor := range in.Witness.Ancestors {
+		if ancestor.Hash() == in.Blocks[0].Header.ParentHash {
+			return ancestor
+		}
+	}
+	return nil
+}
+
+// knownAddresses returns the addresses appearing in the prover input, indexed by their key in the account trie
+func knownAddresses(in *ProverInput) map[gethcommon.Hash]gethcommon.Address {
+	addresses := make(map[gethcommon.Hash]gethcommon.Address)
+	add := func(addr gethcommon.Address) {
+		addresses[crypto.Keccak256Hash(addr.Bytes())] = addr
+	}
+
+	for _, block := range in.Blocks {
+		for _, tx := range block.Transactions {
+			if tx.To() != nil {
+				add(*tx.To())
+			}
+			for _, tuple := range tx.AccessList() {
+				add(tuple.Address)
+			}
+		}
+		for _, withdrawal := range block.Withdrawals {
+			add(withdrawal.Address)
+		}
+	}
+
+	if in.Extra == nil {
+		return addresses
+	}
+	for _, tuple := range in.Extra.AccessList {
+		add(tuple.Address)
+	}
+	for addr := range in.Extra.PreState {
+		add(addr)
+	}
+	for _, addr := range in.Extra.Senders {
+		add(addr)
+	}
+	if in.Extra.Preimages != nil {
+		for addr, key := range in.Extra.Preimages.Accounts {
+			addresses[key] = addr
+		}
+	}
+	return addresses
+}
+
+// extraSections returns the names of the non-empty extra sections
+func extraSections(extra *Extra) []string {
+	if extra == nil {
+		return nil
+	}
+
+	var sections []string
+	for _, section := range []struct {
+		name    string
+		present bool
+	}{
+		{"accessList", len(extra.AccessList) > 0},
+		{"committed", len(extra.Committed) > 0},
+		{"stateDiffs", len(extra.StateDiffs) > 0},
+		{"preState", len(extra.PreState) > 0},
+		{"provingCost", extra.ProvingCost != nil},
+		{"senders", len(extra.Senders) > 0},
+		{"receipts", len(extra.Receipts) > 0},
+		{"txStateDiffs", len(extra.TxStateDiffs) > 0},
+		{"preimages", extra.Preimages != nil},
+	} {
+		if section.present {
+			sections = append(sections, section.name)
+		}
+	}
+	return sections
+}
+
+func txTypeName(txType uint8) string {
+	switch txType {
+	case gethtypes.LegacyTxType:
+		return "legacy"
+	case gethtypes.AccessListTxType:
+		return "accessList"
+	case gethtypes.DynamicFeeTxType:
+		return "dynamicFee"
+	case gethtypes.BlobTxType:
+		return "blob"
+	case gethtypes.SetCodeTxType:
+		return "setCode"
+	default:
+		return fmt.Sprintf("0x%02x", txType)
+	}
+}
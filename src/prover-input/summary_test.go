@@ -0,0 +1,110 @@
+package input
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/hashdb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testEOA      = gethcommon.HexToAddress("0x01")
+	testContract = gethcommon.HexToAddress("0x02")
+	testCode     = []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	testOrphan   = []byte{0xc2, 0x80, 0x80}
+)
+
+// newTestProverInput creates a prover input of block 10, which witness contains the state of an EOA and of a contract
+// having storage, an orphan node, and 2 ancestors
+func newTestProverInput(t *testing.T) *ProverInput {
+	// Create a state with an EOA and a contract having storage
+	db := rawdb.NewMemoryDatabase()
+	tdb := triedb.NewDatabase(db, &triedb.Config{HashDB: &hashdb.Config{}})
+	statedb, err := gethstate.New(gethcommon.Hash{}, gethstate.NewDatabase(tdb, nil))
+	require.NoError(t, err)
+	statedb.SetBalance(testEOA, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	statedb.SetCode(testContract, testCode)
+	for i := int64(1); i <= 20; i++ {
+		statedb.SetState(testContract, gethcommon.BigToHash(big.NewInt(i)), gethcommon.BigToHash(big.NewInt(i)))
+	}
+	root, err := statedb.Commit(0, false, false)
+	require.NoError(t, err)
+	require.NoError(t, tdb.Commit(root, false))
+
+	var state [][]byte
+	it := db.NewIterator(nil, nil)
+	for it.Next() {
+		if len(it.Key()) == gethcommon.HashLength {
+			state = append(state, gethcommon.CopyBytes(it.Value()))
+		}
+	}
+	it.Release()
+	state = append(state, testOrphan)
+
+	grandParent := &gethtypes.Header{Number: big.NewInt(8), Difficulty: big.NewInt(0)}
+	parent := &gethtypes.Header{Number: big.NewInt(9), Difficulty: big.NewInt(0), ParentHash: grandParent.Hash(), Root: root}
+	return &ProverInput{
+		Version: SchemaVersion,
+		Blocks: []*Block{
+			{
+				Header: &gethtypes.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0), ParentHash: parent.Hash()},
+				Transactions: []*gethtypes.Transaction{
+					gethtypes.NewTx(&gethtypes.LegacyTx{To: &testContract}),
+					gethtypes.NewTx(&gethtypes.DynamicFeeTx{To: &testContract}),
+					gethtypes.NewTx(&gethtypes.DynamicFeeTx{To: &testEOA}),
+				},
+			},
+		},
+		Witness: &Witness{
+			State:     state,
+			Ancestors: []*gethtypes.Header{parent, grandParent},
+			Codes:     [][]byte{testCode, {0x00}},
+		},
+		Extra: &Extra{
+			PreState: map[gethcommon.Address]*AccountState{
+				testContract: {CodeHash: crypto.Keccak256Hash(testCode)},
+			},
+			ProvingCost: &ProvingCost{Cycles: 1},
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	in := newTestProverInput(t)
+
+	s, err := Summarize(in)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(10), s.FirstBlock)
+	assert.Equal(t, uint64(10), s.LastBlock)
+	assert.Equal(t, 3, s.Transactions)
+	assert.Equal(t, map[string]int{"legacy": 1, "dynamicFee": 2}, s.TransactionsByType)
+	assert.Equal(t, 2, s.Ancestors)
+	assert.Equal(t, uint64(2), s.AncestorDepth)
+	assert.Equal(t, []string{"preState", "provingCost"}, s.Extra)
+
+	w := s.Witness
+	assert.Equal(t, len(in.Witness.State), w.Nodes)
+	assert.Equal(t, &TrieSummary{Nodes: 1, Bytes: len(testOrphan)}, w.Unreachable)
+	assert.Positive(t, w.AccountTrie.Nodes)
+	require.Len(t, w.StorageTries, 1)
+	assert.Equal(t, crypto.Keccak256Hash(testContract.Bytes()), w.StorageTries[0].Account)
+	assert.Equal(t, &testContract, w.StorageTries[0].Address)
+	assert.Equal(t, w.Nodes, w.AccountTrie.Nodes+w.StorageTries[0].Nodes+w.Unreachable.Nodes)
+
+	assert.Equal(t, 2, w.Codes)
+	assert.Equal(t, len(testCode)+1, w.CodeBytes)
+	require.Len(t, s.LargestContracts, 2)
+	assert.Equal(t, &ContractSummary{CodeHash: crypto.Keccak256Hash(testCode), Size: len(testCode), Addresses: []gethcommon.Address{testContract}}, s.LargestContracts[0])
+	assert.Equal(t, 1, s.LargestContracts[1].Size)
+}
@@ -0,0 +1,119 @@
+package input
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SchemaVersion is the semantic version of the prover input schema stamped on generated prover inputs
+//
+// - major is bumped on breaking changes of the layout (consumers can not read inputs of another major version)
+// - minor is bumped on backward compatible additions (e.g. new extra data)
+// - patch is bumped on changes that do not affect the layout
+const SchemaVersion = "1.0.0"
+
+// Migration upgrades a prover input from a schema version to a later one
+type Migration struct {
+	From    string
+	To      string
+	Migrate func(in *ProverInput) error
+}
+
+// migrations holds the registered migrations indexed by the version they upgrade from
+var migrations = make(map[string]*Migration)
+
+func init() {
+	// Prover inputs generated before versioning was introduced are not stamped, but have the layout of 1.0.0
+	RegisterMigration(&Migration{
+		From:    "",
+		To:      "1.0.0",
+		Migrate: func(_ *ProverInput) error { return nil },
+	})
+}
+
+// RegisterMigration registers a migration
+//
+// It panics if a migration is already registered for the same version, or if the migration does not upgrade the version
+func RegisterMigration(m *Migration) {
+	if _, ok := migrations[m.From]; ok {
+		panic(fmt.Sprintf("migration from version %q already registered", m.From))
+	}
+	if m.From != "" && compareVersions(m.From, m.To) >= 0 {
+		panic(fmt.Sprintf("invalid migration from version %q to %q", m.From, m.To))
+	}
+	migrations[m.From] = m
+}
+
+// Migrate upgrades in place a prover input to the current schema version by applying registered migrations in sequence
+//
+// It returns an error if the resulting version is not compatible with the current schema version
+func Migrate(in *ProverInput) error {
+	for in.Version != SchemaVersion {
+		m, ok := migrations[in.Version]
+		if !ok {
+			break
+		}
+		if err := m.Migrate(in); err != nil {
+			return fmt.Errorf("failed to migrate prover input from version %q to %q: %v", m.From, m.To, err)
+		}
+		in.Version = m.To
+	}
+
+	return CheckVersion(in.Version)
+}
+
+// CheckVersion checks that a schema version is compatible with the current schema version
+//
+// Versions are compatible if they have the same major version. Data added by newer minor versions is ignored.
+func CheckVersion(version string) error {
+	v, err := parseVersion(version)
+	if err != nil {
+		return err
+	}
+	current, _ := parseVersion(SchemaVersion)
+	if v[0] != current[0] {
+		return fmt.Errorf("incompatible prover input version %q (expected %d.x.x)", version, current[0])
+	}
+	return nil
+}
+
+// parseVersion parses a MAJOR.MINOR.PATCH version
+func parseVersion(version string) ([3]uint64, error) {
+	var v [3]uint64
+	parts := strings.Split(version, ".")
+	if len(parts) != len(v) {
+		return v, fmt.Errorf("invalid prover input version %q", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("invalid prover input version %q", version)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+// compareVersions compares two versions, invalid versions are lower than any valid version
+func compareVersions(a, b string) int {
+	va, errA := parseVersion(a)
+	vb, errB := parseVersion(b)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	for i := range va {
+		switch {
+		case va[i] < vb[i]:
+			return -1
+		case va[i] > vb[i]:
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package input
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	RegisterMigration(&Migration{
+		From: "0.1.0",
+		To:   "0.2.0",
+		Migrate: func(in *ProverInput) error {
+			in.Extra = &Extra{}
+			return nil
+		},
+	})
+	RegisterMigration(&Migration{
+		From:    "0.2.0",
+		To:      SchemaVersion,
+		Migrate: func(_ *ProverInput) error { return nil },
+	})
+	RegisterMigration(&Migration{
+		From:    "0.3.0",
+		To:      SchemaVersion,
+		Migrate: func(_ *ProverInput) error { return fmt.Errorf("test error") },
+	})
+	defer func() {
+		delete(migrations, "0.1.0")
+		delete(migrations, "0.2.0")
+		delete(migrations, "0.3.0")
+	}()
+
+	testCases := []struct {
+		desc            string
+		version         string
+		expectedVersion string
+		expectedErr     bool
+	}{
+		{desc: "current version", version: SchemaVersion, expectedVersion: SchemaVersion},
+		{desc: "unversioned", version: "", expectedVersion: SchemaVersion},
+		{desc: "migration chain", version: "0.1.0", expectedVersion: SchemaVersion},
+		{desc: "failing migration", version: "0.3.0", expectedVersion: "0.3.0", expectedErr: true},
+		{desc: "newer minor version", version: "1.3.0", expectedVersion: "1.3.0"},
+		{desc: "newer major version", version: "2.0.0", expectedVersion: "2.0.0", expectedErr: true},
+		{desc: "older version without migration", version: "0.0.1", expectedVersion: "0.0.1", expectedErr: true},
+		{desc: "invalid version", version: "v1", expectedVersion: "v1", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			in := &ProverInput{Version: tc.version}
+			err := Migrate(in)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedVersion, in.Version)
+		})
+	}
+}
+
+func TestRegisterMigrationPanics(t *testing.T) {
+	assert.Panics(t, func() { RegisterMigration(&Migration{From: "", To: SchemaVersion}) }, "already registered")
+	assert.Panics(t, func() { RegisterMigration(&Migration{From: "1.1.0", To: "1.0.0"}) }, "downgrade")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	gethstate "github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/ethereum/go-ethereum/triedb/hashdb"
 	"github.com/kkrt-labs/go-utils/app/svc"
@@ -60,53 +61,83 @@ func (e *executor) Execute(ctx context.Context, in *input.ProverInput) (*core.Pr
 	return res, err
 }
 
+// execute replays the prover input blocks sequentially, validating the post-state of each block.
+// It returns the result of the last block execution.
 func (e *executor) execute(ctx context.Context, in *input.ProverInput) (*core.ProcessResult, error) {
-	stateDB, hc, err := e.prepareStateDBAndChain(in)
-	if err != nil {
-		return nil, fmt.Errorf("execute: failed to prepare state db and chain: %v", err)
-	}
+	return e.executeOnDB(ctx, in, rawdb.NewMemoryDatabase(), nil)
+}
 
-	parentHeader := hc.GetHeader(in.Blocks[0].Header.ParentHash, in.Blocks[0].Header.Number.Uint64()-1)
-	if parentHeader == nil {
-		return nil, fmt.Errorf("execute: missing parent header for block %q", in.Blocks[0].Header.Number.String())
+// executeOnDB executes the prover input using db as the underlying key-value database.
+// If provided, onExecuted is called with the execution parameters after every successful block execution.
+func (e *executor) executeOnDB(ctx context.Context, in *input.ProverInput, db ethdb.Database, onExecuted func(*evm.ExecParams)) (*core.ProcessResult, error) {
+	if len(in.Blocks) == 0 {
+		return nil, fmt.Errorf("execute: no block to execute")
 	}
 
-	preState, err := gethstate.New(parentHeader.Root, stateDB)
+	stateDB, hc, err := e.prepareStateDBAndChain(in, db)
 	if err != nil {
-		return nil, fmt.Errorf("execute: failed to create pre-state from parent root %v: %v", parentHeader.Root, err)
-	}
-
-	execParams := &evm.ExecParams{
-		VMConfig: &vm.Config{
-			StatelessSelfValidation: true,
-		},
-		Block:    in.Blocks[0].Block(),
-		Validate: true, // We validate the block execution to ensure the result and final state are correct
-		Chain:    hc,
-		State:    preState,
+		return nil, fmt.Errorf("execute: failed to prepare state db and chain: %v", err)
 	}
 
-	res, err := e.evm.Execute(ctx, execParams)
-	if err != nil {
-		return res, fmt.Errorf("execute: %v", err)
+	// Blocks are executed as part of the range of blocks of the prover input (e.g. so traces are stored next to it)
+	ctx = evm.ContextWithBlockRange(ctx, in.Blocks[0].Header.Number.Uint64(), in.Blocks[len(in.Blocks)-1].Header.Number.Uint64())
+
+	var res *core.ProcessResult
+	for i, block := range in.Blocks {
+		parentHeader := hc.GetHeader(block.Header.ParentHash, block.Header.Number.Uint64()-1)
+		if parentHeader == nil {
+			return nil, fmt.Errorf("execute: missing parent header for block %q", block.Header.Number.String())
+		}
+
+		preState, err := gethstate.New(parentHeader.Root, stateDB)
+		if err != nil {
+			return nil, fmt.Errorf("execute: failed to create pre-state from parent root %v: %v", parentHeader.Root, err)
+		}
+
+		// Every block but the last one commits its state changes, so the next block can execute on top of it
+		isLast := i == len(in.Blocks)-1
+		execParams := &evm.ExecParams{
+			VMConfig: &vm.Config{
+				StatelessSelfValidation: true,
+			},
+			Block:    block.Block(),
+			Validate: true, // We validate the block execution to ensure the result and final state are correct
+			Commit:   !isLast,
+			Flush:    !isLast,
+			Chain:    hc,
+			State:    preState,
+		}
+
+		res, err = e.evm.Execute(ctx, execParams)
+		if err != nil {
+			return res, fmt.Errorf("execute: block %q: %v", block.Header.Number.String(), err)
+		}
+
+		if onExecuted != nil {
+			onExecuted(execParams)
+		}
 	}
 
 	return res, nil
 }
 
-func (e *executor) prepareStateDBAndChain(in *input.ProverInput) (gethstate.Database, *core.HeaderChain, error) {
+func (e *executor) prepareStateDBAndChain(in *input.ProverInput, db ethdb.Database) (gethstate.Database, *ethereum.Chain, error) {
 	// --- Create in Memory database ---
 	stateDB := gethstate.NewDatabase(
-		triedb.NewDatabase(rawdb.NewMemoryDatabase(), &triedb.Config{HashDB: &hashdb.Config{}}),
+		triedb.NewDatabase(db, &triedb.Config{HashDB: &hashdb.Config{}}),
 		nil,
 	) // We use a modified trie database to track trie modifications
 
 	// -- Pre-populates database with Witness data ---
 	ethereum.WriteHeaders(stateDB.TrieDB().Disk(), in.Witness.Ancestors...)
+	for _, block := range in.Blocks[:len(in.Blocks)-1] {
+		// Headers of the blocks within the range are parents of the subsequent blocks
+		ethereum.WriteHeaders(stateDB.TrieDB().Disk(), block.Header)
+	}
 	ethereum.WriteCodes(stateDB.TrieDB().Disk(), in.Witness.Codes...)
 	ethereum.WriteNodesToHashDB(stateDB.TrieDB().Disk(), in.Witness.State...)
 
-	hc, err := ethereum.NewChain(in.ChainConfig, stateDB)
+	hc, err := ethereum.NewChain(in.ChainConfig, stateDB.TrieDB().Disk())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create chain: %v", err)
 	}
@@ -9,19 +9,30 @@ import (
 type Include int
 
 const (
-	expAccessList = 0
-	expPreState   = 1
-	expStateDiffs = 2
-	expCommitted  = 3
+	expAccessList   = 0
+	expPreState     = 1
+	expStateDiffs   = 2
+	expCommitted    = 3
+	expProvingCost  = 4
+	expSenders      = 5
+	expReceipts     = 6
+	expTxStateDiffs = 7
+	expPreimages    = 8
 )
 
 const (
-	IncludeNone       Include = 0
-	IncludeAccessList Include = 1 << expAccessList
-	IncludePreState   Include = 1 << expPreState
-	IncludeStateDiffs Include = 1 << expStateDiffs
-	IncludeCommitted  Include = 1 << expCommitted
-	IncludeAll        Include = IncludeAccessList | IncludePreState | IncludeStateDiffs | IncludeCommitted
+	IncludeNone         Include = 0
+	IncludeAccessList   Include = 1 << expAccessList
+	IncludePreState     Include = 1 << expPreState
+	IncludeStateDiffs   Include = 1 << expStateDiffs
+	IncludeCommitted    Include = 1 << expCommitted
+	IncludeProvingCost  Include = 1 << expProvingCost
+	IncludeSenders      Include = 1 << expSenders
+	IncludeReceipts     Include = 1 << expReceipts
+	IncludeTxStateDiffs Include = 1 << expTxStateDiffs
+	IncludePreimages    Include = 1 << expPreimages
+	// IncludeAll includes the default extended data. Other data (provingCost, senders, receipts, txStateDiffs, preimages) is opt-in and must be included explicitly.
+	IncludeAll Include = IncludeAccessList | IncludePreState | IncludeStateDiffs | IncludeCommitted
 )
 
 var ValidIncludes = []Include{
@@ -30,6 +41,11 @@ var ValidIncludes = []Include{
 	IncludePreState,
 	IncludeStateDiffs,
 	IncludeCommitted,
+	IncludeProvingCost,
+	IncludeSenders,
+	IncludeReceipts,
+	IncludeTxStateDiffs,
+	IncludePreimages,
 	IncludeAll,
 }
 
@@ -42,18 +58,28 @@ var (
 		"preState",
 		"stateDiffs",
 		"committed",
+		"provingCost",
+		"senders",
+		"receipts",
+		"txStateDiffs",
+		"preimages",
 		includeAllStr,
 		includeNoneStr,
 	}
 )
 
 var includesStrReverse = map[string]Include{
-	includesStr[expAccessList]: IncludeAccessList,
-	includesStr[expPreState]:   IncludePreState,
-	includesStr[expStateDiffs]: IncludeStateDiffs,
-	includesStr[expCommitted]:  IncludeCommitted,
-	includeAllStr:              IncludeAll,
-	includeNoneStr:             IncludeNone,
+	includesStr[expAccessList]:   IncludeAccessList,
+	includesStr[expPreState]:     IncludePreState,
+	includesStr[expStateDiffs]:   IncludeStateDiffs,
+	includesStr[expCommitted]:    IncludeCommitted,
+	includesStr[expProvingCost]:  IncludeProvingCost,
+	includesStr[expSenders]:      IncludeSenders,
+	includesStr[expReceipts]:     IncludeReceipts,
+	includesStr[expTxStateDiffs]: IncludeTxStateDiffs,
+	includesStr[expPreimages]:    IncludePreimages,
+	includeAllStr:                IncludeAll,
+	includeNoneStr:               IncludeNone,
 }
 
 func (opt Include) String() string {
@@ -61,13 +87,14 @@ func (opt Include) String() string {
 		return includeNoneStr
 	}
 
-	if opt.Include(IncludeAll) {
-		return includeAllStr
-	}
-
 	inclusions := make([]string, 0)
+	all := opt.Include(IncludeAll)
+	if all {
+		inclusions = append(inclusions, includeAllStr)
+	}
 	for i, inclStr := range includesStr[:len(includesStr)-2] {
-		if opt.Include(Include(1 << i)) {
+		incl := Include(1 << i)
+		if opt.Include(incl) && (!all || !IncludeAll.Include(incl)) {
 			inclusions = append(inclusions, inclStr)
 		}
 	}
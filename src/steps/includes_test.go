@@ -19,11 +19,22 @@ func TestIncludesString(t *testing.T) {
 		{IncludePreState, "preState"},
 		{IncludeStateDiffs, "stateDiffs"},
 		{IncludeCommitted, "committed"},
+		{IncludeProvingCost, "provingCost"},
+		{IncludeSenders, "senders"},
+		{IncludeReceipts, "receipts"},
+		{IncludeTxStateDiffs, "txStateDiffs"},
+		{IncludePreimages, "preimages"},
 		{IncludeAccessList | IncludePreState, "accessList,preState"},
 		{IncludeAccessList | IncludePreState | IncludeStateDiffs, "accessList,preState,stateDiffs"},
 		{IncludeAccessList | IncludePreState | IncludeStateDiffs | IncludeCommitted, "all"},
-		{1 << 4, "none"},
-		{1<<4 | 1<<3, "committed"},
+		{IncludeAccessList | IncludePreState | IncludeStateDiffs | IncludeCommitted | IncludeProvingCost, "all,provingCost"},
+		{IncludeAccessList | IncludePreState | IncludeStateDiffs | IncludeCommitted | IncludeProvingCost | IncludeSenders, "all,provingCost,senders"},
+		{IncludeAccessList | IncludePreState | IncludeStateDiffs | IncludeCommitted | IncludeProvingCost | IncludeSenders | IncludeReceipts, "all,provingCost,senders,receipts"},
+		{IncludeAccessList | IncludePreState | IncludeStateDiffs | IncludeCommitted | IncludeProvingCost | IncludeSenders | IncludeReceipts | IncludeTxStateDiffs, "all,provingCost,senders,receipts,txStateDiffs"},
+		{IncludeAccessList | IncludePreState | IncludeStateDiffs | IncludeCommitted | IncludeProvingCost | IncludeSenders | IncludeReceipts | IncludeTxStateDiffs | IncludePreimages, "all,provingCost,senders,receipts,txStateDiffs,preimages"},
+		{IncludeAll | IncludeProvingCost, "all,provingCost"},
+		{1 << 9, "none"},
+		{1<<9 | 1<<3, "committed"},
 	}
 	for _, test := range tests {
 		if got := test.incl.String(); got != test.want {
@@ -45,9 +56,15 @@ func TestParseIncludes(t *testing.T) {
 		{[]string{"preState"}, IncludePreState, false},
 		{[]string{"stateDiffs"}, IncludeStateDiffs, false},
 		{[]string{"committed"}, IncludeCommitted, false},
+		{[]string{"provingCost"}, IncludeProvingCost, false},
+		{[]string{"senders"}, IncludeSenders, false},
+		{[]string{"receipts"}, IncludeReceipts, false},
+		{[]string{"txStateDiffs"}, IncludeTxStateDiffs, false},
+		{[]string{"preimages"}, IncludePreimages, false},
 		{[]string{"accessList", "preState"}, IncludeAccessList | IncludePreState, false},
 		{[]string{"accessList", "preState", "stateDiffs"}, IncludeAccessList | IncludePreState | IncludeStateDiffs, false},
 		{[]string{"all", "none"}, IncludeAll, false},
+		{[]string{"all", "provingCost"}, IncludeAll | IncludeProvingCost, false},
 		{[]string{"all", "none", "invalid"}, 0, true},
 	}
 	for _, test := range tests {
@@ -62,5 +79,5 @@ func TestParseIncludes(t *testing.T) {
 }
 
 func TestValidIncludes(t *testing.T) {
-	assert.Equal(t, "[\"none\" \"accessList\" \"preState\" \"stateDiffs\" \"committed\" \"all\"]", fmt.Sprintf("%q", ValidIncludes))
+	assert.Equal(t, "[\"none\" \"accessList\" \"preState\" \"stateDiffs\" \"committed\" \"provingCost\" \"senders\" \"receipts\" \"txStateDiffs\" \"preimages\" \"all\"]", fmt.Sprintf("%q", ValidIncludes))
 }
@@ -0,0 +1,200 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/kkrt-labs/go-utils/app/svc"
+	"github.com/kkrt-labs/go-utils/log"
+	"github.com/kkrt-labs/go-utils/tag"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/evm"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -destination=./mock/minimizer.go -package=mocksteps github.com/kkrt-labs/zk-pig/src/steps Minimizer
+
+// Minimizer is the interface for minimizing the witness of a prover input.
+// It replays the blocks against the witness and drops every state node, code and ancestor header
+// that is never resolved during the execution.
+// The minimized prover input is not re-executed: it is meant to be validated by the Executor
+// (e.g. the execute step of the generator), which avoids executing the blocks one more time.
+type Minimizer interface {
+	// Minimize returns a copy of the prover input with a minimal witness
+	Minimize(ctx context.Context, in *input.ProverInput) (*input.ProverInput, error)
+}
+
+type minimizer struct {
+	replayer *executor
+}
+
+// NewMinimizer creates a new Minimizer.
+func NewMinimizer() Minimizer {
+	return NewMinimizerFromEvm(evm.NewExecutor())
+}
+
+// NewMinimizerFromEvm creates a new Minimizer from an EVM executor.
+func NewMinimizerFromEvm(e evm.Executor) Minimizer {
+	return &minimizer{
+		replayer: &executor{evm: e},
+	}
+}
+
+// Minimize minimizes the witness of the prover input.
+func (m *minimizer) Minimize(ctx context.Context, in *input.ProverInput) (*input.ProverInput, error) {
+	log.LoggerFromContext(ctx).Info("Start minimizing prover input witness...")
+	out, err := m.minimize(ctx, in)
+	if err != nil {
+		log.LoggerFromContext(ctx).Error("Prover input witness minimization failed", zap.Error(err))
+		return nil, err
+	}
+
+	log.LoggerFromContext(ctx).Info(
+		"Prover input witness minimization succeeded",
+		zap.Int("removed.nodes", len(in.Witness.State)-len(out.Witness.State)),
+		zap.Int("removed.codes", len(in.Witness.Codes)-len(out.Witness.Codes)),
+		zap.Int("removed.ancestors", len(in.Witness.Ancestors)-len(out.Witness.Ancestors)),
+		zap.Int("bytes.saved", witnessSize(in.Witness)-witnessSize(out.Witness)),
+	)
+
+	return out, nil
+}
+
+func (m *minimizer) minimize(ctx context.Context, in *input.ProverInput) (*input.ProverInput, error) {
+	if len(in.Blocks) == 0 {
+		return nil, fmt.Errorf("minimize: no block to replay")
+	}
+
+	// --- Replay blocks against the witness, recording resolved data ---
+	db := newRecordingDB(rawdb.NewMemoryDatabase())
+	witness := newWitnessBuilder(in.Blocks[0].Header.Number.Uint64())
+	_, err := m.replayer.executeOnDB(ctx, in, db, func(params *evm.ExecParams) {
+		witness.add(params.State.Witness())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("minimize: failed to replay blocks: %v", err)
+	}
+
+	// --- Keep only resolved data ---
+	ancestors := make(map[gethcommon.Hash]struct{})
+	for _, header := range witness.ancestors {
+		ancestors[header.Hash()] = struct{}{}
+	}
+
+	minimized := &input.Witness{
+		Ancestors: make([]*gethtypes.Header, 0),
+		Codes:     make([][]byte, 0),
+		State:     make([][]byte, 0),
+	}
+	for _, header := range in.Witness.Ancestors {
+		if _, ok := ancestors[header.Hash()]; ok {
+			minimized.Ancestors = append(minimized.Ancestors, header)
+		}
+	}
+	for _, code := range in.Witness.Codes {
+		if _, ok := witness.codes[string(code)]; ok {
+			minimized.Codes = append(minimized.Codes, code)
+		}
+	}
+	for _, node := range in.Witness.State {
+		if db.hasRead(crypto.Keccak256(node)) {
+			minimized.State = append(minimized.State, node)
+		}
+	}
+
+	return &input.ProverInput{
+		Version:     in.Version,
+		Blocks:      in.Blocks,
+		Witness:     minimized,
+		ChainConfig: in.ChainConfig,
+		Extra:       in.Extra,
+	}, nil
+}
+
+// witnessSize returns the size in bytes of the witness data
+func witnessSize(w *input.Witness) int {
+	size := 0
+	for _, node := range w.State {
+		size += len(node)
+	}
+	for _, code := range w.Codes {
+		size += len(code)
+	}
+	for _, header := range w.Ancestors {
+		b, err := rlp.EncodeToBytes(header)
+		if err == nil {
+			size += len(b)
+		}
+	}
+	return size
+}
+
+// recordingDB is a key-value database that records the keys read from it
+type recordingDB struct {
+	ethdb.Database
+
+	mu    sync.Mutex
+	reads map[string]struct{}
+}
+
+func newRecordingDB(db ethdb.Database) *recordingDB {
+	return &recordingDB{
+		Database: db,
+		reads:    make(map[string]struct{}),
+	}
+}
+
+// Get retrieves the given key and records the read
+func (db *recordingDB) Get(key []byte) ([]byte, error) {
+	db.record(key)
+	return db.Database.Get(key)
+}
+
+// Has retrieves if a key is present and records the read
+func (db *recordingDB) Has(key []byte) (bool, error) {
+	db.record(key)
+	return db.Database.Has(key)
+}
+
+func (db *recordingDB) record(key []byte) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.reads[string(key)] = struct{}{}
+}
+
+func (db *recordingDB) hasRead(key []byte) bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	_, ok := db.reads[string(key)]
+	return ok
+}
+
+type taggedMinimizer struct {
+	Minimizer
+	*svc.Tagged
+}
+
+func MinimizerWithTags(m Minimizer, tags ...*tag.Tag) Minimizer {
+	return &taggedMinimizer{
+		Minimizer: m,
+		Tagged:    svc.NewTagged(tags...),
+	}
+}
+
+func (tm *taggedMinimizer) Minimize(ctx context.Context, in *input.ProverInput) (*input.ProverInput, error) {
+	ctx = tm.Context(
+		ctx,
+		tag.Key("chain.id").String(in.ChainConfig.ChainID.String()),
+		tag.Key("block.number").Int64(in.Blocks[0].Header.Number.Int64()),
+		tag.Key("block.hash").String(in.Blocks[0].Header.Hash().Hex()),
+	)
+
+	return tm.Minimizer.Minimize(ctx, in)
+}
@@ -0,0 +1,132 @@
+package steps
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMinimizerInput generates a chain of 3 blocks, the last one calling a contract that increments a storage slot,
+// and returns the prover input of the last block with a witness holding every state node, code and header of the chain
+func newTestMinimizerInput(t *testing.T) *input.ProverInput {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	config := *params.MergedTestChainConfig
+	config.CancunTime, config.PragueTime, config.BlobScheduleConfig = nil, nil, nil
+
+	// PUSH1 0 SLOAD PUSH1 1 ADD PUSH1 0 SSTORE STOP
+	counter := gethcommon.HexToAddress("0xc0")
+	alloc := gethtypes.GenesisAlloc{
+		sender:  {Balance: big.NewInt(params.Ether)},
+		counter: {Code: []byte{0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x60, 0x00, 0x55, 0x00}, Storage: make(map[gethcommon.Hash]gethcommon.Hash)},
+		// Contract never called during the execution
+		gethcommon.HexToAddress("0xc1"): {Code: []byte{0x60, 0x01, 0x00}},
+	}
+	for i := int64(0); i < 64; i++ {
+		alloc[counter].Storage[gethcommon.BigToHash(big.NewInt(i))] = gethcommon.BigToHash(big.NewInt(i + 1))
+		alloc[gethcommon.BigToAddress(big.NewInt(0x1000+i))] = gethtypes.Account{Balance: big.NewInt(i + 1)}
+	}
+
+	genesis := &core.Genesis{Config: &config, Alloc: alloc, BaseFee: big.NewInt(params.InitialBaseFee)}
+	db, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.New(ethash.NewFaker()), 3, func(i int, gen *core.BlockGen) {
+		if i < 2 {
+			return
+		}
+		tx, err := gethtypes.SignNewTx(key, gethtypes.LatestSigner(&config), &gethtypes.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     gen.TxNonce(sender),
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: new(big.Int).Add(gen.BaseFee(), big.NewInt(1)),
+			Gas:       100000,
+			To:        &counter,
+		})
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	})
+
+	// Every trie node (stored under its hash) and code of the chain states
+	witness := &input.Witness{
+		Ancestors: []*gethtypes.Header{blocks[1].Header(), blocks[0].Header(), genesis.ToBlock().Header()},
+	}
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		k, v := it.Key(), gethcommon.CopyBytes(it.Value())
+		switch {
+		case len(k) == gethcommon.HashLength && crypto.Keccak256Hash(v) == gethcommon.BytesToHash(k):
+			witness.State = append(witness.State, v)
+		case len(k) == len(rawdb.CodePrefix)+gethcommon.HashLength && bytes.HasPrefix(k, rawdb.CodePrefix):
+			witness.Codes = append(witness.Codes, v)
+		}
+	}
+
+	last := blocks[2]
+	return &input.ProverInput{
+		Version:     input.SchemaVersion,
+		ChainConfig: &config,
+		Blocks: []*input.Block{
+			{
+				Header:       last.Header(),
+				Transactions: last.Transactions(),
+				Uncles:       last.Uncles(),
+				Withdrawals:  last.Withdrawals(),
+			},
+		},
+		Witness: witness,
+	}
+}
+
+func TestMinimizer(t *testing.T) {
+	in := newTestMinimizerInput(t)
+	_, err := NewExecutor().Execute(context.Background(), in)
+	require.NoError(t, err)
+
+	minimized, err := NewMinimizer().Minimize(context.Background(), in)
+	require.NoError(t, err)
+
+	assert.Less(t, len(minimized.Witness.State), len(in.Witness.State))
+	assert.Less(t, witnessSize(minimized.Witness), witnessSize(in.Witness))
+	assert.Equal(t, [][]byte{{0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x60, 0x00, 0x55, 0x00}}, minimized.Witness.Codes)
+	assert.Equal(t, []*gethtypes.Header{in.Witness.Ancestors[0]}, minimized.Witness.Ancestors, "only the parent header should be kept")
+	assert.Equal(t, in.Blocks, minimized.Blocks)
+
+	// The minimized prover input is still valid
+	_, err = NewExecutor().Execute(context.Background(), minimized)
+	require.NoError(t, err)
+}
+
+func TestRecordingDB(t *testing.T) {
+	db := newRecordingDB(rawdb.NewMemoryDatabase())
+	require.NoError(t, db.Put([]byte("key1"), []byte("value1")))
+	require.NoError(t, db.Put([]byte("key2"), []byte("value2")))
+
+	v, err := db.Get([]byte("key1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), v)
+
+	assert.True(t, db.hasRead([]byte("key1")))
+	assert.False(t, db.hasRead([]byte("key2")))
+}
+
+func TestWitnessSize(t *testing.T) {
+	w := &input.Witness{
+		State: [][]byte{{0x1, 0x2}, {0x3}},
+		Codes: [][]byte{{0x4, 0x5, 0x6}},
+	}
+	assert.Equal(t, 6, witnessSize(w))
+}
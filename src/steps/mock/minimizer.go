@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kkrt-labs/zk-pig/src/steps (interfaces: Minimizer)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/minimizer.go -package=mocksteps github.com/kkrt-labs/zk-pig/src/steps Minimizer
+//
+
+// Package mocksteps is a generated GoMock package.
+package mocksteps
+
+import (
+	context "context"
+	reflect "reflect"
+
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMinimizer is a mock of Minimizer interface.
+type MockMinimizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMinimizerMockRecorder
+	isgomock struct{}
+}
+
+// MockMinimizerMockRecorder is the mock recorder for MockMinimizer.
+type MockMinimizerMockRecorder struct {
+	mock *MockMinimizer
+}
+
+// NewMockMinimizer creates a new mock instance.
+func NewMockMinimizer(ctrl *gomock.Controller) *MockMinimizer {
+	mock := &MockMinimizer{ctrl: ctrl}
+	mock.recorder = &MockMinimizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMinimizer) EXPECT() *MockMinimizerMockRecorder {
+	return m.recorder
+}
+
+// Minimize mocks base method.
+func (m *MockMinimizer) Minimize(ctx context.Context, in *input.ProverInput) (*input.ProverInput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Minimize", ctx, in)
+	ret0, _ := ret[0].(*input.ProverInput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Minimize indicates an expected call of Minimize.
+func (mr *MockMinimizerMockRecorder) Minimize(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Minimize", reflect.TypeOf((*MockMinimizer)(nil).Minimize), ctx, in)
+}
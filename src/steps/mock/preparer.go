@@ -43,16 +43,21 @@ func (m *MockPreparer) EXPECT() *MockPreparerMockRecorder {
 }
 
 // Prepare mocks base method.
-func (m *MockPreparer) Prepare(ctx context.Context, data *steps.PreflightData) (*input.ProverInput, error) {
+func (m *MockPreparer) Prepare(ctx context.Context, data ...*steps.PreflightData) (*input.ProverInput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Prepare", ctx, data)
+	varargs := []any{ctx}
+	for _, a := range data {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Prepare", varargs...)
 	ret0, _ := ret[0].(*input.ProverInput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Prepare indicates an expected call of Prepare.
-func (mr *MockPreparerMockRecorder) Prepare(ctx, data any) *gomock.Call {
+func (mr *MockPreparerMockRecorder) Prepare(ctx any, data ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prepare", reflect.TypeOf((*MockPreparer)(nil).Prepare), ctx, data)
+	varargs := append([]any{ctx}, data...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prepare", reflect.TypeOf((*MockPreparer)(nil).Prepare), varargs...)
 }
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kkrt-labs/zk-pig/src/steps (interfaces: Validator)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/validator.go -package=mocksteps github.com/kkrt-labs/zk-pig/src/steps Validator
+//
+
+// Package mocksteps is a generated GoMock package.
+package mocksteps
+
+import (
+	context "context"
+	reflect "reflect"
+
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	steps "github.com/kkrt-labs/zk-pig/src/steps"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockValidator is a mock of Validator interface.
+type MockValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorMockRecorder
+	isgomock struct{}
+}
+
+// MockValidatorMockRecorder is the mock recorder for MockValidator.
+type MockValidatorMockRecorder struct {
+	mock *MockValidator
+}
+
+// NewMockValidator creates a new mock instance.
+func NewMockValidator(ctrl *gomock.Controller) *MockValidator {
+	mock := &MockValidator{ctrl: ctrl}
+	mock.recorder = &MockValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockValidator) Validate(ctx context.Context, in *input.ProverInput) *steps.ValidationReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", ctx, in)
+	ret0, _ := ret[0].(*steps.ValidationReport)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockValidatorMockRecorder) Validate(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockValidator)(nil).Validate), ctx, in)
+}
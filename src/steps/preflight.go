@@ -1,12 +1,13 @@
 package steps
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sort"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	gethstate "github.com/ethereum/go-ethereum/core/state"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -73,7 +74,7 @@ func NewPreflightFromEvm(e evm.Executor, remote ethrpc.Client) Preflight {
 	}
 }
 
-func (pf *preflight) configureDBAndChain(ctx context.Context) (*state.RPCDatabase, *core.HeaderChain, error) {
+func (pf *preflight) configureDBAndChain(ctx context.Context) (*state.RPCDatabase, *ethereum.Chain, error) {
 	// Fetch chain ID
 	chainID, err := pf.remote.ChainID(ctx)
 	if err != nil {
@@ -89,7 +90,7 @@ func (pf *preflight) configureDBAndChain(ctx context.Context) (*state.RPCDatabas
 	trieDB := triedb.NewDatabase(db, &triedb.Config{HashDB: &hashdb.Config{}})
 	rpcDB := state.HackWithContext(ctx, gethstate.NewDatabase(trieDB, nil), pf.remote)
 
-	hc, err := ethereum.NewChain(pf.chainCfg, rpcDB)
+	hc, err := ethereum.NewChain(pf.chainCfg, db)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create chain: %v", err)
 	}
@@ -160,6 +161,10 @@ func (pf *preflight) preflight(ctx context.Context, block *gethtypes.Block) (*Pr
 	for code := range witness.Codes {
 		data.Codes = append(data.Codes, []byte(code))
 	}
+	// Sort codes so preflight data is deterministic
+	sort.Slice(data.Codes, func(i, j int) bool {
+		return bytes.Compare(data.Codes[i], data.Codes[j]) < 0
+	})
 
 	// Fetch all necessary state proofs in order to derive the post-state root
 	data.PreStateProofs, data.PostStateProofs, err = pf.fetchStateProofs(ctx, trackers, parentHeader, execParams)
@@ -175,13 +180,29 @@ func (pf *preflight) preflight(ctx context.Context, block *gethtypes.Block) (*Pr
 func (pf *preflight) fetchStateProofs(ctx context.Context, trackers *state.AccessTrackerManager, parentHeader *gethtypes.Header, execParams *evm.ExecParams) (preStateProofs, postStateProofs []*trie.AccountProof, err error) {
 	finalState := execParams.State
 	tracker := trackers.GetAccessTracker(parentHeader.Root)
-	for addr, accountAccessTracker := range tracker.Accounts {
+
+	// Accounts and slots are iterated in sorted order so preflight data is deterministic
+	addrs := make([]gethcommon.Address, 0, len(tracker.Accounts))
+	for addr := range tracker.Accounts {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].Cmp(addrs[j]) < 0 })
+
+	for _, addr := range addrs {
+		accountAccessTracker := tracker.Accounts[addr]
 		var (
 			slots       = []string{}
 			deletedSlot = []string{}
 		)
 
-		for slot, preStateValue := range accountAccessTracker.Storage {
+		sortedSlots := make([]gethcommon.Hash, 0, len(accountAccessTracker.Storage))
+		for slot := range accountAccessTracker.Storage {
+			sortedSlots = append(sortedSlots, slot)
+		}
+		sort.Slice(sortedSlots, func(i, j int) bool { return sortedSlots[i].Cmp(sortedSlots[j]) < 0 })
+
+		for _, slot := range sortedSlots {
+			preStateValue := accountAccessTracker.Storage[slot]
 			slots = append(slots, slot.Hex())
 			if (preStateValue != gethcommon.Hash{}) && (finalState.GetState(addr, slot) == gethcommon.Hash{}) {
 				deletedSlot = append(deletedSlot, slot.Hex())
@@ -6,11 +6,12 @@ import (
 	"fmt"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	gethstate "github.com/ethereum/go-ethereum/core/state"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	gethtrie "github.com/ethereum/go-ethereum/trie"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/ethereum/go-ethereum/triedb/hashdb"
 	"github.com/kkrt-labs/go-utils/app/svc"
@@ -31,7 +32,8 @@ import (
 // It bases on the preflight data collected during preflight to prepare the final prover input
 type Preparer interface {
 	// Prepare prepares the ProvableBlockInputs data for the EVM prover engine.
-	Prepare(ctx context.Context, data *PreflightData) (*input.ProverInput, error)
+	// If preflight data for several consecutive blocks is provided, it prepares a single prover input covering all the blocks.
+	Prepare(ctx context.Context, data ...*PreflightData) (*input.ProverInput, error)
 }
 
 type preparer struct {
@@ -66,9 +68,9 @@ func NewPreparerFromEvm(e evm.Executor, opts ...PrepareOption) (Preparer, error)
 }
 
 // Prepare prepares the ProvableBlockInputs data for the EVM prover engine.
-func (p *preparer) Prepare(ctx context.Context, data *PreflightData) (*input.ProverInput, error) {
+func (p *preparer) Prepare(ctx context.Context, data ...*PreflightData) (*input.ProverInput, error) {
 	log.LoggerFromContext(ctx).Info("Start preparing prover input...")
-	in, err := p.prepare(ctx, data)
+	in, err := p.prepare(ctx, data...)
 	if err != nil {
 		log.LoggerFromContext(ctx).Error("Prover input preparation failed", zap.Error(err))
 		return nil, err
@@ -78,8 +80,12 @@ func (p *preparer) Prepare(ctx context.Context, data *PreflightData) (*input.Pro
 	return in, nil
 }
 
-func (p *preparer) prepare(ctx context.Context, data *PreflightData) (*input.ProverInput, error) {
-	stateDB, hc, err := p.prepareStateDBAndChain(ctx, data)
+func (p *preparer) prepare(ctx context.Context, data ...*PreflightData) (*input.ProverInput, error) {
+	if err := checkConsecutive(data); err != nil {
+		return nil, err
+	}
+
+	stateDB, hc, err := p.prepareStateDBAndChain(ctx, data...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare state db and chain: %v", err)
 	}
@@ -87,37 +93,115 @@ func (p *preparer) prepare(ctx context.Context, data *PreflightData) (*input.Pro
 	trackers := state.NewAccessTrackerManager()
 	trackedDB := state.NewAccessTrackerDatabase(stateDB, trackers)
 
-	// ___ Populate state database with state nodes ---
-	parentHeader := hc.GetHeader(data.Block.Header.ParentHash, data.Block.Header.Number.ToInt().Uint64()-1)
-	if parentHeader == nil {
-		return nil, fmt.Errorf("missing parent header for block %q", data.Block.Header.Number.String())
+	var (
+		blocks   = make([]*input.Block, 0, len(data))
+		witness  = newWitnessBuilder(data[0].Block.Number.ToInt().Uint64())
+		tracker  = state.NewAccessTracker()
+		preState *gethstate.StateDB
+		executed = new(executionData)
+	)
+	if p.include(IncludeProvingCost) {
+		// The proving cost of the blocks is estimated if the EVM executor is decorated with evm.WithCostEstimate
+		ctx = evm.ContextWithCostEstimateRecorder(ctx, func(estimate *evm.CostEstimate) {
+			executed.cost = addCostEstimate(executed.cost, estimate)
+		})
 	}
+	for i, d := range data {
+		parentHeader := hc.GetHeader(d.Block.Header.ParentHash, d.Block.Header.Number.ToInt().Uint64()-1)
+		if parentHeader == nil {
+			return nil, fmt.Errorf("missing parent header for block %q", d.Block.Header.Number.String())
+		}
 
-	preState, err := gethstate.New(parentHeader.Root, trackedDB)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create pre-state from parent root %v: %v", parentHeader.Root, err)
-	}
+		preState, err = gethstate.New(parentHeader.Root, trackedDB)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pre-state from parent root %v: %v", parentHeader.Root, err)
+		}
+
+		// Every block but the last one must have its state changes flushed, so the next block can execute on top of it
+		isLast := i == len(data)-1
+		execParams := &evm.ExecParams{
+			VMConfig: &vm.Config{
+				StatelessSelfValidation: true,
+			},
+			Block:    d.Block.Block(),
+			Validate: true, // We validate the block execution to ensure the result and final state are correct
+			Commit:   !isLast || p.include(IncludeCommitted),
+			Flush:    !isLast,
+			Chain:    hc,
+			State:    preState,
+		}
 
-	execParams := &evm.ExecParams{
-		VMConfig: &vm.Config{
-			StatelessSelfValidation: true,
-		},
-		Block:    data.Block.Block(),
-		Validate: true, // We validate the block execution to ensure the result and final state are correct
-		Commit:   p.include(IncludeCommitted),
-		Chain:    hc,
-		State:    preState,
+		var txDiffTracer *txStateDiffTracer
+		if p.include(IncludeTxStateDiffs) {
+			txDiffTracer = newTxStateDiffTracer(preState, execParams.Block.NumberU64())
+			evm.AddTracer(execParams.VMConfig, txDiffTracer.Hooks())
+		}
+
+		res, err := p.evm.Execute(ctx, execParams)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute block %q: %v", d.Block.Header.Number.String(), err)
+		}
+
+		if p.include(IncludeReceipts) {
+			if err := checkReceiptsRoot(execParams.Block.Header(), res.Receipts); err != nil {
+				return nil, fmt.Errorf("invalid receipts for block %q: %v", d.Block.Header.Number.String(), err)
+			}
+			executed.receipts = append(executed.receipts, res.Receipts...)
+		}
+
+		if txDiffTracer != nil {
+			executed.txStateDiffs = append(executed.txStateDiffs, txDiffTracer.TxStateDiffs()...)
+		}
+
+		if p.include(IncludeSenders) {
+			blockSenders, err := recoverSenders(hc.Config(), execParams.Block)
+			if err != nil {
+				return nil, fmt.Errorf("failed to recover senders of block %q: %v", d.Block.Header.Number.String(), err)
+			}
+			executed.senders = append(executed.senders, blockSenders...)
+		}
+
+		witness.add(execParams.State.Witness())
+		tracker.Merge(trackers.GetAccessTracker(parentHeader.Root))
+		blocks = append(blocks, &input.Block{
+			Header:       execParams.Block.Header(),
+			Transactions: execParams.Block.Transactions(),
+			Uncles:       execParams.Block.Uncles(),
+			Withdrawals:  execParams.Block.Withdrawals(),
+		})
 	}
 
-	_, err = p.evm.Execute(ctx, execParams)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute block: %v", err)
+	in := &input.ProverInput{
+		Version:     input.SchemaVersion,
+		ChainConfig: hc.Config(),
+		Blocks:      blocks,
+		Witness:     witness.witness(),
+		Extra:       p.extra(tracker, preState, witness, executed),
 	}
 
+	// Order data canonically so the prover input is deterministic
+	in.Canonicalize()
+
+	return in, nil
+}
+
+// executionData is the data collected while executing the blocks, in blocks and transactions order
+// (each field is only collected if the corresponding extra data is included)
+type executionData struct {
+	cost         *evm.CostEstimate // proving cost estimate of the blocks (nil if the EVM executor does not estimate proving cost)
+	senders      []gethcommon.Address
+	receipts     []*gethtypes.Receipt
+	txStateDiffs []*input.TxStateDiff
+}
+
+// extra computes the extra data to include in the prover input
+// - tracker holds the state accessed during the execution of the blocks (valued at the pre-state of the first block)
+// - postState is the state after the execution of the last block
+// - executed holds the data collected while executing the blocks
+func (p *preparer) extra(tracker *state.AccessTracker, postState *gethstate.StateDB, witness *witnessBuilder, executed *executionData) *input.Extra {
 	extra := new(input.Extra)
 
 	if p.include(IncludeAccessList) {
-		tracker := trackers.GetAccessTracker(parentHeader.Root)
 		for addr, accountAccessTracker := range tracker.Accounts {
 			accessTuple := gethtypes.AccessTuple{
 				Address:     addr,
@@ -132,10 +216,9 @@ func (p *preparer) prepare(ctx context.Context, data *PreflightData) (*input.Pro
 	}
 
 	if p.include(IncludeStateDiffs) {
-		tracker := trackers.GetAccessTracker(parentHeader.Root)
 		for addr, accountAccessTracker := range tracker.Accounts {
 			preAcc := accountAccessTracker.Account
-			postAcc := getAccount(execParams.State, addr)
+			postAcc := getAccount(postState, addr)
 
 			if !accountHasChanged(preAcc, postAcc) {
 				continue
@@ -149,7 +232,7 @@ func (p *preparer) prepare(ctx context.Context, data *PreflightData) (*input.Pro
 
 			if accountRootHasChanged(preAcc, postAcc) {
 				for slot, preValue := range accountAccessTracker.Storage {
-					postValue := execParams.State.GetState(addr, slot)
+					postValue := postState.GetState(addr, slot)
 					if preValue != postValue {
 						stateDiff.Storage = append(stateDiff.Storage, &input.StorageDiff{
 							Slot:      slot,
@@ -165,12 +248,11 @@ func (p *preparer) prepare(ctx context.Context, data *PreflightData) (*input.Pro
 	}
 
 	if p.include(IncludeCommitted) {
-		extra.Committed = witnessToBytes(execParams.State.Witness().Committed)
+		extra.Committed = witnessToBytes(witness.committed)
 	}
 
 	if p.include(IncludePreState) {
 		extra.PreState = make(map[gethcommon.Address]*input.AccountState)
-		tracker := trackers.GetAccessTracker(parentHeader.Root)
 		for addr, accountAccessTracker := range tracker.Accounts {
 			if accountAccessTracker.Account == nil {
 				extra.PreState[addr] = nil
@@ -180,7 +262,7 @@ func (p *preparer) prepare(ctx context.Context, data *PreflightData) (*input.Pro
 			extra.PreState[addr] = &input.AccountState{
 				Balance:     accountAccessTracker.Account.Balance.ToBig(),
 				CodeHash:    gethcommon.BytesToHash(accountAccessTracker.Account.CodeHash),
-				Code:        execParams.State.GetCode(addr),
+				Code:        postState.GetCode(addr),
 				Nonce:       accountAccessTracker.Account.Nonce,
 				StorageHash: accountAccessTracker.Account.Root,
 				Storage:     accountAccessTracker.Storage,
@@ -188,23 +270,42 @@ func (p *preparer) prepare(ctx context.Context, data *PreflightData) (*input.Pro
 		}
 	}
 
-	return &input.ProverInput{
-		ChainConfig: execParams.Chain.Config(),
-		Blocks: []*input.Block{
-			{
-				Header:       execParams.Block.Header(),
-				Transactions: execParams.Block.Transactions(),
-				Uncles:       execParams.Block.Uncles(),
-				Withdrawals:  execParams.Block.Withdrawals(),
-			},
-		},
-		Witness: &input.Witness{
-			Ancestors: execParams.State.Witness().Headers,
-			Codes:     witnessToBytes(execParams.State.Witness().Codes),
-			State:     witnessToBytes(execParams.State.Witness().State),
-		},
-		Extra: extra,
-	}, nil
+	if p.include(IncludeProvingCost) {
+		extra.ProvingCost = toProvingCost(executed.cost)
+	}
+
+	if p.include(IncludeSenders) {
+		extra.Senders = executed.senders
+	}
+
+	if p.include(IncludeReceipts) {
+		extra.Receipts = executed.receipts
+	}
+
+	if p.include(IncludeTxStateDiffs) {
+		extra.TxStateDiffs = executed.txStateDiffs
+	}
+
+	if p.include(IncludePreimages) {
+		extra.Preimages = toPreimages(tracker)
+	}
+
+	return extra
+}
+
+// toPreimages computes the trie keys of the accounts and storage slots resolved during the execution
+func toPreimages(tracker *state.AccessTracker) *input.Preimages {
+	preimages := &input.Preimages{
+		Accounts: make(map[gethcommon.Address]gethcommon.Hash),
+		Slots:    make(map[gethcommon.Hash]gethcommon.Hash),
+	}
+	for addr, accountAccessTracker := range tracker.Accounts {
+		preimages.Accounts[addr] = gethcommon.BytesToHash(trie.AccountTrieKey(addr))
+		for slot := range accountAccessTracker.Storage {
+			preimages.Slots[slot] = gethcommon.BytesToHash(trie.StorageTrieKey(slot.Bytes()))
+		}
+	}
+	return preimages
 }
 
 func (p *preparer) include(opt Include) bool {
@@ -268,7 +369,70 @@ func toAccount(acc *gethtypes.StateAccount) *input.Account {
 	}
 }
 
-func (p *preparer) prepareStateDBAndChain(_ context.Context, in *PreflightData) (gethstate.Database, *core.HeaderChain, error) {
+// recoverSenders recovers the sender of every transaction of a block
+func recoverSenders(chainCfg *params.ChainConfig, block *gethtypes.Block) ([]gethcommon.Address, error) {
+	signer := gethtypes.MakeSigner(chainCfg, block.Number(), block.Time())
+	senders := make([]gethcommon.Address, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		sender, err := gethtypes.Sender(signer, tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx %d (%v): %v", i, tx.Hash().Hex(), err)
+		}
+		senders[i] = sender
+	}
+	return senders, nil
+}
+
+// checkReceiptsRoot checks that the root of the receipts matches the receipts root of the block header
+func checkReceiptsRoot(header *gethtypes.Header, receipts []*gethtypes.Receipt) error {
+	root := gethtypes.DeriveSha(gethtypes.Receipts(receipts), gethtrie.NewStackTrie(nil))
+	if root != header.ReceiptHash {
+		return fmt.Errorf("receipts root mismatch (computed=%v, header=%v)", root.Hex(), header.ReceiptHash.Hex())
+	}
+	return nil
+}
+
+// addCostEstimate adds the cost estimate of a block to the cost estimate of the previous blocks
+func addCostEstimate(total, est *evm.CostEstimate) *evm.CostEstimate {
+	if est == nil {
+		return total
+	}
+
+	if total == nil {
+		total = &evm.CostEstimate{Stats: evm.NewExecutionStats()}
+	}
+	total.Cycles += est.Cycles
+	total.Stats.Merge(est.Stats)
+
+	return total
+}
+
+func toProvingCost(cost *evm.CostEstimate) *input.ProvingCost {
+	if cost == nil {
+		return nil
+	}
+
+	provingCost := &input.ProvingCost{
+		Cycles:       cost.Cycles,
+		Opcodes:      make(map[string]uint64, len(cost.Stats.Opcodes)),
+		Precompiles:  make(map[gethcommon.Address]*input.PrecompileUsage, len(cost.Stats.Precompiles)),
+		KeccakBytes:  cost.Stats.KeccakBytes,
+		WitnessNodes: cost.Stats.WitnessNodes,
+	}
+	for op, count := range cost.Stats.Opcodes {
+		provingCost.Opcodes[op.String()] = count
+	}
+	for addr, pStats := range cost.Stats.Precompiles {
+		provingCost.Precompiles[addr] = &input.PrecompileUsage{
+			Calls:      pStats.Calls,
+			InputBytes: pStats.InputBytes,
+		}
+	}
+
+	return provingCost
+}
+
+func (p *preparer) prepareStateDBAndChain(_ context.Context, data ...*PreflightData) (gethstate.Database, *ethereum.Chain, error) {
 	// --- Create in Memory database ---
 	stateDB := gethstate.NewDatabase(
 		triedb.NewDatabase(rawdb.NewMemoryDatabase(), &triedb.Config{HashDB: &hashdb.Config{}}),
@@ -276,34 +440,55 @@ func (p *preparer) prepareStateDBAndChain(_ context.Context, in *PreflightData)
 	) // We use a modified trie database to track trie modifications
 
 	// -- Pre-populates database with Witness data ---
-	ethereum.WriteHeaders(stateDB.TrieDB().Disk(), in.Ancestors...)
-	ethereum.WriteCodes(stateDB.TrieDB().Disk(), hexBytesToBytes(in.Codes)...)
+	for _, d := range data {
+		ethereum.WriteHeaders(stateDB.TrieDB().Disk(), d.Ancestors...)
+		ethereum.WriteCodes(stateDB.TrieDB().Disk(), hexBytesToBytes(d.Codes)...)
+	}
 
 	// --- Create chain instance ---
-	hc, err := ethereum.NewChain(in.ChainConfig, stateDB)
+	hc, err := ethereum.NewChain(data[0].ChainConfig, stateDB.TrieDB().Disk())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create chain: %v", err)
 	}
 
-	// ___ Populate state database with state nodes ---
-	parentHeader := hc.GetHeader(in.Block.Header.ParentHash, in.Block.Header.Number.ToInt().Uint64()-1)
-	if parentHeader == nil {
-		return nil, nil, fmt.Errorf("missing parent header for block %q", in.Block.Header.Number.String())
+	// ___ Populate state database with state nodes of every block ---
+	for _, d := range data {
+		parentHeader := hc.GetHeader(d.Block.Header.ParentHash, d.Block.Header.Number.ToInt().Uint64()-1)
+		if parentHeader == nil {
+			return nil, nil, fmt.Errorf("missing parent header for block %q", d.Block.Header.Number.String())
+		}
+
+		nodeSet, err := trie.NodeSetFromStateTransitionProofs(parentHeader.Root, d.Block.Root, d.PreStateProofs, d.PostStateProofs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create state nodes for block %q: %v", d.Block.Header.Number.String(), err)
+		}
+
+		err = stateDB.TrieDB().Update(parentHeader.Root, gethtypes.EmptyRootHash, 0, nodeSet, triedb.NewStateSet())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to update trie db with state nodes for block %q: %v", d.Block.Header.Number.String(), err)
+		}
 	}
 
-	genesisHeader := hc.GetHeaderByNumber(0)
+	return stateDB, hc, nil
+}
 
-	nodeSet, err := trie.NodeSetFromStateTransitionProofs(parentHeader.Root, in.Block.Root, in.PreStateProofs, in.PostStateProofs)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create state nodes: %v", err)
+// checkConsecutive checks that the preflight data are for consecutive blocks of the same chain
+func checkConsecutive(data []*PreflightData) error {
+	if len(data) == 0 {
+		return fmt.Errorf("no preflight data provided")
 	}
 
-	err = stateDB.TrieDB().Update(parentHeader.Root, genesisHeader.Root, 0, nodeSet, triedb.NewStateSet())
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to update trie db with state nodes: %v", err)
+	for i := 1; i < len(data); i++ {
+		if data[i].ChainConfig.ChainID.Cmp(data[0].ChainConfig.ChainID) != 0 {
+			return fmt.Errorf("preflight data for block %q is for chain %q (expected %q)", data[i].Block.Header.Number.String(), data[i].ChainConfig.ChainID.String(), data[0].ChainConfig.ChainID.String())
+		}
+
+		if data[i].Block.Header.ParentHash != data[i-1].Block.Hash {
+			return fmt.Errorf("block %q is not a child of block %q", data[i].Block.Header.Number.String(), data[i-1].Block.Header.Number.String())
+		}
 	}
 
-	return stateDB, hc, nil
+	return nil
 }
 
 func witnessToBytes(hex map[string]struct{}) [][]byte {
@@ -326,13 +511,15 @@ func PreparerWithTags(p Preparer, tags ...*tag.Tag) Preparer {
 	}
 }
 
-func (tp *taggedPreparer) Prepare(ctx context.Context, data *PreflightData) (*input.ProverInput, error) {
-	ctx = tp.Context(
-		ctx,
-		tag.Key("chain.id").String(data.ChainConfig.ChainID.String()),
-		tag.Key("block.number").Int64(data.Block.Number.ToInt().Int64()),
-		tag.Key("block.hash").String(data.Block.Hash.Hex()),
-	)
+func (tp *taggedPreparer) Prepare(ctx context.Context, data ...*PreflightData) (*input.ProverInput, error) {
+	if len(data) > 0 {
+		ctx = tp.Context(
+			ctx,
+			tag.Key("chain.id").String(data[0].ChainConfig.ChainID.String()),
+			tag.Key("block.number").Int64(data[0].Block.Number.ToInt().Int64()),
+			tag.Key("block.hash").String(data[0].Block.Hash.Hex()),
+		)
+	}
 
-	return tp.Preparer.Prepare(ctx, data)
+	return tp.Preparer.Prepare(ctx, data...)
 }
@@ -2,9 +2,19 @@ package steps
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	gethtrie "github.com/ethereum/go-ethereum/trie"
+	ethrpc "github.com/kkrt-labs/go-utils/ethereum/rpc"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/state"
 	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,6 +39,113 @@ func TestPreparer(t *testing.T) {
 	}
 }
 
+func TestCheckConsecutive(t *testing.T) {
+	newData := func(chainID, number int64, hash, parentHash gethcommon.Hash) *PreflightData {
+		return &PreflightData{
+			Block: &ethrpc.Block{
+				Header: ethrpc.Header{
+					Number:     (*hexutil.Big)(big.NewInt(number)),
+					ParentHash: parentHash,
+					Hash:       hash,
+				},
+			},
+			ChainConfig: &params.ChainConfig{ChainID: big.NewInt(chainID)},
+		}
+	}
+
+	var (
+		hash1 = gethcommon.HexToHash("0x1")
+		hash2 = gethcommon.HexToHash("0x2")
+		hash3 = gethcommon.HexToHash("0x3")
+	)
+
+	assert.Error(t, checkConsecutive(nil))
+	assert.NoError(t, checkConsecutive([]*PreflightData{newData(1, 1, hash1, gethcommon.Hash{})}))
+	assert.NoError(t, checkConsecutive([]*PreflightData{newData(1, 1, hash1, gethcommon.Hash{}), newData(1, 2, hash2, hash1), newData(1, 3, hash3, hash2)}))
+	assert.Error(t, checkConsecutive([]*PreflightData{newData(1, 1, hash1, gethcommon.Hash{}), newData(1, 3, hash3, hash2)}))
+	assert.Error(t, checkConsecutive([]*PreflightData{newData(1, 1, hash1, gethcommon.Hash{}), newData(2, 2, hash2, hash1)}))
+}
+
 func testDataInputsPath(filename string) string {
 	return "testdata/" + filename
 }
+
+func TestRecoverSenders(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	chainCfg := params.MainnetChainConfig
+	header := &gethtypes.Header{Number: big.NewInt(21465322), Time: 1734944999}
+	signer := gethtypes.MakeSigner(chainCfg, header.Number, header.Time)
+
+	txs := make([]*gethtypes.Transaction, 2)
+	for i := range txs {
+		txs[i], err = gethtypes.SignNewTx(key, signer, &gethtypes.DynamicFeeTx{ChainID: chainCfg.ChainID, Nonce: uint64(i)})
+		require.NoError(t, err)
+	}
+	block := gethtypes.NewBlockWithHeader(header).WithBody(gethtypes.Body{Transactions: txs})
+
+	senders, err := recoverSenders(chainCfg, block)
+	require.NoError(t, err)
+	assert.Equal(t, []gethcommon.Address{sender, sender}, senders)
+
+	// Transaction signed for another chain
+	otherCfg := *chainCfg
+	otherCfg.ChainID = big.NewInt(2)
+	_, err = recoverSenders(&otherCfg, block)
+	require.Error(t, err)
+}
+
+func TestCheckReceiptsRoot(t *testing.T) {
+	receipts := []*gethtypes.Receipt{
+		{Type: gethtypes.DynamicFeeTxType, Status: gethtypes.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*gethtypes.Log{}},
+		{Type: gethtypes.LegacyTxType, Status: gethtypes.ReceiptStatusFailed, CumulativeGasUsed: 63000, Logs: []*gethtypes.Log{}},
+	}
+	header := &gethtypes.Header{ReceiptHash: gethtypes.DeriveSha(gethtypes.Receipts(receipts), gethtrie.NewStackTrie(nil))}
+	require.NoError(t, checkReceiptsRoot(header, receipts))
+
+	// Receipts of another execution
+	require.Error(t, checkReceiptsRoot(header, receipts[:1]))
+	require.Error(t, checkReceiptsRoot(&gethtypes.Header{ReceiptHash: gethtypes.EmptyReceiptsHash}, receipts))
+	require.NoError(t, checkReceiptsRoot(&gethtypes.Header{ReceiptHash: gethtypes.EmptyReceiptsHash}, nil))
+}
+
+func TestToPreimages(t *testing.T) {
+	var (
+		addr = gethcommon.HexToAddress("0xa")
+		slot = gethcommon.HexToHash("0x1")
+	)
+	tracker := &state.AccessTracker{
+		Accounts: map[gethcommon.Address]*state.AccountAccessTracker{
+			addr: {Storage: map[gethcommon.Hash]gethcommon.Hash{slot: {}}},
+		},
+	}
+
+	preimages := toPreimages(tracker)
+	assert.Equal(t, map[gethcommon.Address]gethcommon.Hash{addr: crypto.Keccak256Hash(addr.Bytes())}, preimages.Accounts)
+	assert.Equal(t, map[gethcommon.Hash]gethcommon.Hash{slot: crypto.Keccak256Hash(slot.Bytes())}, preimages.Slots)
+}
+
+func TestPreparerExtraExecutionData(t *testing.T) {
+	executed := &executionData{
+		senders:      []gethcommon.Address{gethcommon.HexToAddress("0xa")},
+		receipts:     []*gethtypes.Receipt{{Status: gethtypes.ReceiptStatusSuccessful, Logs: []*gethtypes.Log{}}},
+		txStateDiffs: []*input.TxStateDiff{{Type: input.TxStateDiffTypeTransaction}},
+	}
+	tracker := state.NewAccessTracker()
+
+	p := &preparer{includeOpt: IncludeSenders | IncludeReceipts | IncludeTxStateDiffs}
+	extra := p.extra(tracker, nil, newWitnessBuilder(0), executed)
+	assert.Equal(t, executed.senders, extra.Senders)
+	assert.Equal(t, executed.receipts, extra.Receipts)
+	assert.Equal(t, executed.txStateDiffs, extra.TxStateDiffs)
+
+	// Data collected during execution is only included on demand
+	p = &preparer{includeOpt: IncludeNone}
+	extra = p.extra(tracker, nil, newWitnessBuilder(0), executed)
+	assert.Nil(t, extra.Senders)
+	assert.Nil(t, extra.Receipts)
+	assert.Nil(t, extra.TxStateDiffs)
+	assert.Nil(t, extra.ProvingCost)
+}
@@ -0,0 +1,282 @@
+package proto
+
+import (
+	"fmt"
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/kkrt-labs/go-utils/ethereum/rpc"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/trie"
+	protoinput "github.com/kkrt-labs/zk-pig/src/prover-input/proto"
+	"github.com/kkrt-labs/zk-pig/src/steps"
+)
+
+// ToProto converts Go steps.PreflightData to protobuf format
+//
+// Proof nodes, which are hex encoded in JSON, are decoded to bytes.
+func ToProto(d *steps.PreflightData) (*PreflightData, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	block, err := BlockToProto(d.Block)
+	if err != nil {
+		return nil, err
+	}
+	preStateProofs, err := AccountProofsToProto(d.PreStateProofs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pre-state proof: %w", err)
+	}
+	postStateProofs, err := AccountProofsToProto(d.PostStateProofs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid post-state proof: %w", err)
+	}
+
+	codes := make([][]byte, len(d.Codes))
+	for i, code := range d.Codes {
+		codes[i] = code
+	}
+
+	return &PreflightData{
+		Block:           block,
+		Ancestors:       protoinput.HeadersToProto(d.Ancestors),
+		ChainConfig:     protoinput.ChainConfigToProto(d.ChainConfig),
+		Codes:           codes,
+		PreStateProofs:  preStateProofs,
+		PostStateProofs: postStateProofs,
+	}, nil
+}
+
+// FromProto converts protobuf PreflightData to Go steps.PreflightData format
+func FromProto(d *PreflightData) (*steps.PreflightData, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	block, err := BlockFromProto(d.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]hexutil.Bytes, len(d.Codes))
+	for i, code := range d.Codes {
+		codes[i] = code
+	}
+
+	return &steps.PreflightData{
+		Block:           block,
+		Ancestors:       protoinput.HeadersFromProto(d.Ancestors),
+		ChainConfig:     protoinput.ChainConfigFromProto(d.ChainConfig),
+		Codes:           codes,
+		PreStateProofs:  AccountProofsFromProto(d.PreStateProofs),
+		PostStateProofs: AccountProofsFromProto(d.PostStateProofs),
+	}, nil
+}
+
+func BlockToProto(b *ethrpc.Block) (*Block, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	transactions := make([]*Transaction, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		t, err := TransactionToProto(tx)
+		if err != nil {
+			return nil, err
+		}
+		transactions[i] = t
+	}
+
+	uncles := make([][]byte, len(b.Uncles))
+	for i, uncle := range b.Uncles {
+		uncles[i] = uncle.Bytes()
+	}
+
+	return &Block{
+		Header:       protoinput.HeaderToProto(b.Header.Header()),
+		Hash:         b.Hash.Bytes(),
+		Size:         uint64(b.Size),
+		Transactions: transactions,
+		Uncles:       uncles,
+		Withdrawals:  protoinput.WithdrawalsToProto(b.Withdrawals),
+	}, nil
+}
+
+func BlockFromProto(b *Block) (*ethrpc.Block, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	block := new(ethrpc.Block)
+	if b.Header != nil {
+		block.Header.FromHeader(protoinput.HeaderFromProto(b.Header))
+	}
+	block.Hash = gethcommon.BytesToHash(b.Hash) // the hash is kept as returned by the JSON-RPC API
+	block.Size = hexutil.Uint64(b.Size)
+	block.Withdrawals = protoinput.WithdrawalsFromProto(b.Withdrawals)
+
+	block.Transactions = make([]*ethrpc.Transaction, len(b.Transactions))
+	for i, t := range b.Transactions {
+		tx, err := TransactionFromProto(t)
+		if err != nil {
+			return nil, err
+		}
+		block.Transactions[i] = tx
+	}
+
+	block.Uncles = make([]gethcommon.Hash, len(b.Uncles))
+	for i, uncle := range b.Uncles {
+		block.Uncles[i] = gethcommon.BytesToHash(uncle)
+	}
+
+	return block, nil
+}
+
+func TransactionToProto(tx *ethrpc.Transaction) (*Transaction, error) {
+	if tx == nil {
+		return nil, nil
+	}
+
+	t, err := protoinput.TransactionToProto(tx.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Transaction{Transaction: t}
+	if tx.BlockNumber != nil {
+		p.BlockNumber = tx.BlockNumber.ToInt().Bytes()
+	}
+	if tx.BlockHash != nil {
+		p.BlockHash = tx.BlockHash.Bytes()
+	}
+	if tx.From != nil {
+		p.From = tx.From.Bytes()
+	}
+	return p, nil
+}
+
+func TransactionFromProto(t *Transaction) (*ethrpc.Transaction, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	transaction, err := protoinput.TransactionFromProto(t.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := ethrpc.NewTransactionFromGeth(transaction)
+	if t.BlockNumber != nil {
+		tx.BlockNumber = (*hexutil.Big)(new(big.Int).SetBytes(t.BlockNumber))
+	}
+	if t.BlockHash != nil {
+		blockHash := gethcommon.BytesToHash(t.BlockHash)
+		tx.BlockHash = &blockHash
+	}
+	if t.From != nil {
+		from := gethcommon.BytesToAddress(t.From)
+		tx.From = &from
+	}
+	return tx, nil
+}
+
+func AccountProofsToProto(proofs []*trie.AccountProof) ([]*AccountProof, error) {
+	if proofs == nil {
+		return nil, nil
+	}
+
+	result := make([]*AccountProof, len(proofs))
+	for i, proof := range proofs {
+		p, err := AccountProofToProto(proof)
+		if err != nil {
+			return nil, fmt.Errorf("account %v: %w", proof.Address, err)
+		}
+		result[i] = p
+	}
+	return result, nil
+}
+
+func AccountProofsFromProto(proofs []*AccountProof) []*trie.AccountProof {
+	if proofs == nil {
+		return nil
+	}
+
+	result := make([]*trie.AccountProof, len(proofs))
+	for i, proof := range proofs {
+		result[i] = AccountProofFromProto(proof)
+	}
+	return result
+}
+
+func AccountProofToProto(proof *trie.AccountProof) (*AccountProof, error) {
+	nodes, err := proofToProto(proof.Proof)
+	if err != nil {
+		return nil, err
+	}
+
+	storageProofs := make([]*StorageProof, len(proof.Storage))
+	for i, storageProof := range proof.Storage {
+		storageNodes, err := proofToProto(storageProof.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("storage %s: %w", storageProof.Key, err)
+		}
+		storageProofs[i] = &StorageProof{
+			Key:   storageProof.Key,
+			Value: storageProof.Value.ToInt().Bytes(),
+			Proof: storageNodes,
+		}
+	}
+
+	return &AccountProof{
+		Address:     proof.Address.Bytes(),
+		Proof:       nodes,
+		Balance:     proof.Balance.ToInt().Bytes(),
+		CodeHash:    proof.CodeHash.Bytes(),
+		Nonce:       proof.Nonce,
+		StorageHash: proof.StorageHash.Bytes(),
+		Storage:     storageProofs,
+	}, nil
+}
+
+func AccountProofFromProto(p *AccountProof) *trie.AccountProof {
+	storageProofs := make([]*trie.StorageProof, len(p.Storage))
+	for i, storageProof := range p.Storage {
+		storageProofs[i] = &trie.StorageProof{
+			Key:   storageProof.Key,
+			Value: hexutil.Big(*new(big.Int).SetBytes(storageProof.Value)),
+			Proof: proofFromProto(storageProof.Proof),
+		}
+	}
+
+	return &trie.AccountProof{
+		Address:     gethcommon.BytesToAddress(p.Address),
+		Proof:       proofFromProto(p.Proof),
+		Balance:     hexutil.Big(*new(big.Int).SetBytes(p.Balance)),
+		CodeHash:    gethcommon.BytesToHash(p.CodeHash),
+		Nonce:       p.Nonce,
+		StorageHash: gethcommon.BytesToHash(p.StorageHash),
+		Storage:     storageProofs,
+	}
+}
+
+// proofToProto decodes hex encoded proof nodes
+func proofToProto(proof []string) ([][]byte, error) {
+	nodes := make([][]byte, len(proof))
+	for i, node := range proof {
+		b, err := hexutil.Decode(node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof node %d: %w", i, err)
+		}
+		nodes[i] = b
+	}
+	return nodes, nil
+}
+
+func proofFromProto(nodes [][]byte) []string {
+	proof := make([]string, len(nodes))
+	for i, node := range nodes {
+		proof[i] = hexutil.Encode(node)
+	}
+	return proof
+}
@@ -0,0 +1,116 @@
+package proto
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	ethrpc "github.com/kkrt-labs/go-utils/ethereum/rpc"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/trie"
+	"github.com/kkrt-labs/zk-pig/src/steps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestPreflightData() *steps.PreflightData {
+	to := gethcommon.HexToAddress("0x2")
+	from := gethcommon.HexToAddress("0x1")
+	blockHash := gethcommon.HexToHash("0xb")
+
+	tx := ethrpc.NewTransactionFromGeth(gethtypes.NewTx(&gethtypes.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     1,
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(3),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(4),
+		V:         big.NewInt(1),
+		R:         big.NewInt(5),
+		S:         big.NewInt(6),
+	}))
+	tx.BlockNumber, tx.BlockHash, tx.From = (*hexutil.Big)(big.NewInt(10)), &blockHash, &from
+
+	block := new(ethrpc.Block)
+	block.Header.FromHeader(&gethtypes.Header{
+		Number:          big.NewInt(10),
+		Difficulty:      big.NewInt(0),
+		GasLimit:        30_000_000,
+		GasUsed:         21000,
+		Time:            1234,
+		BaseFee:         big.NewInt(7),
+		WithdrawalsHash: &gethtypes.EmptyWithdrawalsHash,
+		Extra:           []byte{0x01},
+	})
+	block.Hash = blockHash
+	block.Size = 1000
+	block.Transactions = []*ethrpc.Transaction{tx}
+	block.Uncles = []gethcommon.Hash{}
+	block.Withdrawals = gethtypes.Withdrawals{{Index: 1, Validator: 2, Address: to, Amount: 3}}
+
+	return &steps.PreflightData{
+		Block:       block,
+		Ancestors:   []*gethtypes.Header{{Number: big.NewInt(9), Difficulty: big.NewInt(0)}},
+		ChainConfig: params.MainnetChainConfig,
+		Codes:       []hexutil.Bytes{{0x60, 0x00}},
+		PreStateProofs: []*trie.AccountProof{
+			{
+				Address:     to,
+				Proof:       []string{"0xc2c180", "0xc180"},
+				Balance:     hexutil.Big(*big.NewInt(100)),
+				CodeHash:    gethtypes.EmptyCodeHash,
+				Nonce:       1,
+				StorageHash: gethtypes.EmptyRootHash,
+				Storage: []*trie.StorageProof{
+					{Key: "0x0000000000000000000000000000000000000000000000000000000000000001", Value: hexutil.Big(*big.NewInt(5)), Proof: []string{"0xc180"}},
+				},
+			},
+		},
+		PostStateProofs: []*trie.AccountProof{
+			{Address: from, Proof: []string{"0xc180"}},
+		},
+	}
+}
+
+func TestPreflightData(t *testing.T) {
+	data := newTestPreflightData()
+
+	protoData, err := ToProto(data)
+	require.NoError(t, err)
+	b, err := proto.Marshal(protoData)
+	require.NoError(t, err)
+
+	decodedProto := new(PreflightData)
+	require.NoError(t, proto.Unmarshal(b, decodedProto))
+	decoded, err := FromProto(decodedProto)
+	require.NoError(t, err)
+
+	expected, err := json.Marshal(data)
+	require.NoError(t, err)
+	actual, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+
+	// Fields that are not encoded in JSON are preserved
+	tx := decoded.Block.Transactions[0]
+	assert.Equal(t, data.Block.Transactions[0].From, tx.From)
+	assert.Equal(t, data.Block.Transactions[0].BlockHash, tx.BlockHash)
+	assert.Equal(t, data.Block.Transactions[0].BlockNumber, tx.BlockNumber)
+
+	// Protobuf is more compact than JSON (proof nodes are not hex encoded)
+	assert.Less(t, len(b), len(expected))
+}
+
+func TestPreflightDataInvalidProof(t *testing.T) {
+	data := newTestPreflightData()
+	data.PreStateProofs[0].Storage[0].Proof = []string{"not hex"}
+
+	_, err := ToProto(data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid pre-state proof")
+}
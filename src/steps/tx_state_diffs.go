@@ -0,0 +1,281 @@
+package steps
+
+import (
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+)
+
+// txStateDiffTracer is an EVM tracer that records the state diffs of every operation of a block
+// (system calls, transactions, withdrawals), in execution order.
+//
+// Pre-values are the values passed to the state change hooks the first time a field is changed during the operation.
+// As state changes hooks are not called on reverts, post-values of transactions and system calls are read from the state
+// at the end of the operation. Post-values of operations outside transactions and system calls (which can not revert)
+// are the values passed to the last state change hook.
+type txStateDiffTracer struct {
+	state       *gethstate.StateDB
+	blockNumber uint64
+
+	txIndex uint64
+	inCall  bool // whether the current operation is a transaction or a system call
+	current *pendingTxStateDiff
+	diffs   []*input.TxStateDiff
+}
+
+type pendingTxStateDiff struct {
+	diff     *input.TxStateDiff
+	accounts map[gethcommon.Address]*pendingAccountDiff
+	order    []gethcommon.Address
+}
+
+type pendingAccountDiff struct {
+	preBalance, postBalance   *big.Int
+	preNonce, postNonce       *uint64
+	preCodeHash, postCodeHash *gethcommon.Hash
+	preStorage, postStorage   map[gethcommon.Hash]gethcommon.Hash
+}
+
+func newTxStateDiffTracer(state *gethstate.StateDB, blockNumber uint64) *txStateDiffTracer {
+	return &txStateDiffTracer{
+		state:       state,
+		blockNumber: blockNumber,
+	}
+}
+
+// OnTxStart starts recording the state diff of a transaction
+func (t *txStateDiffTracer) OnTxStart(_ *tracing.VMContext, tx *gethtypes.Transaction, _ gethcommon.Address) {
+	t.close()
+	txIndex, txHash := t.txIndex, tx.Hash()
+	t.open(&input.TxStateDiff{
+		Type:    input.TxStateDiffTypeTransaction,
+		TxIndex: &txIndex,
+		TxHash:  &txHash,
+	})
+	t.inCall = true
+	t.txIndex++
+}
+
+// OnTxEnd ends recording the state diff of a transaction
+func (t *txStateDiffTracer) OnTxEnd(_ *gethtypes.Receipt, _ error) {
+	t.close()
+}
+
+// OnSystemCallStartV2 starts recording the state diff of a system call
+func (t *txStateDiffTracer) OnSystemCallStartV2(_ *tracing.VMContext) {
+	t.close()
+	t.open(&input.TxStateDiff{
+		Type: input.TxStateDiffTypeSystemCall,
+	})
+	t.inCall = true
+}
+
+// OnSystemCallEnd ends recording the state diff of a system call
+func (t *txStateDiffTracer) OnSystemCallEnd() {
+	t.close()
+}
+
+// OnEnter records the address of the called system contract
+func (t *txStateDiffTracer) OnEnter(depth int, _ byte, _, to gethcommon.Address, _ []byte, _ uint64, _ *big.Int) {
+	if depth == 0 && t.current != nil && t.current.diff.Type == input.TxStateDiffTypeSystemCall && t.current.diff.SystemAddress == nil {
+		t.current.diff.SystemAddress = &to
+	}
+}
+
+// OnBalanceChange records a balance change
+func (t *txStateDiffTracer) OnBalanceChange(addr gethcommon.Address, prev, newValue *big.Int, reason tracing.BalanceChangeReason) {
+	if reason == tracing.BalanceIncreaseWithdrawal {
+		t.ensure(input.TxStateDiffTypeWithdrawals)
+	} else {
+		t.ensure(input.TxStateDiffTypeBlock)
+	}
+
+	acc := t.account(addr)
+	if acc.preBalance == nil {
+		acc.preBalance = new(big.Int).Set(prev)
+	}
+	acc.postBalance = new(big.Int).Set(newValue)
+}
+
+// OnNonceChangeV2 records a nonce change
+func (t *txStateDiffTracer) OnNonceChangeV2(addr gethcommon.Address, prev, newValue uint64, _ tracing.NonceChangeReason) {
+	t.ensure(input.TxStateDiffTypeBlock)
+
+	acc := t.account(addr)
+	if acc.preNonce == nil {
+		acc.preNonce = &prev
+	}
+	acc.postNonce = &newValue
+}
+
+// OnCodeChange records a code change
+func (t *txStateDiffTracer) OnCodeChange(addr gethcommon.Address, prevCodeHash gethcommon.Hash, _ []byte, codeHash gethcommon.Hash, _ []byte) {
+	t.ensure(input.TxStateDiffTypeBlock)
+
+	acc := t.account(addr)
+	if acc.preCodeHash == nil {
+		acc.preCodeHash = &prevCodeHash
+	}
+	acc.postCodeHash = &codeHash
+}
+
+// OnStorageChange records a storage change
+func (t *txStateDiffTracer) OnStorageChange(addr gethcommon.Address, slot, prev, newValue gethcommon.Hash) {
+	t.ensure(input.TxStateDiffTypeBlock)
+
+	acc := t.account(addr)
+	if _, ok := acc.preStorage[slot]; !ok {
+		acc.preStorage[slot] = prev
+	}
+	acc.postStorage[slot] = newValue
+}
+
+// Hooks returns the tracer hooks
+func (t *txStateDiffTracer) Hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnTxStart:           t.OnTxStart,
+		OnTxEnd:             t.OnTxEnd,
+		OnSystemCallStartV2: t.OnSystemCallStartV2,
+		OnSystemCallEnd:     t.OnSystemCallEnd,
+		OnEnter:             t.OnEnter,
+		OnBalanceChange:     t.OnBalanceChange,
+		OnNonceChangeV2:     t.OnNonceChangeV2,
+		OnCodeChange:        t.OnCodeChange,
+		OnStorageChange:     t.OnStorageChange,
+	}
+}
+
+// TxStateDiffs ends recording and returns the state diffs of every operation of the block, in execution order
+func (t *txStateDiffTracer) TxStateDiffs() []*input.TxStateDiff {
+	t.close()
+	return t.diffs
+}
+
+func (t *txStateDiffTracer) open(diff *input.TxStateDiff) {
+	diff.BlockNumber = t.blockNumber
+	t.current = &pendingTxStateDiff{
+		diff:     diff,
+		accounts: make(map[gethcommon.Address]*pendingAccountDiff),
+	}
+}
+
+// ensure makes sure an operation is being recorded
+// State changes happening outside transactions and system calls are recorded in an operation of the given type
+func (t *txStateDiffTracer) ensure(typ input.TxStateDiffType) {
+	if t.inCall {
+		return
+	}
+	if t.current != nil && t.current.diff.Type != typ {
+		t.close()
+	}
+	if t.current == nil {
+		t.open(&input.TxStateDiff{Type: typ})
+	}
+}
+
+func (t *txStateDiffTracer) account(addr gethcommon.Address) *pendingAccountDiff {
+	acc, ok := t.current.accounts[addr]
+	if !ok {
+		acc = &pendingAccountDiff{
+			preStorage:  make(map[gethcommon.Hash]gethcommon.Hash),
+			postStorage: make(map[gethcommon.Hash]gethcommon.Hash),
+		}
+		t.current.accounts[addr] = acc
+		t.current.order = append(t.current.order, addr)
+	}
+	return acc
+}
+
+// close ends recording the current operation
+func (t *txStateDiffTracer) close() {
+	if t.current == nil {
+		return
+	}
+
+	if t.inCall {
+		t.readPostState()
+	}
+
+	diff := t.current.diff
+	diff.Accounts = make([]*input.AccountDiff, 0, len(t.current.order))
+	for _, addr := range t.current.order {
+		if accDiff := t.current.accounts[addr].accountDiff(addr); accDiff != nil {
+			diff.Accounts = append(diff.Accounts, accDiff)
+		}
+	}
+	t.diffs = append(t.diffs, diff)
+
+	t.current, t.inCall = nil, false
+}
+
+// readPostState sets the post-values of the current operation from the state
+func (t *txStateDiffTracer) readPostState() {
+	for addr, acc := range t.current.accounts {
+		if acc.preBalance != nil {
+			acc.postBalance = t.state.GetBalance(addr).ToBig()
+		}
+		if acc.preNonce != nil {
+			nonce := t.state.GetNonce(addr)
+			acc.postNonce = &nonce
+		}
+		if acc.preCodeHash != nil {
+			codeHash := t.state.GetCodeHash(addr)
+			acc.postCodeHash = &codeHash
+		}
+		for slot := range acc.preStorage {
+			acc.postStorage[slot] = t.state.GetState(addr, slot)
+		}
+	}
+}
+
+// accountDiff returns the diff of the account, or nil if the account has not changed
+func (acc *pendingAccountDiff) accountDiff(addr gethcommon.Address) *input.AccountDiff {
+	diff := &input.AccountDiff{Address: addr}
+	changed := false
+
+	if acc.preBalance != nil && acc.preBalance.Cmp(acc.postBalance) != 0 {
+		diff.PreBalance, diff.PostBalance = acc.preBalance, acc.postBalance
+		changed = true
+	}
+
+	if acc.preNonce != nil && *acc.preNonce != *acc.postNonce {
+		diff.PreNonce, diff.PostNonce = acc.preNonce, acc.postNonce
+		changed = true
+	}
+
+	if acc.preCodeHash != nil {
+		// Accounts that do not exist have a zero code hash
+		preCodeHash, postCodeHash := codeHashOrEmpty(*acc.preCodeHash), codeHashOrEmpty(*acc.postCodeHash)
+		if preCodeHash != postCodeHash {
+			diff.PreCodeHash, diff.PostCodeHash = &preCodeHash, &postCodeHash
+			changed = true
+		}
+	}
+
+	for slot, preValue := range acc.preStorage {
+		if postValue := acc.postStorage[slot]; postValue != preValue {
+			diff.Storage = append(diff.Storage, &input.StorageDiff{
+				Slot:      slot,
+				PreValue:  preValue,
+				PostValue: postValue,
+			})
+		}
+	}
+	changed = changed || len(diff.Storage) > 0
+
+	if !changed {
+		return nil
+	}
+	return diff
+}
+
+func codeHashOrEmpty(codeHash gethcommon.Hash) gethcommon.Hash {
+	if codeHash == (gethcommon.Hash{}) {
+		return gethtypes.EmptyCodeHash
+	}
+	return codeHash
+}
@@ -0,0 +1,96 @@
+package steps
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/kkrt-labs/go-utils/common"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxStateDiffTracer(t *testing.T) {
+	var (
+		sender    = gethcommon.HexToAddress("0xa")
+		contract  = gethcommon.HexToAddress("0xb")
+		validator = gethcommon.HexToAddress("0xc")
+		slot1     = gethcommon.HexToHash("0x1")
+		slot2     = gethcommon.HexToHash("0x2")
+	)
+
+	statedb, err := gethstate.New(gethtypes.EmptyRootHash, gethstate.NewDatabaseForTesting())
+	require.NoError(t, err)
+	statedb.SetBalance(sender, uint256.NewInt(100), tracing.BalanceChangeUnspecified)
+
+	tracer := newTxStateDiffTracer(statedb, 10)
+	hooks := tracer.Hooks()
+	hooked := gethstate.NewHookedState(statedb, hooks)
+
+	// Beacon root system call
+	hooks.OnSystemCallStartV2(nil)
+	hooks.OnEnter(0, 0, params.SystemAddress, params.BeaconRootsAddress, nil, 0, nil)
+	hooked.SetState(params.BeaconRootsAddress, slot1, gethcommon.HexToHash("0x3"))
+	hooks.OnSystemCallEnd()
+
+	// Transaction with a reverted storage change
+	tx := gethtypes.NewTx(&gethtypes.LegacyTx{Nonce: 0})
+	hooks.OnTxStart(nil, tx, sender)
+	hooked.SubBalance(sender, uint256.NewInt(21), tracing.BalanceDecreaseGasBuy)
+	hooked.SetNonce(sender, 1, tracing.NonceChangeEoACall)
+	hooked.SetState(contract, slot1, gethcommon.HexToHash("0x4"))
+	snapshot := hooked.Snapshot()
+	hooked.SetState(contract, slot2, gethcommon.HexToHash("0x5"))
+	hooked.RevertToSnapshot(snapshot)
+	hooks.OnTxEnd(nil, nil)
+
+	// Withdrawals
+	hooked.AddBalance(validator, uint256.NewInt(7), tracing.BalanceIncreaseWithdrawal)
+
+	diffs := tracer.TxStateDiffs()
+	assert.Equal(t, []*input.TxStateDiff{
+		{
+			BlockNumber:   10,
+			Type:          input.TxStateDiffTypeSystemCall,
+			SystemAddress: &params.BeaconRootsAddress,
+			Accounts: []*input.AccountDiff{
+				{
+					Address: params.BeaconRootsAddress,
+					Storage: []*input.StorageDiff{{Slot: slot1, PostValue: gethcommon.HexToHash("0x3")}},
+				},
+			},
+		},
+		{
+			BlockNumber: 10,
+			Type:        input.TxStateDiffTypeTransaction,
+			TxIndex:     common.Ptr(uint64(0)),
+			TxHash:      common.Ptr(tx.Hash()),
+			Accounts: []*input.AccountDiff{
+				{
+					Address:     sender,
+					PreBalance:  big.NewInt(100),
+					PostBalance: big.NewInt(79),
+					PreNonce:    common.Ptr(uint64(0)),
+					PostNonce:   common.Ptr(uint64(1)),
+				},
+				{
+					Address: contract,
+					Storage: []*input.StorageDiff{{Slot: slot1, PostValue: gethcommon.HexToHash("0x4")}},
+				},
+			},
+		},
+		{
+			BlockNumber: 10,
+			Type:        input.TxStateDiffTypeWithdrawals,
+			Accounts: []*input.AccountDiff{
+				{Address: validator, PreBalance: big.NewInt(0), PostBalance: big.NewInt(7)},
+			},
+		},
+	}, diffs)
+}
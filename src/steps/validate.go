@@ -0,0 +1,281 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/evm"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/trie"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+)
+
+//go:generate mockgen -destination=./mock/validator.go -package=mocksteps github.com/kkrt-labs/zk-pig/src/steps Validator
+
+// Validator is the interface for validating a standalone prover input.
+// It checks the structural invariants of the prover input, then runs a full "execution + final state validation".
+type Validator interface {
+	// Validate validates the prover input and returns a report of every check
+	Validate(ctx context.Context, in *input.ProverInput) *ValidationReport
+}
+
+// ValidationCheck is the result of a validation check
+type ValidationCheck struct {
+	Name string
+	Err  error // nil if the check passed
+}
+
+// ValidationReport is the result of the validation of a prover input
+type ValidationReport struct {
+	Checks []*ValidationCheck
+}
+
+// Passed returns whether every check passed
+func (r *ValidationReport) Passed() bool {
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *ValidationReport) add(name string, err error) {
+	r.Checks = append(r.Checks, &ValidationCheck{Name: name, Err: err})
+}
+
+// Names of the validation checks
+const (
+	CheckVersion     = "version"
+	CheckBlocks      = "blocks"
+	CheckChainConfig = "chainConfig"
+	CheckAncestors   = "ancestors"
+	CheckState       = "state"
+	CheckCodes       = "codes"
+	CheckExecution   = "execution"
+)
+
+// ErrCheckSkipped is the error of checks that could not run because a check they depend on failed
+var ErrCheckSkipped = errors.New("skipped")
+
+type validator struct {
+	evm evm.Executor
+}
+
+// NewValidator creates a new Validator.
+func NewValidator() Validator {
+	return NewValidatorFromEvm(evm.NewExecutor())
+}
+
+// NewValidatorFromEvm creates a new Validator from an EVM executor.
+func NewValidatorFromEvm(e evm.Executor) Validator {
+	return &validator{
+		evm: e,
+	}
+}
+
+// Validate runs every check on the prover input
+//
+// - version: the schema version is compatible with the current one (older inputs are migrated in place first, see input.Migrate)
+// - blocks: there is at least one block, and blocks are consecutive
+// - chainConfig: the chain config is present
+// - ancestors: ancestors are contiguous down to the parent of the first block
+// - state: every state node of the witness is reachable from the parent state root
+// - codes: the code of every account executed by the blocks (including through internal and system calls) is in the witness
+// - execution: blocks execute and the final state is valid
+//
+// Structural checks are run first, and the execution is skipped if any of them failed.
+// Codes are checked while executing, as the accounts executed through internal calls are only known at execution.
+func (v *validator) Validate(ctx context.Context, in *input.ProverInput) *ValidationReport {
+	report := new(ValidationReport)
+
+	report.add(CheckVersion, input.Migrate(in))
+	report.add(CheckBlocks, checkBlocks(in))
+	report.add(CheckChainConfig, checkChainConfig(in))
+	if in.Witness == nil {
+		report.add(CheckAncestors, fmt.Errorf("missing witness"))
+		return report
+	}
+
+	parent, err := checkAncestors(in)
+	report.add(CheckAncestors, err)
+	if parent == nil {
+		report.add(CheckState, ErrCheckSkipped)
+	} else {
+		report.add(CheckState, checkState(in, parent.Root))
+	}
+
+	if !report.Passed() {
+		report.add(CheckCodes, ErrCheckSkipped)
+		report.add(CheckExecution, ErrCheckSkipped)
+		return report
+	}
+
+	codes := newCodeChecker(in.Witness.Codes)
+	_, err = NewExecutorFromEvm(codes.decorate(v.evm)).Execute(ctx, in)
+	report.add(CheckCodes, codes.err())
+	report.add(CheckExecution, err)
+
+	return report
+}
+
+func checkBlocks(in *input.ProverInput) error {
+	if len(in.Blocks) == 0 {
+		return fmt.Errorf("no block")
+	}
+	for i, block := range in.Blocks {
+		if block == nil || block.Header == nil {
+			return fmt.Errorf("block %d has no header", i)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := in.Blocks[i-1].Header
+		if block.Header.Number.Uint64() != prev.Number.Uint64()+1 || block.Header.ParentHash != prev.Hash() {
+			return fmt.Errorf("block %v is not the child of block %v", block.Header.Number, prev.Number)
+		}
+	}
+	return nil
+}
+
+func checkChainConfig(in *input.ProverInput) error {
+	if in.ChainConfig == nil {
+		return fmt.Errorf("missing chain config")
+	}
+	if in.ChainConfig.ChainID == nil {
+		return fmt.Errorf("missing chain id")
+	}
+	return nil
+}
+
+// checkAncestors checks that ancestors are contiguous down to the parent of the first block, and returns the parent
+func checkAncestors(in *input.ProverInput) (*gethtypes.Header, error) {
+	if len(in.Blocks) == 0 || in.Blocks[0] == nil || in.Blocks[0].Header == nil {
+		return nil, ErrCheckSkipped
+	}
+
+	ancestors := make([]*gethtypes.Header, len(in.Witness.Ancestors))
+	copy(ancestors, in.Witness.Ancestors)
+	sort.Slice(ancestors, func(i, j int) bool {
+		return ancestors[i].Number.Cmp(ancestors[j].Number) > 0
+	})
+
+	first := in.Blocks[0].Header
+	if len(ancestors) == 0 || ancestors[0].Hash() != first.ParentHash {
+		return nil, fmt.Errorf("missing parent %v of block %v", first.ParentHash, first.Number)
+	}
+	for i := 1; i < len(ancestors); i++ {
+		if ancestors[i-1].ParentHash != ancestors[i].Hash() {
+			return ancestors[0], fmt.Errorf("ancestors are not contiguous: missing parent of block %v", ancestors[i-1].Number)
+		}
+	}
+
+	return ancestors[0], nil
+}
+
+// checkState checks that every state node is reachable from the parent state root
+func checkState(in *input.ProverInput, root gethcommon.Hash) error {
+	nodes := make(map[gethcommon.Hash][]byte, len(in.Witness.State))
+	for _, node := range in.Witness.State {
+		nodes[crypto.Keccak256Hash(node)] = node
+	}
+
+	if _, ok := nodes[root]; !ok && root != gethtypes.EmptyRootHash {
+		return fmt.Errorf("missing parent state root node %v", root)
+	}
+
+	reachable, err := trie.ReachableNodes(root, nodes)
+	if err != nil {
+		return err
+	}
+
+	if unreachable := len(nodes) - len(reachable); unreachable > 0 {
+		return fmt.Errorf("%d of %d state nodes are unreachable from parent state root %v", unreachable, len(nodes), root)
+	}
+	return nil
+}
+
+// codeChecker checks that the code of every account executed by the blocks is in the witness
+//
+// Code deployed while executing the blocks is not expected in the witness.
+type codeChecker struct {
+	codes   map[gethcommon.Hash][]byte
+	state   tracing.StateDB
+	block   *gethtypes.Block
+	missing error // first missing code
+}
+
+func newCodeChecker(codes [][]byte) *codeChecker {
+	c := &codeChecker{codes: make(map[gethcommon.Hash][]byte, len(codes))}
+	for _, code := range codes {
+		c.codes[crypto.Keccak256Hash(code)] = code
+	}
+	return c
+}
+
+// decorate attaches the code checker tracer to every execution of the given EVM executor
+func (c *codeChecker) decorate(e evm.Executor) evm.Executor {
+	return evm.ExecutorFunc(func(ctx context.Context, params *evm.ExecParams) (*core.ProcessResult, error) {
+		c.block = params.Block
+		evm.AddTracer(params.VMConfig, c.hooks())
+		return e.Execute(ctx, params)
+	})
+}
+
+func (c *codeChecker) hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnTxStart: func(vmCtx *tracing.VMContext, _ *gethtypes.Transaction, _ gethcommon.Address) {
+			c.state = vmCtx.StateDB
+		},
+		OnSystemCallStartV2: func(vmCtx *tracing.VMContext) {
+			c.state = vmCtx.StateDB
+		},
+		OnEnter: func(_ int, typ byte, _, to gethcommon.Address, _ []byte, _ uint64, _ *big.Int) {
+			switch vm.OpCode(typ) {
+			case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+				c.check(to)
+			}
+		},
+		OnCodeChange: func(_ gethcommon.Address, _ gethcommon.Hash, _ []byte, codeHash gethcommon.Hash, code []byte) {
+			c.codes[codeHash] = code
+		},
+	}
+}
+
+// check checks that the code of the called account (and of its EIP-7702 delegation target) is known
+func (c *codeChecker) check(addr gethcommon.Address) {
+	if c.missing != nil || c.state == nil {
+		return
+	}
+	if code := c.code(addr); code != nil {
+		if target, ok := gethtypes.ParseDelegation(code); ok {
+			c.code(target)
+		}
+	}
+}
+
+// code returns the known code of the account, recording it as missing if it is unknown
+func (c *codeChecker) code(addr gethcommon.Address) []byte {
+	codeHash := c.state.GetCodeHash(addr)
+	if codeHash == (gethcommon.Hash{}) || codeHash == gethtypes.EmptyCodeHash {
+		return nil
+	}
+
+	code, ok := c.codes[codeHash]
+	if !ok {
+		c.missing = fmt.Errorf("missing code %v of account %v (executed in block %v)", codeHash, addr, c.block.Number())
+	}
+	return code
+}
+
+func (c *codeChecker) err() error {
+	return c.missing
+}
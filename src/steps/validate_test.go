@@ -0,0 +1,180 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/hashdb"
+	"github.com/holiman/uint256"
+	"github.com/kkrt-labs/zk-pig/src/ethereum/evm"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEvm returns an EVM executor that does not execute blocks and returns err
+func testEvm(err error) evm.Executor {
+	return evm.ExecutorFunc(func(_ context.Context, _ *evm.ExecParams) (*core.ProcessResult, error) {
+		return nil, err
+	})
+}
+
+func testValidationInput(t *testing.T) *input.ProverInput {
+	var (
+		eoa      = gethcommon.HexToAddress("0xa")
+		contract = gethcommon.HexToAddress("0xb")
+		code     = []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	)
+
+	db := rawdb.NewMemoryDatabase()
+	tdb := triedb.NewDatabase(db, &triedb.Config{HashDB: &hashdb.Config{}})
+	statedb, err := gethstate.New(gethcommon.Hash{}, gethstate.NewDatabase(tdb, nil))
+	require.NoError(t, err)
+	statedb.SetBalance(eoa, uint256.NewInt(1e18), tracing.BalanceChangeUnspecified)
+	statedb.SetCode(contract, code)
+	statedb.SetState(contract, gethcommon.HexToHash("0x1"), gethcommon.HexToHash("0x2"))
+	root, err := statedb.Commit(0, false, false)
+	require.NoError(t, err)
+	require.NoError(t, tdb.Commit(root, false))
+
+	var state [][]byte
+	it := db.NewIterator(nil, nil)
+	for it.Next() {
+		if len(it.Key()) == gethcommon.HashLength {
+			state = append(state, gethcommon.CopyBytes(it.Value()))
+		}
+	}
+	it.Release()
+
+	grandParent := &gethtypes.Header{Number: big.NewInt(8), Difficulty: big.NewInt(0)}
+	parent := &gethtypes.Header{Number: big.NewInt(9), Difficulty: big.NewInt(0), ParentHash: grandParent.Hash(), Root: root}
+	block := &gethtypes.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0), ParentHash: parent.Hash()}
+
+	return &input.ProverInput{
+		Version:     input.SchemaVersion,
+		ChainConfig: params.MergedTestChainConfig,
+		Blocks: []*input.Block{
+			{
+				Header:       block,
+				Transactions: []*gethtypes.Transaction{gethtypes.NewTx(&gethtypes.LegacyTx{To: &contract})},
+			},
+		},
+		Witness: &input.Witness{
+			State:     state,
+			Ancestors: []*gethtypes.Header{grandParent, parent},
+			Codes:     [][]byte{code},
+		},
+	}
+}
+
+func checkErrors(report *ValidationReport) map[string]error {
+	errs := make(map[string]error)
+	for _, check := range report.Checks {
+		errs[check.Name] = check.Err
+	}
+	return errs
+}
+
+func TestValidator(t *testing.T) {
+	t.Run("valid input", func(t *testing.T) {
+		v := &validator{evm: testEvm(nil)}
+		report := v.Validate(context.Background(), testValidationInput(t))
+		assert.True(t, report.Passed())
+		assert.Len(t, report.Checks, 7)
+	})
+
+	t.Run("unversioned input", func(t *testing.T) {
+		// Inputs generated before versioning was introduced are migrated
+		in := testValidationInput(t)
+		in.Version = ""
+		v := &validator{evm: testEvm(nil)}
+		report := v.Validate(context.Background(), in)
+		assert.True(t, report.Passed())
+		assert.Equal(t, input.SchemaVersion, in.Version)
+	})
+
+	t.Run("execution failure", func(t *testing.T) {
+		v := &validator{evm: testEvm(fmt.Errorf("invalid state root"))}
+		report := v.Validate(context.Background(), testValidationInput(t))
+		assert.False(t, report.Passed())
+		assert.ErrorContains(t, checkErrors(report)[CheckExecution], "invalid state root")
+	})
+
+	testCases := []struct {
+		desc   string
+		modify func(in *input.ProverInput)
+		failed []string
+	}{
+		{
+			desc:   "incompatible version",
+			modify: func(in *input.ProverInput) { in.Version = "99.0.0" },
+			failed: []string{CheckVersion},
+		},
+		{
+			desc:   "missing chain config",
+			modify: func(in *input.ProverInput) { in.ChainConfig = nil },
+			failed: []string{CheckChainConfig},
+		},
+		{
+			desc:   "missing parent",
+			modify: func(in *input.ProverInput) { in.Witness.Ancestors = in.Witness.Ancestors[:1] },
+			failed: []string{CheckAncestors},
+		},
+		{
+			desc: "non contiguous ancestors",
+			modify: func(in *input.ProverInput) {
+				in.Witness.Ancestors[0] = &gethtypes.Header{Number: big.NewInt(7), Difficulty: big.NewInt(0)}
+			},
+			failed: []string{CheckAncestors},
+		},
+		{
+			desc:   "unreachable state node",
+			modify: func(in *input.ProverInput) { in.Witness.State = append(in.Witness.State, []byte{0xc2, 0x80, 0x80}) },
+			failed: []string{CheckState},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			in := testValidationInput(t)
+			tc.modify(in)
+
+			v := &validator{evm: testEvm(nil)}
+			errs := checkErrors(v.Validate(context.Background(), in))
+			for _, name := range tc.failed {
+				assert.Error(t, errs[name], name)
+			}
+			assert.ErrorIs(t, errs[CheckCodes], ErrCheckSkipped)
+			assert.ErrorIs(t, errs[CheckExecution], ErrCheckSkipped)
+		})
+	}
+}
+
+func TestValidatorCodes(t *testing.T) {
+	// The witness of the test input holds every node of the chain states, it is minimized so every node is reachable
+	in, err := NewMinimizer().Minimize(context.Background(), newTestMinimizerInput(t))
+	require.NoError(t, err)
+
+	t.Run("valid input", func(t *testing.T) {
+		report := NewValidator().Validate(context.Background(), in)
+		assert.True(t, report.Passed())
+	})
+
+	t.Run("missing code of a called contract", func(t *testing.T) {
+		in.Witness.Codes = nil
+
+		errs := checkErrors(NewValidator().Validate(context.Background(), in))
+		assert.ErrorContains(t, errs[CheckCodes], "missing code")
+		assert.ErrorContains(t, errs[CheckCodes], "0x00000000000000000000000000000000000000C0")
+	})
+}
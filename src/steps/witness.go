@@ -0,0 +1,78 @@
+package steps
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/core/stateless"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+)
+
+// witnessBuilder merges the witnesses collected while executing consecutive blocks into a single witness.
+//
+// The merged witness contains
+// - the state nodes required to execute the first block, plus the nodes required by every subsequent block
+// that are not committed by a previous block (those nodes are recomputed by the prover while executing the range)
+// - the codes accessed by any of the blocks
+// - the ancestors of the first block required by any of the blocks
+type witnessBuilder struct {
+	firstNumber uint64
+
+	ancestors map[uint64]*gethtypes.Header
+	codes     map[string]struct{}
+	state     map[string]struct{}
+	committed map[string]struct{}
+}
+
+func newWitnessBuilder(firstNumber uint64) *witnessBuilder {
+	return &witnessBuilder{
+		firstNumber: firstNumber,
+		ancestors:   make(map[uint64]*gethtypes.Header),
+		codes:       make(map[string]struct{}),
+		state:       make(map[string]struct{}),
+		committed:   make(map[string]struct{}),
+	}
+}
+
+// add merges the witness of the next block of the range
+func (b *witnessBuilder) add(w *stateless.Witness) {
+	for _, header := range w.Headers {
+		// Headers of blocks within the range are provided as part of the prover input blocks
+		if header.Number.Uint64() < b.firstNumber {
+			b.ancestors[header.Number.Uint64()] = header
+		}
+	}
+
+	for code := range w.Codes {
+		b.codes[code] = struct{}{}
+	}
+
+	for node := range w.State {
+		if _, ok := b.committed[node]; !ok {
+			b.state[node] = struct{}{}
+		}
+	}
+
+	for node := range w.Committed {
+		b.committed[node] = struct{}{}
+	}
+}
+
+// witness returns the merged witness
+func (b *witnessBuilder) witness() *input.Witness {
+	ancestors := make([]*gethtypes.Header, 0, len(b.ancestors))
+	for _, header := range b.ancestors {
+		ancestors = append(ancestors, header)
+	}
+
+	// Ancestors are in reverse order (0=parent, 1=parent's-parent, etc.)
+	sort.Slice(ancestors, func(i, j int) bool {
+		return ancestors[i].Number.Uint64() > ancestors[j].Number.Uint64()
+	})
+
+	return &input.Witness{
+		Ancestors: ancestors,
+		Codes:     witnessToBytes(b.codes),
+		State:     witnessToBytes(b.state),
+	}
+}
@@ -0,0 +1,41 @@
+package steps
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/stateless"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWitnessBuilder(t *testing.T) {
+	b := newWitnessBuilder(10)
+
+	b.add(&stateless.Witness{
+		Headers:   []*gethtypes.Header{{Number: big.NewInt(9)}, {Number: big.NewInt(8)}},
+		Codes:     map[string]struct{}{"code1": {}},
+		State:     map[string]struct{}{"node1": {}, "node2": {}},
+		Committed: map[string]struct{}{"node3": {}},
+	})
+	b.add(&stateless.Witness{
+		Headers:   []*gethtypes.Header{{Number: big.NewInt(10)}, {Number: big.NewInt(7)}},
+		Codes:     map[string]struct{}{"code1": {}, "code2": {}},
+		State:     map[string]struct{}{"node2": {}, "node3": {}, "node4": {}},
+		Committed: map[string]struct{}{"node5": {}},
+	})
+
+	w := b.witness()
+
+	// Ancestors are the headers before the first block, in reverse order
+	assert.Len(t, w.Ancestors, 3)
+	assert.Equal(t, uint64(9), w.Ancestors[0].Number.Uint64())
+	assert.Equal(t, uint64(8), w.Ancestors[1].Number.Uint64())
+	assert.Equal(t, uint64(7), w.Ancestors[2].Number.Uint64())
+
+	assert.ElementsMatch(t, [][]byte{[]byte("code1"), []byte("code2")}, w.Codes)
+
+	// node3 is committed by the first block so it is not part of the merged witness
+	assert.ElementsMatch(t, [][]byte{[]byte("node1"), []byte("node2"), []byte("node4")}, w.State)
+	assert.Len(t, b.committed, 2)
+}
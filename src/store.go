@@ -24,6 +24,7 @@ var (
 	blockStoreComponentName         = fmt.Sprintf("%s.block", storeComponentName)
 	proverInputStoreComponentName   = "prover-input-store"
 	preflightDataStoreComponentName = "preflight-data-store"
+	traceStoreComponentName         = fmt.Sprintf("%s.trace", storeComponentName)
 )
 
 func (a *App) BlockStore() inputstore.BlockStore {
@@ -80,7 +81,19 @@ func (a *App) PreflightDataStore() inputstore.PreflightDataStore {
 		a,
 		preflightDataStoreComponentName,
 		func() (inputstore.PreflightDataStore, error) {
-			return inputstore.NewPreflightDataStore(a.Store())
+			cfg := a.Config().ProverInputs
+
+			return inputstore.NewPreflightDataStore(a.Store(), inputstore.PreflightContentType(common.Val(cfg.ContentType)))
+		},
+	)
+}
+
+func (a *App) TraceStore() inputstore.TraceStore {
+	return provide(
+		a,
+		traceStoreComponentName,
+		func() (inputstore.TraceStore, error) {
+			return inputstore.NewTraceStore(a.Store()), nil
 		},
 	)
 }
@@ -89,13 +102,24 @@ func (a *App) Store() store.Store {
 	return provide(
 		a,
 		storeComponentName,
+		func() (store.Store, error) {
+			return a.StoreWithContentEncoding(common.Val(a.Config().Store.ContentEncoding)), nil
+		},
+	)
+}
+
+// StoreWithContentEncoding returns a store over the configured file and S3 stores compressing data with the given content encoding
+func (a *App) StoreWithContentEncoding(encoding store.ContentEncoding) store.Store {
+	return provide(
+		a,
+		fmt.Sprintf("%s.%s", storeComponentName, encoding),
 		func() (store.Store, error) {
 			multiStore := multistore.New(
 				a.FileStore(),
 				a.S3Store(),
 			)
 
-			compressedStore, err := compressstore.New(multiStore, compressstore.WithContentEncoding(common.Val(a.Config().Store.ContentEncoding)))
+			compressedStore, err := compressstore.New(multiStore, compressstore.WithContentEncoding(encoding))
 			if err != nil {
 				return nil, fmt.Errorf("failed to create compressed store: %w", err)
 			}
@@ -0,0 +1,292 @@
+package store
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	store "github.com/kkrt-labs/go-utils/store"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/kkrt-labs/zk-pig/src/prover-input/execwitness"
+	protoinput "github.com/kkrt-labs/zk-pig/src/prover-input/proto"
+	sszinput "github.com/kkrt-labs/zk-pig/src/prover-input/ssz"
+	"github.com/kkrt-labs/zk-pig/src/steps"
+	protopreflight "github.com/kkrt-labs/zk-pig/src/steps/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// MarshalProverInput encodes a prover input in the given content type
+func MarshalProverInput(contentType ContentType, data *input.ProverInput) ([]byte, error) {
+	switch contentType {
+	case ContentTypeProtobuf:
+		protoMsg, err := protoinput.ToProto(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert to protobuf: %w", err)
+		}
+		protoBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(protoMsg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal protobuf: %w", err)
+		}
+		return protoBytes, nil
+	case ContentTypeJSON:
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(data); err != nil {
+			return nil, fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return buf.Bytes(), nil
+	case ContentTypeExecutionWitness:
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(execwitness.ToExecWitness(data)); err != nil {
+			return nil, fmt.Errorf("failed to encode execution witness: %w", err)
+		}
+		return buf.Bytes(), nil
+	case ContentTypeProtobufStream:
+		buf := new(bytes.Buffer)
+		if err := protoinput.WriteStream(buf, data, protoinput.DefaultStreamChunkSize); err != nil {
+			return nil, fmt.Errorf("failed to write protobuf stream: %w", err)
+		}
+		return buf.Bytes(), nil
+	case ContentTypeSSZ:
+		sszBytes, err := sszinput.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal SSZ: %w", err)
+		}
+		return sszBytes, nil
+	default:
+		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+}
+
+// UnmarshalProverInput decodes a prover input encoded in the given content type
+func UnmarshalProverInput(contentType ContentType, b []byte) (*input.ProverInput, error) {
+	switch contentType {
+	case ContentTypeJSON:
+		data := &input.ProverInput{}
+		if err := json.Unmarshal(b, data); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON: %w", err)
+		}
+		return data, nil
+	case ContentTypeProtobuf:
+		protoMsg := &protoinput.ProverInput{}
+		if err := proto.Unmarshal(b, protoMsg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal protobuf: %w", err)
+		}
+		data, err := protoinput.FromProto(protoMsg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert from protobuf: %w", err)
+		}
+		return data, nil
+	case ContentTypeExecutionWitness:
+		execWitness := &execwitness.ProverInput{}
+		if err := json.Unmarshal(b, execWitness); err != nil {
+			return nil, fmt.Errorf("failed to decode execution witness: %w", err)
+		}
+		return execwitness.FromExecWitness(execWitness), nil
+	case ContentTypeProtobufStream:
+		r := bytes.NewReader(b)
+		data, err := protoinput.ReadStream(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read protobuf stream: %w", err)
+		}
+		if r.Len() != 0 {
+			return nil, fmt.Errorf("failed to read protobuf stream: %d bytes of trailing data", r.Len())
+		}
+		return data, nil
+	case ContentTypeSSZ:
+		data, err := sszinput.Unmarshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SSZ: %w", err)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+}
+
+// MarshalPreflightData encodes preflight data in the given content type (JSON or protobuf)
+func MarshalPreflightData(contentType store.ContentType, data *steps.PreflightData) ([]byte, error) {
+	switch contentType {
+	case store.ContentTypeJSON:
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(data); err != nil {
+			return nil, fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return buf.Bytes(), nil
+	case store.ContentTypeProtobuf:
+		protoMsg, err := protopreflight.ToProto(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert to protobuf: %w", err)
+		}
+		protoBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(protoMsg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal protobuf: %w", err)
+		}
+		return protoBytes, nil
+	default:
+		return nil, fmt.Errorf("unsupported preflight data content type: %s", contentType)
+	}
+}
+
+// UnmarshalPreflightData decodes preflight data encoded in the given content type (JSON or protobuf)
+func UnmarshalPreflightData(contentType store.ContentType, b []byte) (*steps.PreflightData, error) {
+	switch contentType {
+	case store.ContentTypeJSON:
+		data := &steps.PreflightData{}
+		if err := json.Unmarshal(b, data); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON: %w", err)
+		}
+		return data, nil
+	case store.ContentTypeProtobuf:
+		protoMsg := &protopreflight.PreflightData{}
+		if err := proto.Unmarshal(b, protoMsg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal protobuf: %w", err)
+		}
+		data, err := protopreflight.FromProto(protoMsg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert from protobuf: %w", err)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unsupported preflight data content type: %s", contentType)
+	}
+}
+
+// Format is the content type and content encoding of an encoded prover input
+type Format struct {
+	ContentType     ContentType
+	ContentEncoding store.ContentEncoding
+}
+
+func (f *Format) String() string {
+	return fmt.Sprintf("%s (%s)", f.ContentType, f.ContentEncoding)
+}
+
+// DecodeProverInput decodes a prover input, detecting its content encoding (compression) and content type
+//
+// gzip and zlib are detected from their header, and data that is not recognized otherwise is tried as flate.
+// JSON, execution witness JSON, SSZ, protobuf stream and protobuf are detected from the decompressed data.
+// As when loaded from a store, prover inputs with an older schema version are upgraded to the current one (see input.Migrate).
+func DecodeProverInput(b []byte) (*input.ProverInput, *Format, error) {
+	format := &Format{ContentEncoding: store.ContentEncodingPlain}
+
+	switch {
+	case len(b) >= 2 && b[0] == 0x1f && b[1] == 0x8b:
+		format.ContentEncoding = store.ContentEncodingGzip
+	case len(b) >= 2 && b[0]&0x0f == 8 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0:
+		format.ContentEncoding = store.ContentEncodingZlib
+	}
+
+	plain, err := decompress(format.ContentEncoding, b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, ct, err := detectProverInput(plain)
+	if err != nil && format.ContentEncoding == store.ContentEncodingPlain {
+		// flate streams have no header, so they can only be detected by trying to decompress them
+		if inflated, flateErr := decompress(store.ContentEncodingFlate, b); flateErr == nil {
+			if flateData, flateCt, flateErr := detectProverInput(inflated); flateErr == nil {
+				format.ContentEncoding = store.ContentEncodingFlate
+				data, ct, err = flateData, flateCt, nil
+			}
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	format.ContentType = ct
+
+	if err := input.Migrate(data); err != nil {
+		return nil, nil, fmt.Errorf("incompatible prover input: %w", err)
+	}
+
+	return data, format, nil
+}
+
+func decompress(encoding store.ContentEncoding, b []byte) ([]byte, error) {
+	var (
+		r   io.ReadCloser
+		err error
+	)
+	switch encoding {
+	case store.ContentEncodingPlain:
+		return b, nil
+	case store.ContentEncodingGzip:
+		r, err = gzip.NewReader(bytes.NewReader(b))
+	case store.ContentEncodingZlib:
+		r, err = zlib.NewReader(bytes.NewReader(b))
+	case store.ContentEncodingFlate:
+		r = flate.NewReader(bytes.NewReader(b))
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s data: %w", encoding, err)
+	}
+	defer r.Close()
+
+	plain, err := readAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s data: %w", encoding, err)
+	}
+	return plain, nil
+}
+
+// readAll reads all data from a possibly decompressing reader
+//
+// The go-utils compress store flushes the compression stream before storing data but closes it afterwards, so stored
+// data misses the compression stream trailer. Such data is read up to the unexpected EOF, content decoders detecting truncated data.
+func readAll(r io.Reader) ([]byte, error) {
+	b, err := io.ReadAll(r)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return b, nil
+}
+
+// sszFirstOffset is the first offset of an SSZ encoded prover input (5 variable-size fields)
+const sszFirstOffset = 5 * 4
+
+func detectProverInput(b []byte) (*input.ProverInput, ContentType, error) {
+	trimmed := bytes.TrimLeft(b, " \t\r\n")
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		// Execution witnesses are distinguished by their top level witness fields
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &probe); err != nil {
+			return nil, ContentTypeUnknown, fmt.Errorf("failed to decode JSON: %w", err)
+		}
+		ct := ContentTypeJSON
+		if _, ok := probe["headers"]; ok {
+			ct = ContentTypeExecutionWitness
+		}
+		data, err := UnmarshalProverInput(ct, b)
+		return data, ct, err
+	case len(b) >= 4 && binary.LittleEndian.Uint32(b) == sszFirstOffset:
+		// A protobuf message can not start with such bytes (0x14 is an invalid end group tag)
+		data, err := UnmarshalProverInput(ContentTypeSSZ, b)
+		return data, ContentTypeSSZ, err
+	default:
+		// Protobuf streams are stricter to decode than protobuf messages (they must be fully consumed), so they are tried first
+		if isProtobufStream(b) {
+			if data, err := UnmarshalProverInput(ContentTypeProtobufStream, b); err == nil {
+				return data, ContentTypeProtobufStream, nil
+			}
+		}
+		data, err := UnmarshalProverInput(ContentTypeProtobuf, b)
+		return data, ContentTypeProtobuf, err
+	}
+}
+
+// isProtobufStream returns whether b starts with the size prefix of a message that fits in b
+func isProtobufStream(b []byte) bool {
+	size, n := protowire.ConsumeVarint(b)
+	return n > 0 && size <= uint64(len(b)-n)
+}
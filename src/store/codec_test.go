@@ -0,0 +1,90 @@
+package store
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/kkrt-labs/go-utils/store"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compress(t *testing.T, encoding store.ContentEncoding, b []byte) []byte {
+	buf := new(bytes.Buffer)
+	var w io.WriteCloser
+	switch encoding {
+	case store.ContentEncodingPlain:
+		return b
+	case store.ContentEncodingGzip:
+		w = gzip.NewWriter(buf)
+	case store.ContentEncodingZlib:
+		w = zlib.NewWriter(buf)
+	case store.ContentEncodingFlate:
+		var err error
+		w, err = flate.NewWriter(buf, flate.BestCompression)
+		require.NoError(t, err)
+	}
+	_, err := w.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestDecodeProverInput(t *testing.T) {
+	in := &input.ProverInput{
+		Version:     input.SchemaVersion,
+		ChainConfig: &params.ChainConfig{ChainID: big.NewInt(2)},
+		Blocks: []*input.Block{
+			{
+				Header: &gethtypes.Header{
+					Number:          big.NewInt(15),
+					Difficulty:      big.NewInt(15),
+					BaseFee:         big.NewInt(15),
+					WithdrawalsHash: &gethcommon.Hash{0x1},
+				},
+			},
+		},
+		Witness: &input.Witness{State: [][]byte{{0x01}}},
+	}
+
+	contentTypes := []ContentType{ContentTypeJSON, ContentTypeProtobuf, ContentTypeExecutionWitness, ContentTypeSSZ, ContentTypeProtobufStream}
+	encodings := []store.ContentEncoding{store.ContentEncodingPlain, store.ContentEncodingGzip, store.ContentEncodingZlib, store.ContentEncodingFlate}
+	for _, ct := range contentTypes {
+		for _, ce := range encodings {
+			t.Run(fmt.Sprintf("%s/%s", ct, ce), func(t *testing.T) {
+				b, err := MarshalProverInput(ct, in)
+				require.NoError(t, err)
+
+				decoded, format, err := DecodeProverInput(compress(t, ce, b))
+				require.NoError(t, err)
+				assert.Equal(t, &Format{ContentType: ct, ContentEncoding: ce}, format)
+				assert.Equal(t, in.Blocks[0].Header.Number, decoded.Blocks[0].Header.Number)
+				assert.Equal(t, in.Witness.State, decoded.Witness.State)
+			})
+		}
+	}
+
+	_, _, err := DecodeProverInput([]byte("{invalid"))
+	assert.Error(t, err)
+}
+
+func TestDecodeProverInputVersion(t *testing.T) {
+	// Unversioned prover inputs are migrated to the current version
+	decoded, _, err := DecodeProverInput([]byte(`{"version":""}`))
+	require.NoError(t, err)
+	assert.Equal(t, input.SchemaVersion, decoded.Version)
+
+	// Prover inputs with an incompatible version are rejected
+	_, _, err = DecodeProverInput([]byte(`{"version":"99.0.0"}`))
+	assert.Error(t, err)
+}
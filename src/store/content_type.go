@@ -0,0 +1,79 @@
+package store
+
+import (
+	"fmt"
+
+	store "github.com/kkrt-labs/go-utils/store"
+)
+
+// ContentType is the content type of stored prover inputs
+//
+// It extends the content types of the go-utils store (that only supports JSON and protobuf) with the prover input specific ones.
+// It is mapped to a go-utils content type when declared in store headers (see StoreContentType).
+type ContentType int
+
+const (
+	ContentTypeUnknown          ContentType = iota
+	ContentTypeJSON                         // JSON
+	ContentTypeProtobuf                     // Protobuf
+	ContentTypeExecutionWitness             // go-ethereum execution witness (JSON)
+	ContentTypeSSZ                          // SimpleSerialize
+	ContentTypeProtobufStream               // Length-delimited protobuf stream (header followed by chunks of state nodes and codes)
+)
+
+var contentTypes = map[ContentType]struct {
+	str  string
+	ext  string
+	base store.ContentType // content type declared in the store headers
+}{
+	ContentTypeJSON:             {"application/json", "json", store.ContentTypeJSON},
+	ContentTypeProtobuf:         {"application/protobuf", "protobuf", store.ContentTypeProtobuf},
+	ContentTypeExecutionWitness: {"application/execution-witness+json", "witness.json", store.ContentTypeJSON},
+	ContentTypeSSZ:              {"application/ssz", "ssz", store.ContentTypeUnknown}, // go-utils store has no octet-stream content type, so none is declared
+	ContentTypeProtobufStream:   {"application/protobuf-stream", "stream.pb", store.ContentTypeProtobuf},
+}
+
+// ParseContentType parses a prover input content type
+func ParseContentType(contentType string) (ContentType, error) {
+	for ct, desc := range contentTypes {
+		if desc.str == contentType {
+			return ct, nil
+		}
+	}
+	return ContentTypeUnknown, fmt.Errorf("invalid content type: %s", contentType)
+}
+
+func (ct ContentType) String() string {
+	if desc, ok := contentTypes[ct]; ok {
+		return desc.str
+	}
+	return "unknown"
+}
+
+// FilePath returns the path of a key for the content type
+func (ct ContentType) FilePath(key string) string {
+	if desc, ok := contentTypes[ct]; ok {
+		return fmt.Sprintf("%s.%s", key, desc.ext)
+	}
+	return key
+}
+
+// StoreContentType returns the go-utils content type of the content type (e.g. to be declared in store headers)
+func (ct ContentType) StoreContentType() store.ContentType {
+	if desc, ok := contentTypes[ct]; ok {
+		return desc.base
+	}
+	return store.ContentTypeUnknown
+}
+
+// PreflightContentType returns the content type of preflight data stored along prover inputs of the given content type
+//
+// Preflight data is encoded in protobuf if prover inputs are encoded in protobuf (or protobuf stream), and in JSON otherwise.
+func PreflightContentType(proverInputContentType ContentType) store.ContentType {
+	switch proverInputContentType {
+	case ContentTypeProtobuf, ContentTypeProtobufStream:
+		return store.ContentTypeProtobuf
+	default:
+		return store.ContentTypeJSON
+	}
+}
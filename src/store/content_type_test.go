@@ -0,0 +1,36 @@
+package store
+
+import (
+	"testing"
+
+	store "github.com/kkrt-labs/go-utils/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContentType(t *testing.T) {
+	for _, ct := range []ContentType{ContentTypeJSON, ContentTypeProtobuf, ContentTypeExecutionWitness, ContentTypeSSZ, ContentTypeProtobufStream} {
+		parsed, err := ParseContentType(ct.String())
+		require.NoError(t, err)
+		assert.Equal(t, ct, parsed)
+	}
+
+	_, err := ParseContentType("application/unknown")
+	require.Error(t, err)
+}
+
+func TestFilePath(t *testing.T) {
+	assert.Equal(t, "/1/2/zkpi.json", ContentTypeJSON.FilePath("/1/2/zkpi"))
+	assert.Equal(t, "/1/2/zkpi.protobuf", ContentTypeProtobuf.FilePath("/1/2/zkpi"))
+	assert.Equal(t, "/1/2/zkpi.witness.json", ContentTypeExecutionWitness.FilePath("/1/2/zkpi"))
+	assert.Equal(t, "/1/2/zkpi.ssz", ContentTypeSSZ.FilePath("/1/2/zkpi"))
+	assert.Equal(t, "/1/2/zkpi.stream.pb", ContentTypeProtobufStream.FilePath("/1/2/zkpi"))
+}
+
+func TestStoreContentType(t *testing.T) {
+	assert.Equal(t, store.ContentTypeJSON, ContentTypeJSON.StoreContentType())
+	assert.Equal(t, store.ContentTypeJSON, ContentTypeExecutionWitness.StoreContentType())
+	assert.Equal(t, store.ContentTypeProtobuf, ContentTypeProtobuf.StoreContentType())
+	assert.Equal(t, store.ContentTypeProtobuf, ContentTypeProtobufStream.StoreContentType())
+	assert.Equal(t, store.ContentTypeUnknown, ContentTypeSSZ.StoreContentType())
+}
@@ -0,0 +1,157 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	store "github.com/kkrt-labs/go-utils/store"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+)
+
+// ConvertProverInputs converts the prover inputs of the blocks [from, to] of a chain from a store to another
+// (typically the same underlying store with different content types or content encodings).
+//
+// Prover inputs covering multiple blocks (stored under the range of blocks they cover) are converted as long as
+// their range is within [from, to]. They are probed from the longest range starting at a block not found as a single block,
+// so converting a large range with few stored blocks issues many loads.
+//
+// Every converted prover input is loaded back from dst and checked to be equal to the prover input loaded from src.
+// Blocks which prover input is not found in src are skipped. It returns the numbers of the converted blocks.
+func ConvertProverInputs(ctx context.Context, src, dst ProverInputStore, chainID, from, to uint64) ([]uint64, error) {
+	return convertRange(from, to, func(blockNumber uint64) (uint64, error) {
+		data, err := loadProverInputFrom(ctx, src, chainID, blockNumber, to)
+		if err != nil {
+			return 0, err
+		}
+		if err := dst.StoreProverInput(ctx, data); err != nil {
+			return 0, fmt.Errorf("failed to store prover input: %w", err)
+		}
+		lastBlockNumber := data.Blocks[len(data.Blocks)-1].Header.Number.Uint64()
+		converted, err := dst.LoadProverInputRange(ctx, chainID, blockNumber, lastBlockNumber)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load converted prover input: %w", err)
+		}
+		return lastBlockNumber, checkRoundTrip(data, converted)
+	})
+}
+
+// loadProverInputFrom loads the prover input starting at blockNumber, either covering the single block
+// or a range of blocks ending at most at maxBlockNumber
+func loadProverInputFrom(ctx context.Context, s ProverInputStore, chainID, blockNumber, maxBlockNumber uint64) (*input.ProverInput, error) {
+	data, err := s.LoadProverInput(ctx, chainID, blockNumber)
+	if !errors.Is(err, store.ErrNotFound) {
+		return data, err
+	}
+	for last := maxBlockNumber; last > blockNumber; last-- {
+		data, err := s.LoadProverInputRange(ctx, chainID, blockNumber, last)
+		if !errors.Is(err, store.ErrNotFound) {
+			return data, err
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+// ConvertPreflightData converts the preflight data of the blocks [from, to] of a chain from a store to another.
+//
+// It behaves as ConvertProverInputs.
+func ConvertPreflightData(ctx context.Context, src, dst PreflightDataStore, chainID, from, to uint64) ([]uint64, error) {
+	return convertRange(from, to, func(blockNumber uint64) (uint64, error) {
+		data, err := src.LoadPreflightData(ctx, chainID, blockNumber)
+		if err != nil {
+			return 0, err
+		}
+		if err := dst.StorePreflightData(ctx, data); err != nil {
+			return 0, fmt.Errorf("failed to store preflight data: %w", err)
+		}
+		converted, err := dst.LoadPreflightData(ctx, chainID, blockNumber)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load converted preflight data: %w", err)
+		}
+		return blockNumber, checkRoundTrip(data, converted)
+	})
+}
+
+// convertRange converts the data of the blocks [from, to], convert returning the last block covered by the converted data
+func convertRange(from, to uint64, convert func(blockNumber uint64) (uint64, error)) ([]uint64, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid block range: %d > %d", from, to)
+	}
+
+	var converted []uint64
+	for blockNumber := from; blockNumber <= to; blockNumber++ {
+		last, err := convert(blockNumber)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			continue
+		case err != nil:
+			return converted, fmt.Errorf("block %d: %w", blockNumber, err)
+		}
+		for n := blockNumber; n <= last; n++ {
+			converted = append(converted, n)
+		}
+		blockNumber = last
+	}
+	return converted, nil
+}
+
+// checkRoundTrip checks that converted data is equal to the original data
+//
+// Data is compared on its JSON encoding, which covers every field of prover inputs and preflight data.
+// As some encodings (e.g. protobuf) do not distinguish unset from empty values, null values and empty lists and objects are ignored.
+func checkRoundTrip(original, converted any) error {
+	a, err := normalizedJSON(original)
+	if err != nil {
+		return fmt.Errorf("failed to encode original data: %w", err)
+	}
+	b, err := normalizedJSON(converted)
+	if err != nil {
+		return fmt.Errorf("failed to encode converted data: %w", err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		return fmt.Errorf("round-trip mismatch: converted data differs from original data")
+	}
+	return nil
+}
+
+func normalizedJSON(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return nil, err
+	}
+	return dropEmpty(decoded), nil
+}
+
+// dropEmpty recursively drops null values and empty lists and objects (returning nil if v is empty)
+func dropEmpty(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, elem := range v {
+			if elem = dropEmpty(elem); elem == nil {
+				delete(v, k)
+			} else {
+				v[k] = elem
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		return v
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		for i, elem := range v {
+			v[i] = dropEmpty(elem)
+		}
+		return v
+	default:
+		return v
+	}
+}
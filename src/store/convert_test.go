@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/kkrt-labs/go-utils/store"
+	compressstore "github.com/kkrt-labs/go-utils/store/compress"
+	memorystore "github.com/kkrt-labs/go-utils/store/memory"
+	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProverInputStore(t *testing.T, s store.Store, ct ContentType, ce store.ContentEncoding) ProverInputStore {
+	compressed, err := compressstore.New(s, compressstore.WithContentEncoding(ce))
+	require.NoError(t, err)
+	return NewProverInputStore(compressed, ct)
+}
+
+func TestConvertProverInputs(t *testing.T) {
+	ctx := context.TODO()
+	s := memorystore.New()
+	src := newTestProverInputStore(t, s, ContentTypeJSON, store.ContentEncodingPlain)
+	dst := newTestProverInputStore(t, s, ContentTypeProtobuf, store.ContentEncodingGzip)
+
+	for _, blockNumber := range []int64{10, 12} {
+		require.NoError(t, src.StoreProverInput(ctx, &input.ProverInput{
+			Version:     input.SchemaVersion,
+			ChainConfig: &params.ChainConfig{ChainID: big.NewInt(2)},
+			Blocks: []*input.Block{
+				{
+					Header: &gethtypes.Header{
+						Number:          big.NewInt(blockNumber),
+						Difficulty:      big.NewInt(15),
+						BaseFee:         big.NewInt(15),
+						WithdrawalsHash: &gethcommon.Hash{0x1},
+					},
+					Transactions: []*gethtypes.Transaction{},
+					Uncles:       []*gethtypes.Header{},
+					Withdrawals:  []*gethtypes.Withdrawal{},
+				},
+			},
+			Witness: &input.Witness{
+				State:     [][]byte{{0x01}},
+				Ancestors: []*gethtypes.Header{},
+				Codes:     [][]byte{},
+			},
+		}))
+	}
+
+	converted, err := ConvertProverInputs(ctx, src, dst, 2, 10, 12)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10, 12}, converted)
+
+	loaded, err := dst.LoadProverInput(ctx, 2, 12)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(12), loaded.Blocks[0].Header.Number)
+
+	_, err = ConvertProverInputs(ctx, src, dst, 2, 12, 10)
+	assert.Error(t, err)
+}
+
+func TestConvertMultiBlockProverInputs(t *testing.T) {
+	ctx := context.TODO()
+	s := memorystore.New()
+	src := newTestProverInputStore(t, s, ContentTypeJSON, store.ContentEncodingPlain)
+	dst := newTestProverInputStore(t, s, ContentTypeProtobuf, store.ContentEncodingGzip)
+
+	// Prover inputs of the single block 10 and of the range of blocks [11, 13]
+	for _, blocks := range [][]int64{{10}, {11, 12, 13}} {
+		in := &input.ProverInput{
+			Version:     input.SchemaVersion,
+			ChainConfig: &params.ChainConfig{ChainID: big.NewInt(2)},
+			Witness: &input.Witness{
+				State:     [][]byte{{0x01}},
+				Ancestors: []*gethtypes.Header{},
+				Codes:     [][]byte{},
+			},
+		}
+		for _, blockNumber := range blocks {
+			in.Blocks = append(in.Blocks, &input.Block{
+				Header: &gethtypes.Header{
+					Number:          big.NewInt(blockNumber),
+					Difficulty:      big.NewInt(0),
+					BaseFee:         big.NewInt(15),
+					WithdrawalsHash: &gethcommon.Hash{0x1},
+				},
+				Transactions: []*gethtypes.Transaction{},
+				Uncles:       []*gethtypes.Header{},
+				Withdrawals:  []*gethtypes.Withdrawal{},
+			})
+		}
+		require.NoError(t, src.StoreProverInput(ctx, in))
+	}
+
+	converted, err := ConvertProverInputs(ctx, src, dst, 2, 10, 14)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10, 11, 12, 13}, converted)
+
+	loaded, err := dst.LoadProverInputRange(ctx, 2, 11, 13)
+	require.NoError(t, err)
+	require.Len(t, loaded.Blocks, 3)
+	assert.Equal(t, big.NewInt(13), loaded.Blocks[2].Header.Number)
+
+	// A range of blocks exceeding the converted range is not converted
+	converted, err = ConvertProverInputs(ctx, src, dst, 2, 11, 12)
+	require.NoError(t, err)
+	assert.Empty(t, converted)
+}
+
+func TestCheckRoundTrip(t *testing.T) {
+	assert.NoError(t, checkRoundTrip(&input.ProverInput{Version: "1.0.0"}, &input.ProverInput{Version: "1.0.0"}))
+	assert.Error(t, checkRoundTrip(&input.ProverInput{Version: "1.0.0"}, &input.ProverInput{Version: "1.1.0"}))
+
+	// Unset and empty values are not distinguished
+	assert.NoError(t, checkRoundTrip(
+		&input.ProverInput{Witness: &input.Witness{Ancestors: []*gethtypes.Header{}}},
+		&input.ProverInput{Witness: &input.Witness{}},
+	))
+}
@@ -4,13 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
 	store "github.com/kkrt-labs/go-utils/store"
 	input "github.com/kkrt-labs/zk-pig/src/prover-input"
+	"github.com/kkrt-labs/zk-pig/src/prover-input/execwitness"
 	protoinput "github.com/kkrt-labs/zk-pig/src/prover-input/proto"
-	"google.golang.org/protobuf/proto"
 )
 
 //go:generate mockgen -destination=./mock/input_store.go -package=mockstore github.com/kkrt-labs/zk-pig/src/store ProverInputStore
@@ -21,76 +22,133 @@ type ProverInputStore interface {
 	StoreProverInput(ctx context.Context, inputs *input.ProverInput) error
 
 	// LoadProverInput loads the prover inputs for a block.
-	// format can be "protobuf" or "json"
 	LoadProverInput(ctx context.Context, chainID, blockNumber uint64) (*input.ProverInput, error)
+
+	// LoadProverInputRange loads the prover inputs covering the consecutive blocks [from, to] (as stored for multi-block prover inputs).
+	// If from == to, it behaves as LoadProverInput.
+	LoadProverInputRange(ctx context.Context, chainID, from, to uint64) (*input.ProverInput, error)
 }
 
 type proverInputStore struct {
 	store       store.Store
-	contentType store.ContentType
+	contentType ContentType
 }
 
-func NewProverInputStore(s store.Store, contentType store.ContentType) ProverInputStore {
+func NewProverInputStore(s store.Store, contentType ContentType) ProverInputStore {
 	return &proverInputStore{store: s, contentType: contentType}
 }
 
 func (s *proverInputStore) StoreProverInput(ctx context.Context, data *input.ProverInput) error {
-	buf := new(bytes.Buffer)
-	switch s.contentType {
-	case store.ContentTypeProtobuf:
-		protoMsg := protoinput.ToProto(data)
-		protoBytes, err := proto.Marshal(protoMsg)
-		if err != nil {
-			return fmt.Errorf("failed to marshal protobuf: %w", err)
-		}
-		buf.Write(protoBytes)
-	case store.ContentTypeJSON:
-		if err := json.NewEncoder(buf).Encode(data); err != nil {
-			return fmt.Errorf("failed to encode JSON: %w", err)
-		}
-	default:
-		return fmt.Errorf("unsupported content type: %s", s.contentType)
-	}
-
-	path := s.path(data.ChainConfig.ChainID.Uint64(), data.Blocks[0].Header.Number.Uint64())
+	chainID, blockNumber := data.ChainConfig.ChainID.Uint64(), data.Blocks[0].Header.Number.Uint64()
+	path := s.path(chainID, blockNumber)
 	headers := &store.Headers{
-		ContentType:     s.contentType,
+		ContentType:     s.contentType.StoreContentType(),
 		ContentEncoding: store.ContentEncodingPlain,
 		KeyValue: map[string]string{
-			"chain.id":     fmt.Sprintf("%d", data.ChainConfig.ChainID.Uint64()),
-			"block.number": fmt.Sprintf("%d", data.Blocks[0].Header.Number.Uint64()),
+			"chain.id":     fmt.Sprintf("%d", chainID),
+			"block.number": fmt.Sprintf("%d", blockNumber),
 		},
 	}
-	return s.store.Store(ctx, path, bytes.NewReader(buf.Bytes()), headers)
+
+	// Prover inputs covering multiple blocks are stored under the range of blocks they cover
+	if len(data.Blocks) > 1 {
+		lastBlockNumber := data.Blocks[len(data.Blocks)-1].Header.Number.Uint64()
+		path = s.rangePath(chainID, blockNumber, lastBlockNumber)
+		headers.KeyValue["block.count"] = fmt.Sprintf("%d", len(data.Blocks))
+	}
+
+	// Protobuf streams are encoded while being stored, so the whole encoding is never held in memory
+	if s.contentType == ContentTypeProtobufStream {
+		return s.storeStream(ctx, path, data, headers)
+	}
+
+	b, err := MarshalProverInput(s.contentType, data)
+	if err != nil {
+		return err
+	}
+	return s.store.Store(ctx, path, bytes.NewReader(b), headers)
+}
+
+func (s *proverInputStore) storeStream(ctx context.Context, path string, data *input.ProverInput, headers *store.Headers) error {
+	pr, pw := io.Pipe()
+	errC := make(chan error, 1)
+	go func() {
+		err := protoinput.WriteStream(pw, data, protoinput.DefaultStreamChunkSize)
+		pw.CloseWithError(err)
+		errC <- err
+	}()
+
+	err := s.store.Store(ctx, path, pr, headers)
+	pr.CloseWithError(io.ErrClosedPipe) // unblocks the encoder if the store stopped reading early
+	if encodeErr := <-errC; encodeErr != nil && !errors.Is(encodeErr, io.ErrClosedPipe) {
+		return fmt.Errorf("failed to write protobuf stream: %w", encodeErr)
+	}
+	return err
 }
 
 func (s *proverInputStore) LoadProverInput(ctx context.Context, chainID, blockNumber uint64) (*input.ProverInput, error) {
-	path := s.path(chainID, blockNumber)
+	return s.load(ctx, s.path(chainID, blockNumber))
+}
+
+func (s *proverInputStore) LoadProverInputRange(ctx context.Context, chainID, from, to uint64) (*input.ProverInput, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid block range: %d > %d", from, to)
+	}
+
+	path := s.path(chainID, from)
+	if to > from {
+		path = s.rangePath(chainID, from, to)
+	}
+
+	data, err := s.load(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(data.Blocks)) != to-from+1 {
+		return nil, fmt.Errorf("prover input covers %d blocks, expected %d", len(data.Blocks), to-from+1)
+	}
+	return data, nil
+}
+
+func (s *proverInputStore) load(ctx context.Context, path string) (*input.ProverInput, error) {
 	reader, _, err := s.store.Load(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load data from store: %w", err)
 	}
 	defer reader.Close()
 
-	data := &input.ProverInput{}
-
+	var data *input.ProverInput
 	switch s.contentType {
-	case store.ContentTypeJSON:
+	case ContentTypeProtobufStream:
+		// Protobuf streams are decoded while being read (the stream end is known from its header,
+		// so data missing the compression stream trailer is not an issue, see readAll)
+		if data, err = protoinput.ReadStream(reader); err != nil {
+			return nil, fmt.Errorf("failed to read protobuf stream: %w", err)
+		}
+	case ContentTypeJSON:
+		data = &input.ProverInput{}
 		if err := json.NewDecoder(reader).Decode(data); err != nil {
 			return nil, fmt.Errorf("failed to decode JSON: %w", err)
 		}
-	case store.ContentTypeProtobuf:
-		protoBytes, err := io.ReadAll(reader)
+	case ContentTypeExecutionWitness:
+		execWitness := &execwitness.ProverInput{}
+		if err := json.NewDecoder(reader).Decode(execWitness); err != nil {
+			return nil, fmt.Errorf("failed to decode execution witness: %w", err)
+		}
+		data = execwitness.FromExecWitness(execWitness)
+	default:
+		b, err := readAll(reader)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read protobuf data: %w", err)
+			return nil, fmt.Errorf("failed to read data: %w", err)
 		}
-		protoMsg := &protoinput.ProverInput{}
-		if err := proto.Unmarshal(protoBytes, protoMsg); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal protobuf: %w", err)
+		if data, err = UnmarshalProverInput(s.contentType, b); err != nil {
+			return nil, err
 		}
-		data = protoinput.FromProto(protoMsg)
-	default:
-		return nil, fmt.Errorf("unsupported content type: %s", s.contentType)
+	}
+
+	// Prover inputs stored with an older schema version are upgraded to the current one
+	if err := input.Migrate(data); err != nil {
+		return nil, fmt.Errorf("incompatible prover input: %w", err)
 	}
 
 	return data, nil
@@ -100,6 +158,10 @@ func (s *proverInputStore) path(chainID, blockNumber uint64) string {
 	return s.contentType.FilePath(fmt.Sprintf("/%d/%d/zkpi", chainID, blockNumber))
 }
 
+func (s *proverInputStore) rangePath(chainID, fromBlockNumber, toBlockNumber uint64) string {
+	return s.contentType.FilePath(fmt.Sprintf("/%d/%d-%d/zkpi", chainID, fromBlockNumber, toBlockNumber))
+}
+
 type noOpProverInputStore struct{}
 
 func (s *noOpProverInputStore) StoreProverInput(_ context.Context, _ *input.ProverInput) error {
@@ -110,6 +172,10 @@ func (s *noOpProverInputStore) LoadProverInput(_ context.Context, _, _ uint64) (
 	return nil, nil
 }
 
+func (s *noOpProverInputStore) LoadProverInputRange(_ context.Context, _, _, _ uint64) (*input.ProverInput, error) {
+	return nil, nil
+}
+
 func NewNoOpProverInputStore() ProverInputStore {
 	return &noOpProverInputStore{}
 }
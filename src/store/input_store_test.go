@@ -3,18 +3,23 @@ package store
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"fmt"
 	"io"
 	"math/big"
+	"runtime"
+	"runtime/debug"
 	"testing"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/kkrt-labs/go-utils/store"
+	memorystore "github.com/kkrt-labs/go-utils/store/memory"
 	mockstore "github.com/kkrt-labs/go-utils/store/mock"
 	input "github.com/kkrt-labs/zk-pig/src/prover-input"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -26,25 +31,46 @@ func TestProverInputStore(t *testing.T) {
 
 	testCases := []struct {
 		desc        string
-		contentType store.ContentType
+		contentType ContentType
 		chainID     int64
 		blockNumber int64
 		expectedKey string
 	}{
 		{
 			desc:        "JSON Plain File",
-			contentType: store.ContentTypeJSON,
+			contentType: ContentTypeJSON,
 			chainID:     2,
 			blockNumber: 15,
 			expectedKey: "/2/15/zkpi.json",
 		},
 		{
 			desc:        "Protobuf Plain File",
-			contentType: store.ContentTypeProtobuf,
+			contentType: ContentTypeProtobuf,
 			chainID:     2,
 			blockNumber: 15,
 			expectedKey: "/2/15/zkpi.protobuf",
 		},
+		{
+			desc:        "Execution Witness Plain File",
+			contentType: ContentTypeExecutionWitness,
+			chainID:     2,
+			blockNumber: 15,
+			expectedKey: "/2/15/zkpi.witness.json",
+		},
+		{
+			desc:        "SSZ Plain File",
+			contentType: ContentTypeSSZ,
+			chainID:     2,
+			blockNumber: 15,
+			expectedKey: "/2/15/zkpi.ssz",
+		},
+		{
+			desc:        "Protobuf Stream Plain File",
+			contentType: ContentTypeProtobufStream,
+			chainID:     2,
+			blockNumber: 15,
+			expectedKey: "/2/15/zkpi.stream.pb",
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -71,7 +97,7 @@ func TestProverInputStore(t *testing.T) {
 			var dataCache []byte
 			ctx := context.TODO()
 			mockStore.EXPECT().Store(ctx, tt.expectedKey, gomock.Any(), &store.Headers{
-				ContentType:     tt.contentType,
+				ContentType:     tt.contentType.StoreContentType(),
 				ContentEncoding: store.ContentEncodingPlain,
 				KeyValue: map[string]string{
 					"chain.id":     fmt.Sprintf("%d", in.ChainConfig.ChainID.Uint64()),
@@ -94,6 +120,140 @@ func TestProverInputStore(t *testing.T) {
 	}
 }
 
+func TestProverInputStoreStreamError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockstore.NewMockStore(ctrl)
+	inputStore := NewProverInputStore(mockStore, ContentTypeProtobufStream)
+
+	in := &input.ProverInput{
+		ChainConfig: &params.ChainConfig{ChainID: big.NewInt(2)},
+		Blocks:      []*input.Block{{Header: &gethtypes.Header{Number: big.NewInt(15), Difficulty: big.NewInt(15)}}},
+		Witness:     &input.Witness{State: [][]byte{bytes.Repeat([]byte{0x1}, 1<<16)}},
+	}
+
+	// The store fails without reading the stream, which must not block the encoder
+	mockStore.EXPECT().Store(gomock.Any(), "/2/15/zkpi.stream.pb", gomock.Any(), gomock.Any()).Return(fmt.Errorf("store error"))
+	err := inputStore.StoreProverInput(context.TODO(), in)
+	assert.EqualError(t, err, "store error")
+}
+
+func TestProverInputStoreMultiBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockstore.NewMockStore(ctrl)
+	inputStore := NewProverInputStore(mockStore, ContentTypeJSON)
+
+	in := &input.ProverInput{
+		ChainConfig: &params.ChainConfig{
+			ChainID: big.NewInt(2),
+		},
+	}
+	for i := int64(15); i < 18; i++ {
+		in.Blocks = append(in.Blocks, &input.Block{
+			Header: &gethtypes.Header{
+				Number:          big.NewInt(i),
+				Difficulty:      big.NewInt(15),
+				BaseFee:         big.NewInt(15),
+				WithdrawalsHash: &gethcommon.Hash{0x1},
+			},
+		})
+	}
+
+	ctx := context.TODO()
+	mockStore.EXPECT().Store(ctx, "/2/15-17/zkpi.json", gomock.Any(), &store.Headers{
+		ContentType:     store.ContentTypeJSON,
+		ContentEncoding: store.ContentEncodingPlain,
+		KeyValue: map[string]string{
+			"chain.id":     "2",
+			"block.number": "15",
+			"block.count":  "3",
+		},
+	}).Return(nil)
+
+	err := inputStore.StoreProverInput(ctx, in)
+	assert.NoError(t, err)
+}
+
+func TestProverInputStoreMultiBlockRoundTrip(t *testing.T) {
+	for _, ct := range []ContentType{
+		ContentTypeJSON,
+		ContentTypeProtobuf,
+		ContentTypeExecutionWitness,
+		ContentTypeSSZ,
+		ContentTypeProtobufStream,
+	} {
+		t.Run(ct.String(), func(t *testing.T) {
+			ctx := context.TODO()
+			inputStore := newTestProverInputStore(t, memorystore.New(), ct, store.ContentEncodingGzip)
+
+			in := &input.ProverInput{
+				Version:     input.SchemaVersion,
+				ChainConfig: &params.ChainConfig{ChainID: big.NewInt(2)},
+				Witness: &input.Witness{
+					State:     [][]byte{{0x01}, {0x02}},
+					Ancestors: []*gethtypes.Header{},
+					Codes:     [][]byte{{0x60, 0x00}},
+				},
+				Extra: &input.Extra{},
+			}
+			for i := int64(15); i < 17; i++ {
+				in.Blocks = append(in.Blocks, &input.Block{
+					Header: &gethtypes.Header{
+						Number:          big.NewInt(i),
+						Difficulty:      big.NewInt(0),
+						BaseFee:         big.NewInt(15),
+						WithdrawalsHash: &gethcommon.Hash{0x1},
+					},
+					Transactions: []*gethtypes.Transaction{},
+					Uncles:       []*gethtypes.Header{},
+					Withdrawals:  []*gethtypes.Withdrawal{},
+				})
+			}
+			require.NoError(t, inputStore.StoreProverInput(ctx, in))
+
+			loaded, err := inputStore.LoadProverInputRange(ctx, 2, 15, 16)
+			require.NoError(t, err)
+			require.Len(t, loaded.Blocks, 2)
+			assert.Equal(t, uint64(15), loaded.Blocks[0].Header.Number.Uint64())
+			assert.Equal(t, uint64(16), loaded.Blocks[1].Header.Number.Uint64())
+			assert.NoError(t, checkRoundTrip(in, loaded))
+
+			// The multi-block prover input is not stored under its first block
+			_, err = inputStore.LoadProverInput(ctx, 2, 15)
+			assert.ErrorIs(t, err, store.ErrNotFound)
+
+			// The range must match the blocks covered by the prover input
+			_, err = inputStore.LoadProverInputRange(ctx, 2, 15, 17)
+			assert.ErrorIs(t, err, store.ErrNotFound)
+			_, err = inputStore.LoadProverInputRange(ctx, 2, 16, 15)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestProverInputStoreVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockstore.NewMockStore(ctrl)
+	inputStore := NewProverInputStore(mockStore, ContentTypeJSON)
+	ctx := context.TODO()
+
+	// Unversioned prover inputs are migrated to the current version
+	mockStore.EXPECT().Load(ctx, "/2/15/zkpi.json").Return(io.NopCloser(bytes.NewReader([]byte(`{"version":""}`))), nil, nil)
+	loaded, err := inputStore.LoadProverInput(ctx, 2, 15)
+	assert.NoError(t, err)
+	assert.Equal(t, input.SchemaVersion, loaded.Version)
+
+	// Prover inputs with an incompatible version are rejected
+	mockStore.EXPECT().Load(ctx, "/2/15/zkpi.json").Return(io.NopCloser(bytes.NewReader([]byte(`{"version":"99.0.0"}`))), nil, nil)
+	_, err = inputStore.LoadProverInput(ctx, 2, 15)
+	assert.Error(t, err)
+}
+
 func TestNoOpProverInputStore(t *testing.T) {
 	noOpStore := NewNoOpProverInputStore()
 	// Should implement interface
@@ -103,4 +263,57 @@ func TestNoOpProverInputStore(t *testing.T) {
 	loaded, err := noOpStore.LoadProverInput(context.TODO(), 1, 1)
 	assert.Nil(t, loaded)
 	assert.NoError(t, err)
+
+	loaded, err = noOpStore.LoadProverInputRange(context.TODO(), 1, 1, 2)
+	assert.Nil(t, loaded)
+	assert.NoError(t, err)
+}
+
+func TestProverInputStoreStreamMemory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defer debug.SetGCPercent(debug.SetGCPercent(1))
+
+	// Witness of 32MiB made of 1KiB random state nodes (so it does not compress)
+	state := make([][]byte, 32<<10)
+	for i := range state {
+		state[i] = make([]byte, 1<<10)
+		_, _ = rand.Read(state[i])
+	}
+	in := &input.ProverInput{
+		ChainConfig: &params.ChainConfig{ChainID: big.NewInt(2)},
+		Blocks:      []*input.Block{{Header: &gethtypes.Header{Number: big.NewInt(15), Difficulty: big.NewInt(15)}}},
+		Witness:     &input.Witness{State: state},
+	}
+
+	mockStore := mockstore.NewMockStore(ctrl)
+	inputStore := NewProverInputStore(mockStore, ContentTypeProtobufStream)
+
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	base, peak := stats.HeapAlloc, stats.HeapAlloc
+
+	// The underlying store reads and discards the stream, while recording the peak heap allocation
+	mockStore.EXPECT().Store(gomock.Any(), "/2/15/zkpi.stream.pb", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, reader io.Reader, _ *store.Headers) error {
+			buf := make([]byte, 32<<10)
+			for {
+				_, err := reader.Read(buf)
+				runtime.ReadMemStats(&stats)
+				peak = max(peak, stats.HeapAlloc)
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+		},
+	)
+	err := inputStore.StoreProverInput(context.TODO(), in)
+	require.NoError(t, err)
+
+	// The stream is never held in memory as a whole (only chunks of it)
+	assert.Less(t, peak-base, uint64(8<<20), "peak heap allocation while storing")
 }
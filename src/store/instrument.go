@@ -37,6 +37,11 @@ func (s *taggedProverInputStore) LoadProverInput(ctx context.Context, chainID, b
 	return s.s.LoadProverInput(s.context(ctx, chainID, blockNumber), chainID, blockNumber)
 }
 
+func (s *taggedProverInputStore) LoadProverInputRange(ctx context.Context, chainID, from, to uint64) (*input.ProverInput, error) {
+	ctx = tag.WithTags(s.context(ctx, chainID, from), tag.Key("block.count").Int64(int64(to-from+1)))
+	return s.s.LoadProverInputRange(ctx, chainID, from, to)
+}
+
 func (s *taggedProverInputStore) context(ctx context.Context, chainID, blockNumber uint64) context.Context {
 	return s.tagged.Context(ctx, tag.Key("chain.id").Int64(int64(chainID)), tag.Key("block.number").Int64(int64(blockNumber)))
 }
@@ -71,6 +76,16 @@ func (s *loggedProverInputStore) LoadProverInput(ctx context.Context, chainID, b
 	return inputs, err
 }
 
+func (s *loggedProverInputStore) LoadProverInputRange(ctx context.Context, chainID, from, to uint64) (*input.ProverInput, error) {
+	log.LoggerFromContext(ctx).Debug("Loading prover input")
+	inputs, err := s.s.LoadProverInputRange(ctx, chainID, from, to)
+	if err != nil {
+		log.LoggerFromContext(ctx).Error("Failed to load prover input", zap.Error(err))
+	}
+	log.LoggerFromContext(ctx).Debug("Prover input successfully loaded")
+	return inputs, err
+}
+
 type taggedPreflightDataStore struct {
 	s      PreflightDataStore
 	tagged *svc.Tagged
@@ -56,6 +56,21 @@ func (mr *MockProverInputStoreMockRecorder) LoadProverInput(ctx, chainID, blockN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadProverInput", reflect.TypeOf((*MockProverInputStore)(nil).LoadProverInput), ctx, chainID, blockNumber)
 }
 
+// LoadProverInputRange mocks base method.
+func (m *MockProverInputStore) LoadProverInputRange(ctx context.Context, chainID, from, to uint64) (*input.ProverInput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadProverInputRange", ctx, chainID, from, to)
+	ret0, _ := ret[0].(*input.ProverInput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadProverInputRange indicates an expected call of LoadProverInputRange.
+func (mr *MockProverInputStoreMockRecorder) LoadProverInputRange(ctx, chainID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadProverInputRange", reflect.TypeOf((*MockProverInputStore)(nil).LoadProverInputRange), ctx, chainID, from, to)
+}
+
 // StoreProverInput mocks base method.
 func (m *MockProverInputStore) StoreProverInput(ctx context.Context, inputs *input.ProverInput) error {
 	m.ctrl.T.Helper()
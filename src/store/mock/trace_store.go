@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kkrt-labs/zk-pig/src/store (interfaces: TraceStore)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/trace_store.go -package=mockstore github.com/kkrt-labs/zk-pig/src/store TraceStore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTraceStore is a mock of TraceStore interface.
+type MockTraceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTraceStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockTraceStoreMockRecorder is the mock recorder for MockTraceStore.
+type MockTraceStoreMockRecorder struct {
+	mock *MockTraceStore
+}
+
+// NewMockTraceStore creates a new mock instance.
+func NewMockTraceStore(ctrl *gomock.Controller) *MockTraceStore {
+	mock := &MockTraceStore{ctrl: ctrl}
+	mock.recorder = &MockTraceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTraceStore) EXPECT() *MockTraceStoreMockRecorder {
+	return m.recorder
+}
+
+// StoreTxTrace mocks base method.
+func (m *MockTraceStore) StoreTxTrace(ctx context.Context, chainID, fromBlock, toBlock, blockNumber uint64, txIndex int, txHash common.Hash, trace io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreTxTrace", ctx, chainID, fromBlock, toBlock, blockNumber, txIndex, txHash, trace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreTxTrace indicates an expected call of StoreTxTrace.
+func (mr *MockTraceStoreMockRecorder) StoreTxTrace(ctx, chainID, fromBlock, toBlock, blockNumber, txIndex, txHash, trace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreTxTrace", reflect.TypeOf((*MockTraceStore)(nil).StoreTxTrace), ctx, chainID, fromBlock, toBlock, blockNumber, txIndex, txHash, trace)
+}
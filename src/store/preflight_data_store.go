@@ -3,7 +3,7 @@ package store
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 
 	store "github.com/kkrt-labs/go-utils/store"
@@ -21,28 +21,37 @@ type PreflightDataStore interface {
 	LoadPreflightData(ctx context.Context, chainID, blockNumber uint64) (*steps.PreflightData, error)
 }
 
-// NewPreflightDataStore creates a new PreflightDataStore instance
-func NewPreflightDataStore(store store.Store) (PreflightDataStore, error) {
+// NewPreflightDataStore creates a new PreflightDataStore instance storing preflight data in the given content type
+// (store.ContentTypeJSON or store.ContentTypeProtobuf, see PreflightContentType)
+func NewPreflightDataStore(s store.Store, contentType store.ContentType) (PreflightDataStore, error) {
+	switch contentType {
+	case store.ContentTypeJSON, store.ContentTypeProtobuf:
+	default:
+		return nil, fmt.Errorf("unsupported preflight data content type: %s", contentType)
+	}
+
 	return &preflightDataStore{
-		store: store,
+		store:       s,
+		contentType: contentType,
 	}, nil
 }
 
 type preflightDataStore struct {
-	store store.Store
+	store       store.Store
+	contentType store.ContentType
 }
 
 func (s *preflightDataStore) StorePreflightData(ctx context.Context, data *steps.PreflightData) error {
 	chainID := data.ChainConfig.ChainID.Uint64()
 	blockNumber := data.Block.Number.ToInt().Uint64()
-	path := s.path(chainID, blockNumber)
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(data); err != nil {
-		return fmt.Errorf("failed to encode JSON: %w", err)
+	path := preflightDataPath(s.contentType, chainID, blockNumber)
+	b, err := MarshalPreflightData(s.contentType, data)
+	if err != nil {
+		return err
 	}
-	reader := bytes.NewReader(buf.Bytes())
+	reader := bytes.NewReader(b)
 	headers := store.Headers{
-		ContentType:     store.ContentTypeJSON,
+		ContentType:     s.contentType,
 		ContentEncoding: store.ContentEncodingPlain,
 		KeyValue: map[string]string{
 			"chain.id":     fmt.Sprintf("%d", chainID),
@@ -52,23 +61,34 @@ func (s *preflightDataStore) StorePreflightData(ctx context.Context, data *steps
 	return s.store.Store(ctx, path, reader, &headers)
 }
 
+// LoadPreflightData loads preflight data for a block.
+//
+// Preflight data stored before protobuf encoding was supported is always JSON encoded,
+// so if it is not found in protobuf it falls back to loading it from JSON.
 func (s *preflightDataStore) LoadPreflightData(ctx context.Context, chainID, blockNumber uint64) (*steps.PreflightData, error) {
-	path := s.path(chainID, blockNumber)
-	data := &steps.PreflightData{}
-	reader, _, err := s.store.Load(ctx, path)
+	data, err := s.load(ctx, s.contentType, chainID, blockNumber)
+	if errors.Is(err, store.ErrNotFound) && s.contentType != store.ContentTypeJSON {
+		return s.load(ctx, store.ContentTypeJSON, chainID, blockNumber)
+	}
+	return data, err
+}
+
+func (s *preflightDataStore) load(ctx context.Context, contentType store.ContentType, chainID, blockNumber uint64) (*steps.PreflightData, error) {
+	reader, _, err := s.store.Load(ctx, preflightDataPath(contentType, chainID, blockNumber))
 	if err != nil {
 		return nil, err
 	}
 	defer reader.Close()
 
-	if err := json.NewDecoder(reader).Decode(data); err != nil {
-		return nil, err
+	b, err := readAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data: %w", err)
 	}
-	return data, nil
+	return UnmarshalPreflightData(contentType, b)
 }
 
-func (s *preflightDataStore) path(chainID, blockNumber uint64) string {
-	return fmt.Sprintf("/%d/%d/preflight.json", chainID, blockNumber)
+func preflightDataPath(contentType store.ContentType, chainID, blockNumber uint64) string {
+	return contentType.FilePath(fmt.Sprintf("/%d/%d/preflight", chainID, blockNumber))
 }
 
 type noOpPreflightDataStore struct{}
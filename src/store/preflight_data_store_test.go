@@ -11,18 +11,34 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/kkrt-labs/go-utils/ethereum/rpc"
 	store "github.com/kkrt-labs/go-utils/store"
+	memorystore "github.com/kkrt-labs/go-utils/store/memory"
 	mockstore "github.com/kkrt-labs/go-utils/store/mock"
 	"github.com/kkrt-labs/zk-pig/src/steps"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
 func TestPreflightDataStore(t *testing.T) {
+	for _, tt := range []struct {
+		contentType store.ContentType
+		path        string
+	}{
+		{store.ContentTypeJSON, "/1/10/preflight.json"},
+		{store.ContentTypeProtobuf, "/1/10/preflight.protobuf"},
+	} {
+		t.Run(tt.contentType.String(), func(t *testing.T) {
+			testPreflightDataStore(t, tt.contentType, tt.path)
+		})
+	}
+}
+
+func testPreflightDataStore(t *testing.T, contentType store.ContentType, path string) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockStore := mockstore.NewMockStore(ctrl)
-	preflightDataStore, err := NewPreflightDataStore(mockStore)
+	preflightDataStore, err := NewPreflightDataStore(mockStore, contentType)
 	assert.NoError(t, err)
 
 	// Test PreflightData
@@ -40,8 +56,8 @@ func TestPreflightDataStore(t *testing.T) {
 	// Test storing and loading PreflightData
 	var dataCache []byte
 	ctx := context.TODO()
-	mockStore.EXPECT().Store(ctx, "/1/10/preflight.json", gomock.Any(), &store.Headers{
-		ContentType:     store.ContentTypeJSON,
+	mockStore.EXPECT().Store(ctx, path, gomock.Any(), &store.Headers{
+		ContentType:     contentType,
 		ContentEncoding: store.ContentEncodingPlain,
 		KeyValue: map[string]string{
 			"chain.id":     "1",
@@ -54,13 +70,45 @@ func TestPreflightDataStore(t *testing.T) {
 	err = preflightDataStore.StorePreflightData(ctx, preflightData)
 	assert.NoError(t, err)
 
-	mockStore.EXPECT().Load(ctx, "/1/10/preflight.json").Return(io.NopCloser(bytes.NewReader(dataCache)), nil, nil)
+	mockStore.EXPECT().Load(ctx, path).Return(io.NopCloser(bytes.NewReader(dataCache)), nil, nil)
 	loaded, err := preflightDataStore.LoadPreflightData(ctx, 1, 10)
 	assert.NoError(t, err)
 	assert.Equal(t, preflightData.ChainConfig.ChainID, loaded.ChainConfig.ChainID)
 	assert.Equal(t, preflightData.Block.Header.Number, loaded.Block.Header.Number)
 }
 
+func TestPreflightDataStoreJSONFallback(t *testing.T) {
+	ctx := context.TODO()
+	s := memorystore.New()
+
+	jsonStore, err := NewPreflightDataStore(s, store.ContentTypeJSON)
+	require.NoError(t, err)
+	protobufStore, err := NewPreflightDataStore(s, store.ContentTypeProtobuf)
+	require.NoError(t, err)
+
+	// Preflight data stored in JSON is loaded by a store configured with protobuf
+	preflightData := &steps.PreflightData{
+		ChainConfig: &params.ChainConfig{ChainID: big.NewInt(1)},
+		Block:       &rpc.Block{Header: rpc.Header{Number: (*hexutil.Big)(big.NewInt(10))}},
+	}
+	require.NoError(t, jsonStore.StorePreflightData(ctx, preflightData))
+
+	loaded, err := protobufStore.LoadPreflightData(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, preflightData.Block.Header.Number, loaded.Block.Header.Number)
+
+	// Preflight data stored in neither content type is not found
+	_, err = protobufStore.LoadPreflightData(ctx, 1, 11)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestPreflightDataStoreUnsupportedContentType(t *testing.T) {
+	_, err := NewPreflightDataStore(mockstore.NewMockStore(gomock.NewController(t)), store.ContentTypeText)
+	assert.Error(t, err)
+	assert.Equal(t, store.ContentTypeProtobuf, PreflightContentType(ContentTypeProtobufStream))
+	assert.Equal(t, store.ContentTypeJSON, PreflightContentType(ContentTypeSSZ))
+}
+
 func TestNoOpPreflightDataStore(t *testing.T) {
 	noOpStore := NewNoOpPreflightDataStore()
 	// Should implement interface
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/kkrt-labs/go-utils/store"
+)
+
+//go:generate mockgen -destination=./mock/trace_store.go -package=mockstore github.com/kkrt-labs/zk-pig/src/store TraceStore
+
+// TraceStore is a store for transaction execution traces.
+type TraceStore interface {
+	// StoreTxTrace stores the EIP-3155 JSON-lines trace of a transaction of a block,
+	// part of the prover input of the range of blocks [fromBlock, toBlock]
+	StoreTxTrace(ctx context.Context, chainID, fromBlock, toBlock, blockNumber uint64, txIndex int, txHash gethcommon.Hash, trace io.Reader) error
+}
+
+// NewTraceStore creates a new TraceStore.
+// Traces are stored next to the prover input of the block (or of the range of blocks), one file per transaction.
+func NewTraceStore(s store.Store) TraceStore {
+	return &traceStore{store: s}
+}
+
+type traceStore struct {
+	store store.Store
+}
+
+func (s *traceStore) StoreTxTrace(ctx context.Context, chainID, fromBlock, toBlock, blockNumber uint64, txIndex int, txHash gethcommon.Hash, trace io.Reader) error {
+	headers := &store.Headers{
+		ContentType:     store.ContentTypeText,
+		ContentEncoding: store.ContentEncodingPlain,
+		KeyValue: map[string]string{
+			"chain.id":     fmt.Sprintf("%d", chainID),
+			"block.number": fmt.Sprintf("%d", blockNumber),
+			"tx.index":     fmt.Sprintf("%d", txIndex),
+			"tx.hash":      txHash.Hex(),
+		},
+	}
+	return s.store.Store(ctx, s.path(chainID, fromBlock, toBlock, blockNumber, txIndex, txHash), trace, headers)
+}
+
+func (s *traceStore) path(chainID, fromBlock, toBlock, blockNumber uint64, txIndex int, txHash gethcommon.Hash) string {
+	if fromBlock == toBlock {
+		return fmt.Sprintf("/%d/%d/traces/%d-%s.jsonl", chainID, blockNumber, txIndex, txHash.Hex())
+	}
+	return fmt.Sprintf("/%d/%d-%d/traces/%d-%d-%s.jsonl", chainID, fromBlock, toBlock, blockNumber, txIndex, txHash.Hex())
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/kkrt-labs/go-utils/store"
+	mockstore "github.com/kkrt-labs/go-utils/store/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTraceStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockstore.NewMockStore(ctrl)
+	traceStore := NewTraceStore(mockStore)
+
+	txHash := gethcommon.HexToHash("0xabcd")
+	trace := []byte("{\"pc\":0,\"op\":96}\n")
+
+	ctx := context.TODO()
+	mockStore.EXPECT().Store(
+		ctx,
+		"/1/10/traces/2-0x000000000000000000000000000000000000000000000000000000000000abcd.jsonl",
+		gomock.Any(),
+		&store.Headers{
+			ContentType:     store.ContentTypeText,
+			ContentEncoding: store.ContentEncodingPlain,
+			KeyValue: map[string]string{
+				"chain.id":     "1",
+				"block.number": "10",
+				"tx.index":     "2",
+				"tx.hash":      txHash.Hex(),
+			},
+		}).DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ *store.Headers) error {
+		b, _ := io.ReadAll(reader)
+		assert.Equal(t, trace, b)
+		return nil
+	})
+
+	err := traceStore.StoreTxTrace(ctx, 1, 10, 10, 10, 2, txHash, bytes.NewReader(trace))
+	assert.NoError(t, err)
+}
+
+func TestTraceStoreBlockRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockstore.NewMockStore(ctrl)
+	traceStore := NewTraceStore(mockStore)
+
+	txHash := gethcommon.HexToHash("0xabcd")
+
+	ctx := context.TODO()
+	mockStore.EXPECT().Store(
+		ctx,
+		"/1/9-11/traces/10-2-0x000000000000000000000000000000000000000000000000000000000000abcd.jsonl",
+		gomock.Any(),
+		gomock.Any(),
+	).Return(nil)
+
+	err := traceStore.StoreTxTrace(ctx, 1, 9, 11, 10, 2, txHash, bytes.NewReader(nil))
+	assert.NoError(t, err)
+}